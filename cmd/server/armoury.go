@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// Armoury sells equipment-deck items to the heroes between quests. It opens once the quest has
+// ended and stays open until the next one starts. Items bought go straight into the hero's
+// carried items, so they travel into the next quest with the rest of the inventory.
+type Armoury struct {
+	contentManager   *ContentManager
+	inventoryManager *InventoryManager
+	turnManager      *TurnManager
+	heroLifecycle    *HeroLifecycleSystem
+	dynamicTurnOrder *DynamicTurnOrderManager
+	broadcaster      Broadcaster
+	logger           Logger
+}
+
+// NewArmoury creates a new armoury
+func NewArmoury(contentManager *ContentManager, inventoryManager *InventoryManager, turnManager *TurnManager, heroLifecycle *HeroLifecycleSystem, dynamicTurnOrder *DynamicTurnOrderManager, broadcaster Broadcaster, logger Logger) *Armoury {
+	return &Armoury{
+		contentManager:   contentManager,
+		inventoryManager: inventoryManager,
+		turnManager:      turnManager,
+		heroLifecycle:    heroLifecycle,
+		dynamicTurnOrder: dynamicTurnOrder,
+		broadcaster:      broadcaster,
+		logger:           logger,
+	}
+}
+
+// IsOpen reports whether the heroes can shop, which they can once the quest has ended
+func (a *Armoury) IsOpen() bool {
+	return a.dynamicTurnOrder.GetQuestOutcome() != nil
+}
+
+// GetStock returns the equipment cards for sale, cheapest first
+func (a *Armoury) GetStock() []*ItemCard {
+	stock := make([]*ItemCard, 0)
+	for _, item := range a.contentManager.GetAllEquipment() {
+		if item.Cost > 0 {
+			stock = append(stock, item)
+		}
+	}
+	sort.Slice(stock, func(i, j int) bool {
+		if stock[i].Cost != stock[j].Cost {
+			return stock[i].Cost < stock[j].Cost
+		}
+		return stock[i].ID < stock[j].ID
+	})
+	return stock
+}
+
+// Buy sells an equipment card to a player's hero, who must be able to use it and afford it
+func (a *Armoury) Buy(playerID, itemID string) error {
+	player, err := a.shopper(playerID)
+	if err != nil {
+		return err
+	}
+
+	item, ok := a.contentManager.GetEquipmentCard(itemID)
+	if !ok || item.Cost <= 0 {
+		return &GameError{Code: "not_for_sale", Message: fmt.Sprintf("the armoury does not sell %s", itemID)}
+	}
+	if err := checkClassRules(item, player.Class); err != nil {
+		return err
+	}
+	if err := a.inventoryManager.BuyItem(player.EntityID, item); err != nil {
+		return err
+	}
+
+	a.logger.Printf("Hero %s bought %s for %d gold", player.EntityID, item.Name, item.Cost)
+	a.broadcastTransaction(player.EntityID, item, false, item.Cost)
+	return nil
+}
+
+// Sell buys an item back from a player's hero for half its cost
+func (a *Armoury) Sell(playerID, itemID string) error {
+	player, err := a.shopper(playerID)
+	if err != nil {
+		return err
+	}
+
+	item, price, err := a.inventoryManager.SellItem(player.EntityID, itemID)
+	if err != nil {
+		return err
+	}
+
+	a.logger.Printf("Hero %s sold %s for %d gold", player.EntityID, item.Name, price)
+	a.broadcastTransaction(player.EntityID, item, true, price)
+	return nil
+}
+
+// ToLite returns the armoury's stock for client snapshot, or nil while the quest is being played
+func (a *Armoury) ToLite() *protocol.ArmouryLite {
+	if !a.IsOpen() {
+		return nil
+	}
+
+	stock := a.GetStock()
+	lite := &protocol.ArmouryLite{Items: make([]protocol.ArmouryItemLite, 0, len(stock))}
+	for _, item := range stock {
+		lite.Items = append(lite.Items, protocol.ArmouryItemLite{ID: item.ID, Name: item.Name, Type: item.Type, Cost: item.Cost, UsableBy: item.UsableBy})
+	}
+	return lite
+}
+
+// shopper returns the living hero of a player while the armoury is open
+func (a *Armoury) shopper(playerID string) (*Player, error) {
+	if !a.IsOpen() {
+		return nil, &GameError{Code: "armoury_closed", Message: "the armoury opens once the quest is over"}
+	}
+
+	player := a.turnManager.GetPlayer(playerID)
+	if player == nil {
+		return nil, fmt.Errorf("player %s not found", playerID)
+	}
+	if a.heroLifecycle != nil && a.heroLifecycle.IsDead(player.EntityID) {
+		return nil, &GameError{Code: "hero_dead", Message: "a dead hero cannot shop"}
+	}
+	return player, nil
+}
+
+// broadcastTransaction announces a purchase or sale along with the hero's new inventory
+func (a *Armoury) broadcastTransaction(heroID string, item *ItemCard, sold bool, gold int) {
+	a.broadcaster.BroadcastEvent("ArmouryTransaction", protocol.ArmouryTransaction{
+		HeroID:   heroID,
+		ItemID:   item.ID,
+		ItemName: item.Name,
+		Sold:     sold,
+		Gold:     gold,
+	})
+
+	if inventory, err := a.inventoryManager.GetInventory(heroID); err == nil {
+		a.broadcaster.BroadcastEvent("InventoryChanged", inventoryToLite(inventory))
+	}
+}
+
+// BuyItem pays for an item from a hero's gold and adds it to their carried items
+func (im *InventoryManager) BuyItem(heroID string, item *ItemCard) error {
+	im.mutex.Lock()
+	defer im.mutex.Unlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return fmt.Errorf("inventory for hero %s not found", heroID)
+	}
+	if inventory.Gold < item.Cost {
+		return &GameError{Code: "not_enough_gold", Message: fmt.Sprintf("%s costs %d gold; hero %s has %d", item.Name, item.Cost, heroID, inventory.Gold)}
+	}
+
+	inventory.Gold -= item.Cost
+	inventory.Carried = append(inventory.Carried, item)
+	im.refreshEquipmentMods(heroID)
+	return nil
+}
+
+// SellItem takes one copy of an item from a hero, carried before equipped, for half its cost.
+// Returns the item and the gold paid for it.
+func (im *InventoryManager) SellItem(heroID, itemID string) (*ItemCard, int, error) {
+	im.mutex.Lock()
+	defer im.mutex.Unlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return nil, 0, fmt.Errorf("inventory for hero %s not found", heroID)
+	}
+
+	item := findInventoryItem(inventory, itemID)
+	if item == nil {
+		return nil, 0, &GameError{Code: "item_not_held", Message: fmt.Sprintf("hero %s does not hold %s", heroID, itemID)}
+	}
+	if item.Cost <= 0 {
+		return nil, 0, &GameError{Code: "not_sellable", Message: fmt.Sprintf("the armoury does not buy %s", item.Name)}
+	}
+
+	removeInventoryItem(inventory, itemID)
+	price := item.Cost / 2
+	inventory.Gold += price
+	im.refreshEquipmentMods(heroID)
+	return item, price, nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+)
+
+// createTestArmoury stocks a broadsword, a staff and a wizard's cloak for hero-1 (Barbarian)
+// and hero-2 (Elf), both carrying a potion of healing
+func createTestArmoury(t *testing.T) (*HeroActionSystem, *Armoury, *MockBroadcaster) {
+	has, lifecycle := createTestLifecycle(t, HeroPhaseActive, healingPotion())
+	contentManager := has.inventoryManager.contentManager
+	for _, item := range []*ItemCard{
+		{ID: "broadsword", Name: "Broadsword", Type: "weapon", AttackDice: 3, Cost: 250},
+		{ID: "staff", Name: "Staff", Type: "weapon", AttackDice: 1, Cost: 100},
+		{ID: "wizards_cloak", Name: "Wizard's Cloak", Type: "equipment", Cost: 150, UsableBy: []string{"wizard"}},
+		{ID: "cursed_idol", Name: "Cursed Idol", Type: "equipment"},
+	} {
+		contentManager.equipmentCards[item.ID] = item
+	}
+
+	broadcaster := &MockBroadcaster{}
+	armoury := NewArmoury(contentManager, has.inventoryManager, has.turnManager, lifecycle, has.dynamicTurnOrder, broadcaster, &MockLogger{})
+	return has, armoury, broadcaster
+}
+
+func TestArmoury_OpensOnceTheQuestIsOver(t *testing.T) {
+	has, armoury, _ := createTestArmoury(t)
+	has.inventoryManager.AddGold("hero-1", 500)
+
+	expectGameErrorCode(t, armoury.Buy("player-1", "staff"), "armoury_closed")
+	if armoury.ToLite() != nil {
+		t.Error("Expected no armoury in the snapshot while the quest is being played")
+	}
+
+	has.dynamicTurnOrder.EndQuest(QuestVictory, "every objective is complete")
+	lite := armoury.ToLite()
+	if lite == nil || len(lite.Items) != 3 || lite.Items[0].ID != "staff" || lite.Items[2].ID != "broadsword" {
+		t.Fatalf("Expected the three priced items, cheapest first, got %+v", lite)
+	}
+	if err := armoury.Buy("player-1", "staff"); err != nil {
+		t.Errorf("Expected the staff to be sold once the quest is over, got: %v", err)
+	}
+	expectGameErrorCode(t, armoury.Buy("player-1", "cursed_idol"), "not_for_sale")
+}
+
+func TestArmoury_BuyChecksGoldAndClass(t *testing.T) {
+	has, armoury, broadcaster := createTestArmoury(t)
+	has.dynamicTurnOrder.EndQuest(QuestVictory, "every objective is complete")
+	has.inventoryManager.AddGold("hero-1", 200)
+
+	expectGameErrorCode(t, armoury.Buy("player-1", "broadsword"), "not_enough_gold")
+	expectGameErrorCode(t, armoury.Buy("player-1", "wizards_cloak"), "item_not_usable")
+
+	has.inventoryManager.AddGold("hero-1", 100)
+	if err := armoury.Buy("player-1", "broadsword"); err != nil {
+		t.Fatalf("Expected the barbarian to afford the broadsword, got: %v", err)
+	}
+	inventory, _ := has.inventoryManager.GetInventory("hero-1")
+	if inventory.Gold != 50 || !has.inventoryManager.HasItem("hero-1", "broadsword") {
+		t.Errorf("Expected the broadsword carried and 50 gold left, got %d gold", inventory.Gold)
+	}
+	if countEvents(broadcaster, "ArmouryTransaction") != 1 || broadcaster.LastEvent != "InventoryChanged" {
+		t.Errorf("Expected the purchase and the new inventory to be broadcast, got %+v", broadcaster.events)
+	}
+}
+
+func TestArmoury_SellBackAtHalfValue(t *testing.T) {
+	has, armoury, _ := createTestArmoury(t)
+	has.dynamicTurnOrder.EndQuest(QuestDefeat, "every hero has died")
+	has.inventoryManager.AddGold("hero-2", 250)
+	if err := armoury.Buy("player-2", "broadsword"); err != nil {
+		t.Fatalf("Expected the elf to buy the broadsword, got: %v", err)
+	}
+	if err := has.inventoryManager.EquipItem("hero-2", "broadsword"); err != nil {
+		t.Fatalf("Failed to equip the broadsword: %v", err)
+	}
+
+	if err := armoury.Sell("player-2", "broadsword"); err != nil {
+		t.Fatalf("Expected the equipped broadsword to be sold, got: %v", err)
+	}
+	inventory, _ := has.inventoryManager.GetInventory("hero-2")
+	if inventory.Gold != 125 || inventory.Equipment["weapon"] != nil {
+		t.Errorf("Expected 125 gold back and no weapon, got %d gold and %+v", inventory.Gold, inventory.Equipment["weapon"])
+	}
+
+	expectGameErrorCode(t, armoury.Sell("player-2", "potion_of_healing"), "not_sellable")
+	expectGameErrorCode(t, armoury.Sell("player-2", "broadsword"), "item_not_held")
+}
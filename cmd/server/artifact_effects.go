@@ -0,0 +1,249 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Artifact effects (EffectDefinition.Type) handled by the artifact registry
+const (
+	ArtifactEffectStatBonus      = "stat_bonus"       // "attack_dice" and/or "defense_dice" added while held; with "against", only when fighting those monsters
+	ArtifactEffectSpellImmunity  = "spell_immunity"   // Dread spells listed in "spells" (every dread spell when none are listed) have no effect on the holder
+	ArtifactEffectIgnoreDefense  = "ignore_defense"   // The monster rolls "dice" fewer defense dice (none at all when 0), optionally only "against" listed monsters
+	ArtifactEffectExtraMovement  = "extra_movement"   // "dice" extra movement dice (default 1) on every movement roll
+	ArtifactEffectDualModeAttack = "dual_mode_attack" // A melee weapon that can also strike targets up to "range" squares away, with "ranged_dice" attack dice if given
+)
+
+// ignoreDefenseDice is the ActiveEffect type for defense dice a monster does not roll against
+// the hero's next attack (Value 0 = none at all)
+const ignoreDefenseDice = "ignore_defense_dice"
+
+// ArtifactAttack is a hero's attack on a monster, as the artifacts the hero holds may change it
+type ArtifactAttack struct {
+	Monster     *Monster
+	Ranged      bool // The target is not adjacent to the hero
+	AttackDice  int
+	DefenseDice int // Dice the monster rolls to defend
+}
+
+// ArtifactDefense is a hero's defense against a monster's attack
+type ArtifactDefense struct {
+	Monster     *Monster
+	DefenseDice int
+}
+
+// ArtifactEffect implements an artifact effect type. Every hook is optional, and the hooks only
+// run while the artifact works for the hero holding it: equipped, or carried if it has no slot.
+type ArtifactEffect struct {
+	Mods         func(item *ItemCard, mods *StatMods)                                        // Passive bonuses folded into the holder's equipment mods
+	Attack       func(item *ItemCard, attack *ArtifactAttack)                                // The holder attacks a monster
+	Defend       func(item *ItemCard, defense *ArtifactDefense)                              // A monster attacks the holder
+	Reach        func(weapon *ItemCard) *ItemCard                                            // The equipped weapon's ranged mode, tried on targets out of melee reach
+	ResistsSpell func(item *ItemCard, spell *SpellCard) bool                                 // A dread spell is cast at the holder
+	Use          func(has *HeroActionSystem, item *ItemCard, hero *Player) ([]Effect, error) // The holder uses an artifact limited by UsesPerQuest
+}
+
+// artifactEffects holds the effect types artifact cards can name. It is never written after
+// package initialisation, so every game reads it without locking.
+var artifactEffects = map[string]ArtifactEffect{
+	ArtifactEffectStatBonus:      {Mods: statBonusMods, Attack: statBonusAttack, Defend: statBonusDefend},
+	ArtifactEffectSpellImmunity:  {ResistsSpell: spellImmunityResists},
+	ArtifactEffectIgnoreDefense:  {Attack: ignoreDefenseAttack, Use: ignoreDefenseUse},
+	ArtifactEffectExtraMovement:  {Mods: extraMovementMods},
+	ArtifactEffectDualModeAttack: {Reach: dualModeReach, Attack: dualModeAttack},
+}
+
+// eachHeldArtifact calls fn for every item of the inventory with a registered artifact effect that
+// works for the hero: equipped items, and carried items that have no equipment slot
+func eachHeldArtifact(inventory *HeroInventory, fn func(item *ItemCard, effect ArtifactEffect)) {
+	visit := func(item *ItemCard) {
+		if item == nil || item.Effect == nil {
+			return
+		}
+		if effect, ok := artifactEffects[item.Effect.Type]; ok {
+			fn(item, effect)
+		}
+	}
+
+	for _, item := range inventory.Equipment {
+		visit(item)
+	}
+	for _, item := range inventory.Carried {
+		if determineSlot(item) == "" {
+			visit(item)
+		}
+	}
+}
+
+// ApplyArtifactAttack lets the artifacts a hero holds change their attack on a monster
+func (im *InventoryManager) ApplyArtifactAttack(heroID string, attack *ArtifactAttack) {
+	im.mutex.RLock()
+	defer im.mutex.RUnlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return
+	}
+	eachHeldArtifact(inventory, func(item *ItemCard, effect ArtifactEffect) {
+		if effect.Attack != nil {
+			effect.Attack(item, attack)
+		}
+	})
+	attack.AttackDice = max(attack.AttackDice, 1)
+	attack.DefenseDice = max(attack.DefenseDice, 0)
+}
+
+// ApplyArtifactDefense lets the artifacts a hero holds change their defense against a monster
+func (im *InventoryManager) ApplyArtifactDefense(heroID string, defense *ArtifactDefense) {
+	im.mutex.RLock()
+	defer im.mutex.RUnlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return
+	}
+	eachHeldArtifact(inventory, func(item *ItemCard, effect ArtifactEffect) {
+		if effect.Defend != nil {
+			effect.Defend(item, defense)
+		}
+	})
+	defense.DefenseDice = max(defense.DefenseDice, 0)
+}
+
+// SpellWard returns the artifact that makes a hero immune to a dread spell, or nil
+func (im *InventoryManager) SpellWard(heroID string, spell *SpellCard) *ItemCard {
+	im.mutex.RLock()
+	defer im.mutex.RUnlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return nil
+	}
+	var ward *ItemCard
+	eachHeldArtifact(inventory, func(item *ItemCard, effect ArtifactEffect) {
+		if ward == nil && effect.ResistsSpell != nil && effect.ResistsSpell(item, spell) {
+			ward = item
+		}
+	})
+	return ward
+}
+
+// SetInventoryManager sets the inventory manager whose artifacts protect heroes from monsters
+func (ms *MonsterSystem) SetInventoryManager(inventoryManager *InventoryManager) {
+	ms.inventoryManager = inventoryManager
+}
+
+// artifactRangedMode returns the ranged mode of a weapon whose artifact effect gives it one, or nil
+func artifactRangedMode(weapon *ItemCard) *ItemCard {
+	if weapon == nil || weapon.Effect == nil {
+		return nil
+	}
+	if effect, ok := artifactEffects[weapon.Effect.Type]; ok && effect.Reach != nil {
+		return effect.Reach(weapon)
+	}
+	return nil
+}
+
+// effectStrings reads a list of strings from an effect field
+func effectStrings(effect *EffectDefinition, key string) []string {
+	switch v := effect.Data[key].(type) {
+	case []string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, value := range v {
+			if s, ok := value.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// fightsAgainst reports whether an effect applies to a monster: every monster when the effect
+// lists none "against", otherwise those whose type or subtype (e.g. "undead") is listed
+func fightsAgainst(effect *EffectDefinition, monster *Monster) bool {
+	against := effectStrings(effect, "against")
+	if len(against) == 0 {
+		return true
+	}
+	return monster != nil && (slices.Contains(against, string(monster.Type)) || (monster.SubType != "" && slices.Contains(against, monster.SubType)))
+}
+
+// ignoreDefense returns the defense dice a monster still rolls once dice of them are ignored (0 = all)
+func ignoreDefense(defenseDice, dice int) int {
+	if dice <= 0 {
+		return 0
+	}
+	return max(defenseDice-dice, 0)
+}
+
+func statBonusMods(item *ItemCard, mods *StatMods) {
+	if len(effectStrings(item.Effect, "against")) > 0 {
+		return
+	}
+	mods.AttackBonus += effectInt(item.Effect, "attack_dice")
+	mods.DefenseBonus += effectInt(item.Effect, "defense_dice")
+}
+
+func statBonusAttack(item *ItemCard, attack *ArtifactAttack) {
+	if len(effectStrings(item.Effect, "against")) > 0 && fightsAgainst(item.Effect, attack.Monster) {
+		attack.AttackDice += effectInt(item.Effect, "attack_dice")
+	}
+}
+
+func statBonusDefend(item *ItemCard, defense *ArtifactDefense) {
+	if len(effectStrings(item.Effect, "against")) > 0 && fightsAgainst(item.Effect, defense.Monster) {
+		defense.DefenseDice += effectInt(item.Effect, "defense_dice")
+	}
+}
+
+func spellImmunityResists(item *ItemCard, spell *SpellCard) bool {
+	spells := effectStrings(item.Effect, "spells")
+	return len(spells) == 0 || slices.Contains(spells, spell.ID)
+}
+
+// ignoreDefenseAttack pierces the monster's defense on every attack, unless the artifact is
+// limited per quest, in which case it only works when used
+func ignoreDefenseAttack(item *ItemCard, attack *ArtifactAttack) {
+	if item.UsesPerQuest == 0 && fightsAgainst(item.Effect, attack.Monster) {
+		attack.DefenseDice = ignoreDefense(attack.DefenseDice, effectInt(item.Effect, "dice"))
+	}
+}
+
+// ignoreDefenseUse readies an artifact limited per quest to pierce the defense of the next monster attacked
+func ignoreDefenseUse(has *HeroActionSystem, item *ItemCard, hero *Player) ([]Effect, error) {
+	if has.turnStateManager == nil {
+		return nil, fmt.Errorf("turn state manager not initialized")
+	}
+
+	dice := effectInt(item.Effect, "dice")
+	has.turnStateManager.QueueActiveEffect(hero.EntityID, ActiveEffect{
+		Source:     item.ID,
+		EffectType: ignoreDefenseDice,
+		Value:      dice,
+		Trigger:    "next_attack",
+		ExpiresOn:  "after_trigger",
+	})
+	return []Effect{{Type: ignoreDefenseDice, Value: dice, Description: "Applies on next_attack"}}, nil
+}
+
+func extraMovementMods(item *ItemCard, mods *StatMods) {
+	mods.MovementDice += max(effectInt(item.Effect, "dice"), 1)
+}
+
+func dualModeReach(weapon *ItemCard) *ItemCard {
+	ranged := *weapon
+	ranged.Ranged = true
+	ranged.AttackAdjacent = true
+	ranged.Range = effectInt(weapon.Effect, "range")
+	return &ranged
+}
+
+// dualModeAttack swaps the weapon's melee dice for its ranged dice on targets out of melee reach
+func dualModeAttack(item *ItemCard, attack *ArtifactAttack) {
+	if dice := effectInt(item.Effect, "ranged_dice"); attack.Ranged && dice > 0 {
+		attack.AttackDice += dice - item.AttackDice
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+func TestArtifactEffects_QuestNoteAwardsWorkingArtifacts(t *testing.T) {
+	has := createTestSearcher(t)
+	contentManager := has.inventoryManager.contentManager
+	for _, artifact := range []*ItemCard{
+		{ID: "ward_charm", Name: "Ward Charm", Type: "artifact", Effect: &EffectDefinition{Type: ArtifactEffectStatBonus, Data: map[string]any{"defense_dice": float64(2)}}},
+		{ID: "wind_boots", Name: "Wind Boots", Type: "artifact", Effect: &EffectDefinition{Type: ArtifactEffectExtraMovement, Data: map[string]any{}}},
+	} {
+		contentManager.artifactCards[artifact.ID] = artifact
+	}
+	quest := &geometry.QuestDefinition{QuestNotes: map[string]*geometry.QuestTreasureNote{
+		"chest": {
+			TreasureType: "fixed",
+			Location:     geometry.TreasureLocation{Room: 2, X: 5, Y: 5},
+			Items:        []geometry.ItemReference{{ID: "ward_charm"}, {ID: "wind_boots"}},
+		},
+	}}
+	has.SetTreasureResolver(NewTreasureResolver(contentManager, nil, quest, &MockLogger{}))
+
+	barbarian := has.turnManager.GetPlayer("player-1").Character
+	defense := barbarian.GetEffectiveDefenseDice()
+	if _, err := has.ProcessAction(searchRequest(SearchTreasureAction)); err != nil {
+		t.Fatalf("Expected the quest note to be searched, got: %v", err)
+	}
+	if barbarian.GetEffectiveDefenseDice() != defense+2 || barbarian.EquipmentMods.MovementDice != 1 {
+		t.Fatalf("Expected the artifacts to work as soon as they are found, got %+v", barbarian.EquipmentMods)
+	}
+
+	rolls, err := has.turnManager.RollMovementDice()
+	if err != nil || len(rolls) != barbarian.BaseStats.MovementDice+1 {
+		t.Errorf("Expected one extra movement die from the boots, got %d (%v)", len(rolls), err)
+	}
+
+	// Dropping an artifact takes its bonus away
+	if _, err := has.inventoryManager.DropItem("hero-1", "ward_charm", protocol.TileAddress{X: 5, Y: 5}); err != nil {
+		t.Fatalf("Failed to drop the charm: %v", err)
+	}
+	if barbarian.GetEffectiveDefenseDice() != defense {
+		t.Errorf("Expected the defense bonus to go with the charm, got %d", barbarian.GetEffectiveDefenseDice())
+	}
+}
+
+func TestArtifactEffects_DualModeWeaponAndOncePerQuestPiercing(t *testing.T) {
+	spear := &ItemCard{ID: "spirit_spear", Name: "Spirit Spear", Type: "weapon", AttackDice: 3,
+		Effect: &EffectDefinition{Type: ArtifactEffectDualModeAttack, Data: map[string]any{"range": float64(3), "ranged_dice": float64(1)}}}
+	has := createTestArmedHero(t, spear, protocol.TileAddress{X: 5, Y: 8})
+	has.inventoryManager.GiveItems("hero-1", []*ItemCard{{ID: "piercing_rune", Name: "Piercing Rune", Type: "artifact", UsesPerQuest: 1,
+		Effect: &EffectDefinition{Type: ArtifactEffectIgnoreDefense, Data: map[string]any{"against": []any{"goblin"}}}}}, 0)
+	goblin, _ := has.monsterSystem.GetMonsterByID("monster-1")
+	goblin.Body, goblin.DefenseDice = 10, 2
+
+	result, err := has.ProcessAction(attackRequest(map[string]any{}))
+	if err != nil || len(result.AttackRolls) != 1 || len(result.DefenseRolls) != 2 {
+		t.Fatalf("Expected a ranged strike with 1 die against 2, got %+v (%v)", result, err)
+	}
+	goblin.Position = protocol.TileAddress{X: 5, Y: 9}
+	has.turnManager.RestoreActions()
+	_, err = has.ProcessAction(attackRequest(map[string]any{}))
+	expectGameErrorCode(t, err, "target_out_of_range")
+
+	// The rune works only when used, and only once this quest
+	use := useItemRequest("player-1", "hero-1", UseItemInstant, "piercing_rune")
+	if _, err := has.ProcessInstantAction(use); err != nil {
+		t.Fatalf("Expected the rune to be used, got: %v", err)
+	}
+	goblin.Position = protocol.TileAddress{X: 5, Y: 6}
+	result, err = has.ProcessAction(attackRequest(map[string]any{}))
+	if err != nil || len(result.AttackRolls) != 3 || len(result.DefenseRolls) != 0 {
+		t.Fatalf("Expected a melee strike with 3 dice the goblin cannot defend, got %+v (%v)", result, err)
+	}
+
+	has.turnStateManager.AdvanceTurn()
+	_, err = has.ProcessInstantAction(use)
+	expectGameErrorCode(t, err, "item_spent")
+}
+
+func TestArtifactEffects_SpellImmunityAndMonsterWard(t *testing.T) {
+	has, ms := createTestDreadCaster(t, &SpellCard{ID: "lightning_bolt", Name: "Lightning Bolt", Effect: SpellEffectDamage, EffectValue: 2})
+	ms.AssignDreadSpell("caster", "lightning_bolt", 1)
+	inventoryManager := NewInventoryManager(NewContentManager(&MockLogger{}), &MockLogger{})
+	inventoryManager.InitializeHeroInventory("hero-1")
+	inventoryManager.RegisterHero(has.turnManager.GetPlayer("player-1"))
+	ms.SetInventoryManager(inventoryManager)
+
+	amulet := &ItemCard{ID: "amulet", Name: "Amulet of the North", Type: "artifact",
+		Effect: &EffectDefinition{Type: ArtifactEffectSpellImmunity, Data: map[string]any{"spells": []any{"lightning_bolt"}}}}
+	inventoryManager.GiveItems("hero-1", []*ItemCard{amulet, {ID: "undead_ward", Name: "Undead Ward", Type: "artifact",
+		Effect: &EffectDefinition{Type: ArtifactEffectStatBonus, Data: map[string]any{"defense_dice": float64(3), "against": []any{"undead"}}}}}, 0)
+
+	barbarian := has.turnManager.GetPlayer("player-1").Character
+	result, err := ms.CastDreadSpell("caster", "lightning_bolt", "hero-1")
+	if err != nil || !result.Resisted || barbarian.CurrentBody != barbarian.BaseStats.BodyPoints {
+		t.Fatalf("Expected the amulet to ward off the lightning bolt, got %+v (%v)", result, err)
+	}
+
+	amulet.Effect.Data["spells"] = []any{"sleep"}
+	if result, err := ms.CastDreadSpell("caster", "lightning_bolt", "hero-1"); err != nil || result.Resisted {
+		t.Errorf("Expected an amulet against other spells not to help, got %+v (%v)", result, err)
+	}
+
+	// The ward adds defense dice against undead only, and only when fighting them
+	for _, tc := range []struct {
+		monster *Monster
+		want    int
+	}{
+		{&Monster{ID: "skeleton-1", Type: Skeleton, SubType: "undead"}, 5},
+		{ms.monsters["caster"], 2},
+	} {
+		defense := &ArtifactDefense{Monster: tc.monster, DefenseDice: 2}
+		inventoryManager.ApplyArtifactDefense("hero-1", defense)
+		if defense.DefenseDice != tc.want {
+			t.Errorf("Expected %d defense dice against %s, got %d", tc.want, tc.monster.ID, defense.DefenseDice)
+		}
+	}
+	if barbarian.EquipmentMods.DefenseBonus != 0 {
+		t.Errorf("Expected no passive defense from the ward, got %+v", barbarian.EquipmentMods)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// stepClosed reports whether a wall, or a door that is not open, lies on the edge crossed by
+// one orthogonal step. Callers must hold the game state lock.
+func stepClosed(state *GameState, x, y, dx, dy int) bool {
+	edge := edgeForStep(x, y, dx, dy)
+	if state.BlockedWalls[edge] {
+		return true
+	}
+	if doorID, hasDoor := state.DoorByEdge[edge]; hasDoor {
+		door := state.Doors[doorID]
+		return door != nil && door.State != "open"
+	}
+	return false
+}
+
+// adjacentOpen reports whether nothing closed lies between two adjacent squares. A diagonal
+// neighbour is open when it can be reached round at least one of the two corners.
+// Callers must hold the game state lock.
+func adjacentOpen(state *GameState, from, to protocol.TileAddress) bool {
+	dx, dy := to.X-from.X, to.Y-from.Y
+	if dx == 0 || dy == 0 {
+		return !stepClosed(state, from.X, from.Y, dx, dy)
+	}
+	return (!stepClosed(state, from.X, from.Y, dx, 0) && !stepClosed(state, from.X+dx, from.Y, 0, dy)) ||
+		(!stepClosed(state, from.X, from.Y, 0, dy) && !stepClosed(state, from.X, from.Y+dy, dx, 0))
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// Campaign quest statuses (protocol.CampaignQuestLite.Status)
+const (
+	CampaignQuestCompleted = "completed"
+	CampaignQuestFailed    = "failed"
+	CampaignQuestUnplayed  = "unplayed"
+)
+
+// CampaignHero is a hero as they carry over from one quest to the next
+type CampaignHero struct {
+	PlayerID  string         `json:"playerId"`
+	HeroID    string         `json:"heroId"`
+	ClassID   string         `json:"classId"`             // Hero card picked in the lobby
+	Inventory *HeroInventory `json:"inventory,omitempty"` // Gold, equipment and artifacts; nil until the hero ends a quest alive
+	Dead      bool           `json:"dead,omitempty"`      // Died in the last quest; the player starts over with a new hero of the class
+}
+
+// CampaignQuestRecord is how the party has fared in one of the campaign's quests
+type CampaignQuestRecord struct {
+	Completed bool   `json:"completed"`
+	Result    string `json:"result"`   // Outcome of the last attempt: QuestVictory or QuestDefeat
+	Attempts  int    `json:"attempts"` // Times the quest has been played to an end
+}
+
+// CampaignQuestStart is a quest the campaign is about to start. Nothing is recorded until the
+// game has been built for it and hands it to StartQuest.
+type CampaignQuestStart struct {
+	Quest    CampaignQuestRef
+	Party    []CampaignHero // The heroes as they join the quest
+	Previous *QuestOutcome  // How the quest being left ended; nil for the campaign's first quest
+	Played   int            // Quests played to an end once the previous one is recorded
+}
+
+// CampaignSession follows the party through the quests of a campaign. It records how each quest
+// ended and carries the heroes' gold, equipment and artifacts into the next one. A failed quest
+// stays open, so the GM can replay it.
+type CampaignSession struct {
+	campaign     *CampaignMetadata
+	quests       []CampaignQuestRef // In campaign order
+	gameMasterID string
+	records      map[string]*CampaignQuestRecord // questID -> record
+	heroes       map[string]*CampaignHero        // playerID -> hero
+	current      string                          // Quest being played
+	logger       Logger
+	mutex        sync.Mutex
+}
+
+// NewCampaignSession creates a session for the campaign, run by the given game master
+func NewCampaignSession(campaign *CampaignMetadata, gameMasterID string, logger Logger) *CampaignSession {
+	cs := &CampaignSession{
+		campaign:     campaign,
+		gameMasterID: gameMasterID,
+		records:      make(map[string]*CampaignQuestRecord),
+		heroes:       make(map[string]*CampaignHero),
+		logger:       logger,
+	}
+	if campaign != nil {
+		cs.quests = slices.Clone(campaign.Quests)
+		sort.SliceStable(cs.quests, func(i, j int) bool { return cs.quests[i].Order < cs.quests[j].Order })
+	}
+	return cs
+}
+
+// AddHero adds a hero picked in the lobby to the party
+func (cs *CampaignSession) AddHero(playerID, heroID, classID string) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	cs.heroes[playerID] = &CampaignHero{PlayerID: playerID, HeroID: heroID, ClassID: classID}
+}
+
+// GetHeroes returns the party, ordered by hero ID
+func (cs *CampaignSession) GetHeroes() []CampaignHero {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	heroes := make([]CampaignHero, 0, len(cs.heroes))
+	for _, hero := range cs.heroes {
+		heroes = append(heroes, *hero)
+	}
+	sort.Slice(heroes, func(i, j int) bool { return heroes[i].HeroID < heroes[j].HeroID })
+	return heroes
+}
+
+// NextQuest returns the first quest, in campaign order, the party has not completed. The quest
+// being played counts as completed if ended, its end not yet recorded, is a victory.
+func (cs *CampaignSession) NextQuest(ended *QuestOutcome) (CampaignQuestRef, bool) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	for _, quest := range cs.quests {
+		if quest.ID == cs.current && ended != nil && ended.Result == QuestVictory {
+			continue
+		}
+		if record := cs.records[quest.ID]; record == nil || !record.Completed {
+			return quest, true
+		}
+	}
+	return CampaignQuestRef{}, false
+}
+
+// FindQuest returns one of the campaign's quests
+func (cs *CampaignSession) FindQuest(questID string) (CampaignQuestRef, bool) {
+	for _, quest := range cs.quests {
+		if quest.ID == questID {
+			return quest, true
+		}
+	}
+	return CampaignQuestRef{}, false
+}
+
+// PrepareQuest plans the start of a quest after the current one ended as previous, with the
+// heroes as they finished it. Without a previous quest the party joins as it stands.
+func (cs *CampaignSession) PrepareQuest(quest CampaignQuestRef, previous *QuestOutcome, finished []CampaignHero) CampaignQuestStart {
+	start := CampaignQuestStart{Quest: quest, Previous: previous, Played: cs.QuestsPlayed()}
+	if previous == nil {
+		start.Party = cs.GetHeroes()
+		return start
+	}
+
+	start.Played++
+	start.Party = make([]CampaignHero, 0, len(finished))
+	for _, hero := range finished {
+		if hero.Dead {
+			hero.Inventory = nil
+		}
+		start.Party = append(start.Party, hero)
+	}
+	return start
+}
+
+// StartQuest records how the previous quest ended, along with the party as they leave it, and
+// marks the new quest as being played. It is called once the game for the new quest is ready.
+func (cs *CampaignSession) StartQuest(start CampaignQuestStart) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	if start.Previous != nil {
+		cs.endQuestLocked(start.Previous, start.Party)
+	}
+	cs.current = start.Quest.ID
+	cs.logger.Printf("Campaign: starting quest %s", start.Quest.ID)
+}
+
+// QuestsPlayed returns how many quests have been played to an end
+func (cs *CampaignSession) QuestsPlayed() int {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	played := 0
+	for _, record := range cs.records {
+		played += record.Attempts
+	}
+	return played
+}
+
+// endQuestLocked records how the current quest ended along with the heroes as they finished it.
+// Callers must hold the session mutex.
+func (cs *CampaignSession) endQuestLocked(outcome *QuestOutcome, heroes []CampaignHero) {
+	if cs.current == "" {
+		return
+	}
+
+	record := cs.records[cs.current]
+	if record == nil {
+		record = &CampaignQuestRecord{}
+		cs.records[cs.current] = record
+	}
+	record.Attempts++
+	record.Result = outcome.Result
+	record.Completed = record.Completed || outcome.Result == QuestVictory
+
+	for _, hero := range heroes {
+		carried := hero
+		cs.heroes[hero.PlayerID] = &carried
+	}
+
+	cs.logger.Printf("Campaign: quest %s ended in %s (attempt %d)", cs.current, outcome.Result, record.Attempts)
+	cs.current = ""
+}
+
+// GetProgress returns the campaign's quests and how the party has fared in each
+func (cs *CampaignSession) GetProgress() protocol.CampaignProgress {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	progress := protocol.CampaignProgress{
+		Quests:         make([]protocol.CampaignQuestLite, 0, len(cs.quests)),
+		CurrentQuestID: cs.current,
+	}
+	if cs.campaign != nil {
+		progress.CampaignID, progress.Name = cs.campaign.ID, cs.campaign.Name
+	}
+
+	for _, quest := range cs.quests {
+		lite := protocol.CampaignQuestLite{ID: quest.ID, Name: quest.Name, Order: quest.Order, Status: CampaignQuestUnplayed}
+		if record := cs.records[quest.ID]; record != nil {
+			lite.Attempts = record.Attempts
+			lite.Status = CampaignQuestFailed
+			if record.Completed {
+				lite.Status = CampaignQuestCompleted
+			}
+		}
+		progress.Quests = append(progress.Quests, lite)
+	}
+	return progress
+}
+
+// carryOver prepares an inventory for the next quest: gold, equipment and artifacts are kept,
+// while spells are drafted again and per-quest item uses come back. Charges spent on other
+// items stay spent.
+func carryOver(inventory *HeroInventory) *HeroInventory {
+	carried := &HeroInventory{
+		HeroID:    inventory.HeroID,
+		Gold:      inventory.Gold,
+		Equipment: maps.Clone(inventory.Equipment),
+		Carried:   slices.Clone(inventory.Carried),
+		Spells:    make([]*SpellCard, 0),
+	}
+	for itemID, spent := range inventory.ItemUses {
+		if item := findInventoryItem(inventory, itemID); item == nil || item.UsesPerQuest > 0 {
+			continue
+		}
+		if carried.ItemUses == nil {
+			carried.ItemUses = make(map[string]int)
+		}
+		carried.ItemUses[itemID] = spent
+	}
+	return carried
+}
+
+// campaignPersistence is the serialized form of CampaignSession
+type campaignPersistence struct {
+	Records map[string]*CampaignQuestRecord `json:"records"`
+	Heroes  map[string]*CampaignHero        `json:"heroes"`
+	Current string                          `json:"current,omitempty"`
+}
+
+// SerializeForPersistence serializes the party and the quests played so far
+func (cs *CampaignSession) SerializeForPersistence() ([]byte, error) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	return json.Marshal(campaignPersistence{Records: cs.records, Heroes: cs.heroes, Current: cs.current})
+}
+
+// RestoreFromPersistence restores the party and the quests played so far
+func (cs *CampaignSession) RestoreFromPersistence(data []byte) error {
+	var restored campaignPersistence
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return err
+	}
+
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	cs.records = orEmpty(restored.Records)
+	cs.heroes = orEmpty(restored.Heroes)
+	cs.current = restored.Current
+	return nil
+}
+
+// SetCampaign makes the game part of a campaign. startQuest replaces the running game with
+// the given quest, bringing in its party, and hands the start to the session once it succeeds.
+func (gm *GameManager) SetCampaign(session *CampaignSession, startQuest func(CampaignQuestStart) error) {
+	gm.campaign = session
+	gm.startQuest = startQuest
+}
+
+// isGameMaster reports whether the player runs the game. Only lobby games have a GM seat; the
+// single-player dev server, which has no campaign, lets its one player act as GM.
+func (gm *GameManager) isGameMaster(playerID string) bool {
+	return gm.campaign == nil || playerID == gm.campaign.gameMasterID
+}
+
+// AdvanceCampaign records how the quest ended and starts the next one: the quest the GM
+// picked, or the first the party has not completed. Returns the quest started.
+func (gm *GameManager) AdvanceCampaign(playerID, questID string) (*CampaignQuestRef, error) {
+	if gm.campaign == nil || gm.startQuest == nil {
+		return nil, &GameError{Code: "no_campaign", Message: "this game is not part of a campaign"}
+	}
+	if !gm.isGameMaster(playerID) {
+		return nil, &GameError{Code: "not_game_master", Message: "only the GM can start the next quest"}
+	}
+	outcome := gm.dynamicTurnOrder.GetQuestOutcome()
+	if outcome == nil {
+		return nil, &GameError{Code: "quest_in_progress", Message: "the current quest has not ended"}
+	}
+
+	quest, ok := gm.campaign.NextQuest(outcome)
+	if questID != "" {
+		quest, ok = gm.campaign.FindQuest(questID)
+		if !ok {
+			return nil, &GameError{Code: "unknown_quest", Message: fmt.Sprintf("quest %s is not part of the campaign", questID)}
+		}
+	}
+	if !ok {
+		return nil, &GameError{Code: "campaign_complete", Message: "the party has completed every quest"}
+	}
+
+	// The quest's end is recorded only once the next quest has started, so a failed start
+	// leaves this quest ended and the GM free to try again
+	if err := gm.startQuest(gm.campaign.PrepareQuest(quest, outcome, gm.captureCampaignHeroes())); err != nil {
+		return nil, fmt.Errorf("failed to start quest %s: %w", quest.ID, err)
+	}
+	return &quest, nil
+}
+
+// captureCampaignHeroes records every hero as they end the quest
+func (gm *GameManager) captureCampaignHeroes() []CampaignHero {
+	heroes := gm.campaign.GetHeroes()
+	for i := range heroes {
+		hero := &heroes[i]
+		hero.Dead = gm.heroLifecycle.IsDead(hero.HeroID)
+		if inventory, err := gm.inventoryManager.GetInventory(hero.HeroID); err == nil {
+			hero.Inventory = carryOver(inventory)
+		}
+	}
+	return heroes
+}
+
+// ApplyCampaignHero gives a hero joining the quest what they carried out of the last one
+func (gm *GameManager) ApplyCampaignHero(hero CampaignHero) {
+	if hero.Inventory == nil {
+		return
+	}
+	gm.inventoryManager.ReplaceInventory(hero.HeroID, carryOver(hero.Inventory))
+}
+
+// GetCampaignForSnapshot returns the campaign's progress for client snapshot, or nil outside a campaign
+func (gm *GameManager) GetCampaignForSnapshot() *protocol.CampaignProgress {
+	if gm.campaign == nil {
+		return nil
+	}
+	progress := gm.campaign.GetProgress()
+	return &progress
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// createTestCampaign runs quest-01 of a three-quest campaign with hero-1 and hero-2 from
+// createTestLifecycle, GM "gm-1", recording the quests the campaign goes on to start. Starting
+// a quest fails while *failStart is set.
+func createTestCampaign(t *testing.T, phase TurnPhaseType) (*HeroActionSystem, *GameManager, *CampaignSession, *[]string, *bool) {
+	has, lifecycle := createTestLifecycle(t, phase, healingPotion())
+	gm := &GameManager{
+		dynamicTurnOrder: has.dynamicTurnOrder,
+		inventoryManager: has.inventoryManager,
+		heroLifecycle:    lifecycle,
+		logger:           &MockLogger{},
+	}
+
+	session := NewCampaignSession(&CampaignMetadata{ID: "base", Quests: []CampaignQuestRef{
+		{ID: "quest-02", Order: 2},
+		{ID: "quest-01", Order: 1},
+		{ID: "quest-03", Order: 3},
+	}}, "gm-1", &MockLogger{})
+	session.AddHero("player-1", "hero-1", "barbarian")
+	session.AddHero("player-2", "hero-2", "elf")
+
+	started := []string{}
+	failStart := false
+	first, _ := session.NextQuest(nil)
+	session.StartQuest(session.PrepareQuest(first, nil, nil))
+	gm.SetCampaign(session, func(start CampaignQuestStart) error {
+		if failStart {
+			return errors.New("quest content missing")
+		}
+		started = append(started, start.Quest.ID)
+		session.StartQuest(start)
+		return nil
+	})
+	return has, gm, session, &started, &failStart
+}
+
+func TestCampaign_VictoryCarriesHeroesIntoNextQuest(t *testing.T) {
+	has, gm, session, started, _ := createTestCampaign(t, HeroPhaseActive)
+	has.inventoryManager.AddGold("hero-1", 30)
+	has.inventoryManager.GiveItems("hero-1", []*ItemCard{
+		{ID: "spirit_blade", Name: "Spirit Blade", Type: "artifact"},
+		{ID: "wand_of_recall", Name: "Wand of Recall", Type: "artifact", UsesPerQuest: 1},
+		{ID: "holy_water", Name: "Holy Water", Uses: 3},
+	}, 0)
+	has.inventoryManager.SpendItemUse("hero-1", "wand_of_recall")
+	has.inventoryManager.SpendItemUse("hero-1", "holy_water")
+	inventory, _ := has.inventoryManager.GetInventory("hero-1")
+	inventory.Spells = append(inventory.Spells, &SpellCard{ID: "swift_wind"})
+
+	_, err := gm.AdvanceCampaign("gm-1", "")
+	expectGameErrorCode(t, err, "quest_in_progress")
+	has.dynamicTurnOrder.EndQuest(QuestVictory, "every objective is complete")
+	_, err = gm.AdvanceCampaign("player-1", "")
+	expectGameErrorCode(t, err, "not_game_master")
+
+	quest, err := gm.AdvanceCampaign("gm-1", "")
+	if err != nil || quest.ID != "quest-02" || !reflect.DeepEqual(*started, []string{"quest-02"}) {
+		t.Fatalf("Expected the campaign to move on to quest-02, got %+v, %v (%v)", quest, *started, err)
+	}
+	if progress := session.GetProgress(); progress.Quests[0].Status != CampaignQuestCompleted || progress.CurrentQuestID != "quest-02" {
+		t.Errorf("Expected quest-01 completed and quest-02 under way, got %+v", progress)
+	}
+
+	// The next quest's inventory manager takes what the hero carried out
+	next := &GameManager{inventoryManager: NewInventoryManager(NewContentManager(&MockLogger{}), &MockLogger{})}
+	next.inventoryManager.InitializeHeroInventory("hero-1")
+	next.ApplyCampaignHero(session.GetHeroes()[0])
+	carried, _ := next.inventoryManager.GetInventory("hero-1")
+	if carried.Gold != 30 || !next.inventoryManager.HasItem("hero-1", "spirit_blade") || !next.inventoryManager.HasItem("hero-1", "potion_of_healing") {
+		t.Errorf("Expected 30 gold, the artifact and the potion carried over, got %+v", carried)
+	}
+	if carried.ItemUses["wand_of_recall"] != 0 || carried.ItemUses["holy_water"] != 1 {
+		t.Errorf("Expected per-quest uses back and spent charges kept, got %v", carried.ItemUses)
+	}
+	if len(carried.Spells) != 0 {
+		t.Errorf("Expected spells to be drafted again each quest, got %d", len(carried.Spells))
+	}
+}
+
+func TestCampaign_FailedQuestIsReplayedWithNewHeroes(t *testing.T) {
+	has, gm, session, started, _ := createTestCampaign(t, GMPhase)
+	knockOut(has, gm.heroLifecycle, "player-1")
+	knockOut(has, gm.heroLifecycle, "player-2")
+	gm.heroLifecycle.ResolveGMPhaseEnd(1)
+	if outcome := gm.heroLifecycle.ResolveGMPhaseEnd(2); outcome == nil || outcome.Result != QuestDefeat {
+		t.Fatalf("Expected the party to be wiped out, got %+v", outcome)
+	}
+
+	quest, err := gm.AdvanceCampaign("gm-1", "")
+	if err != nil || quest.ID != "quest-01" || len(*started) != 1 {
+		t.Fatalf("Expected the failed quest to be played again, got %+v (%v)", quest, err)
+	}
+	if progress := session.GetProgress(); progress.Quests[0].Status != CampaignQuestFailed || progress.Quests[0].Attempts != 1 {
+		t.Errorf("Expected quest-01 failed after one attempt, got %+v", progress.Quests[0])
+	}
+	for _, hero := range session.GetHeroes() {
+		if !hero.Dead || hero.Inventory != nil {
+			t.Errorf("Expected %s to start over as a new hero, got %+v", hero.HeroID, hero)
+		}
+	}
+}
+
+func TestCampaign_GMPicksQuestAndProgressIsSaved(t *testing.T) {
+	has, gm, session, _, _ := createTestCampaign(t, HeroPhaseActive)
+	has.dynamicTurnOrder.EndQuest(QuestVictory, "every objective is complete")
+
+	_, err := gm.AdvanceCampaign("gm-1", "quest-99")
+	expectGameErrorCode(t, err, "unknown_quest")
+	if quest, err := gm.AdvanceCampaign("gm-1", "quest-03"); err != nil || quest.ID != "quest-03" {
+		t.Fatalf("Expected the GM's pick to be started, got %+v (%v)", quest, err)
+	}
+
+	// The campaign travels with the saved game
+	data, err := session.SerializeForPersistence()
+	if err != nil {
+		t.Fatalf("Failed to serialize the campaign: %v", err)
+	}
+	restored := NewCampaignSession(session.campaign, "gm-1", &MockLogger{})
+	if err := restored.RestoreFromPersistence(data); err != nil {
+		t.Fatalf("Failed to restore the campaign: %v", err)
+	}
+	if !reflect.DeepEqual(restored.GetProgress(), session.GetProgress()) {
+		t.Errorf("Expected %+v after restore, got %+v", session.GetProgress(), restored.GetProgress())
+	}
+	if next, _ := restored.NextQuest(nil); next.ID != "quest-02" {
+		t.Errorf("Expected quest-02 to be next after completing quest-01, got %s", next.ID)
+	}
+}
+
+func TestCampaign_FailedStartRecordsNothingAndCanBeRetried(t *testing.T) {
+	has, gm, session, started, failStart := createTestCampaign(t, HeroPhaseActive)
+	has.dynamicTurnOrder.EndQuest(QuestVictory, "every objective is complete")
+
+	*failStart = true
+	if _, err := gm.AdvanceCampaign("gm-1", ""); err == nil {
+		t.Fatal("Expected the failed start to be reported")
+	}
+	if progress := session.GetProgress(); progress.CurrentQuestID != "quest-01" || progress.Quests[0].Attempts != 0 {
+		t.Errorf("Expected quest-01 still current and unrecorded after a failed start, got %+v", progress)
+	}
+
+	*failStart = false
+	if quest, err := gm.AdvanceCampaign("gm-1", ""); err != nil || quest.ID != "quest-02" || len(*started) != 1 {
+		t.Fatalf("Expected the retry to start quest-02, got %+v, %v (%v)", quest, *started, err)
+	}
+	if progress := session.GetProgress(); progress.Quests[0].Status != CampaignQuestCompleted || progress.Quests[0].Attempts != 1 {
+		t.Errorf("Expected quest-01 recorded once, got %+v", progress.Quests[0])
+	}
+}
@@ -11,12 +11,16 @@ import (
 // ContentManager manages all game content (equipment, treasures, spells, etc.)
 type ContentManager struct {
 	campaign        *CampaignMetadata
+	campaignPath    string // Directory holding campaign.json; deck and quest paths are relative to it
 	equipmentCards  map[string]*ItemCard
 	artifactCards   map[string]*ItemCard
 	treasureCards   map[string]*TreasureCard
 	spellCards      map[string]*SpellCard
 	dreadSpellCards map[string]*SpellCard
+	spellElements   []string            // Spell schools in deck order
+	spellsByElement map[string][]string // Element -> spell IDs in deck order
 	heroCards       map[string]*HeroCard
+	monsterCards    map[string]*MonsterCard
 	logger          Logger
 	mutex           sync.RWMutex
 }
@@ -29,7 +33,9 @@ func NewContentManager(logger Logger) *ContentManager {
 		treasureCards:   make(map[string]*TreasureCard),
 		spellCards:      make(map[string]*SpellCard),
 		dreadSpellCards: make(map[string]*SpellCard),
+		spellsByElement: make(map[string][]string),
 		heroCards:       make(map[string]*HeroCard),
+		monsterCards:    make(map[string]*MonsterCard),
 		logger:          logger,
 	}
 }
@@ -61,6 +67,7 @@ func (cm *ContentManager) LoadCampaign(campaignID string) error {
 		return fmt.Errorf("failed to load campaign metadata: %w", err)
 	}
 	cm.campaign = campaign
+	cm.campaignPath = campaignPath
 
 	// Load equipment deck
 	if err := cm.loadEquipmentDeck(filepath.Join(campaignPath, campaign.Decks.Equipment), campaignPath); err != nil {
@@ -95,6 +102,14 @@ func (cm *ContentManager) LoadCampaign(campaignID string) error {
 		}
 	}
 
+	// Load monsters
+	if campaign.ContentPaths.Monsters != "" {
+		monstersPath := filepath.Join(campaignPath, campaign.ContentPaths.Monsters)
+		if err := cm.loadMonsters(monstersPath); err != nil {
+			return fmt.Errorf("failed to load monsters: %w", err)
+		}
+	}
+
 	cm.logger.Printf("Campaign '%s' loaded successfully", campaign.Name)
 	cm.logger.Printf("  Equipment: %d items", len(cm.equipmentCards))
 	cm.logger.Printf("  Artifacts: %d items", len(cm.artifactCards))
@@ -102,6 +117,7 @@ func (cm *ContentManager) LoadCampaign(campaignID string) error {
 	cm.logger.Printf("  Spells: %d cards", len(cm.spellCards))
 	cm.logger.Printf("  Dread Spells: %d cards", len(cm.dreadSpellCards))
 	cm.logger.Printf("  Heroes: %d characters", len(cm.heroCards))
+	cm.logger.Printf("  Monsters: %d types", len(cm.monsterCards))
 
 	return nil
 }
@@ -220,6 +236,13 @@ func (cm *ContentManager) loadSpellDeck(deckPath string, basePath string) error
 			continue
 		}
 		cm.spellCards[card.ID] = card
+
+		if ref.Element != "" {
+			if _, seen := cm.spellsByElement[ref.Element]; !seen {
+				cm.spellElements = append(cm.spellElements, ref.Element)
+			}
+			cm.spellsByElement[ref.Element] = append(cm.spellsByElement[ref.Element], card.ID)
+		}
 	}
 
 	return nil
@@ -328,6 +351,20 @@ func (cm *ContentManager) GetSpellCard(id string) (*SpellCard, bool) {
 	return card, ok
 }
 
+// GetSpellElements returns the spell schools (elements) in deck order
+func (cm *ContentManager) GetSpellElements() []string {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	return append([]string(nil), cm.spellElements...)
+}
+
+// GetSpellsByElement returns the IDs of the spells in one school
+func (cm *ContentManager) GetSpellsByElement(element string) []string {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	return append([]string(nil), cm.spellsByElement[element]...)
+}
+
 // GetDreadSpellCard retrieves a dread spell card by ID
 func (cm *ContentManager) GetDreadSpellCard(id string) (*SpellCard, bool) {
 	cm.mutex.RLock()
@@ -377,6 +414,13 @@ func (cm *ContentManager) GetCampaign() *CampaignMetadata {
 	return cm.campaign
 }
 
+// GetQuestPath returns the file of a quest listed in the campaign
+func (cm *ContentManager) GetQuestPath(ref CampaignQuestRef) string {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	return filepath.Join(cm.campaignPath, ref.Path)
+}
+
 // loadHeroes loads all hero character definitions from the heroes directory
 func (cm *ContentManager) loadHeroes(heroesPath string) error {
 	// Read all .json files in the heroes directory
@@ -435,3 +479,64 @@ func (cm *ContentManager) GetAllHeroes() map[string]*HeroCard {
 	}
 	return result
 }
+
+// loadMonsters loads all monster definitions from the monsters directory
+func (cm *ContentManager) loadMonsters(monstersPath string) error {
+	entries, err := os.ReadDir(monstersPath)
+	if err != nil {
+		return fmt.Errorf("failed to read monsters directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		monsterPath := filepath.Join(monstersPath, entry.Name())
+		monster, err := cm.loadMonsterCard(monsterPath)
+		if err != nil {
+			cm.logger.Printf("Warning: Failed to load monster %s: %v", entry.Name(), err)
+			continue
+		}
+		cm.monsterCards[monster.ID] = monster
+	}
+
+	return nil
+}
+
+// loadMonsterCard loads a single monster card
+func (cm *ContentManager) loadMonsterCard(path string) (*MonsterCard, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read monster card: %w", err)
+	}
+
+	var card MonsterCard
+	if err := json.Unmarshal(data, &card); err != nil {
+		return nil, fmt.Errorf("failed to parse monster card: %w", err)
+	}
+	if card.ID == "" {
+		return nil, fmt.Errorf("monster card has no id")
+	}
+
+	return &card, nil
+}
+
+// GetMonsterCard retrieves a monster card by ID
+func (cm *ContentManager) GetMonsterCard(id string) (*MonsterCard, bool) {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	card, ok := cm.monsterCards[id]
+	return card, ok
+}
+
+// GetAllMonsters returns all monster cards
+func (cm *ContentManager) GetAllMonsters() map[string]*MonsterCard {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	result := make(map[string]*MonsterCard, len(cm.monsterCards))
+	for k, v := range cm.monsterCards {
+		result[k] = v
+	}
+	return result
+}
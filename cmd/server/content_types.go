@@ -217,3 +217,60 @@ type HeroStartingEquipment struct {
 	Armor   []string `json:"armor"`
 	Items   []string `json:"items"`
 }
+
+// MonsterCard represents a monster definition (see asset_schemas/monster_schema_template.json)
+type MonsterCard struct {
+	ID                 string                    `json:"id"`
+	Name               string                    `json:"name"`
+	Description        string                    `json:"description,omitempty"`
+	Stats              MonsterStats              `json:"stats"`
+	Rendering          MonsterRendering          `json:"rendering"`
+	GridSize           MonsterGridSize           `json:"gridSize"`
+	GameplayProperties MonsterGameplayProperties `json:"gameplayProperties"`
+}
+
+// MonsterStats are the printed stats on a monster card
+type MonsterStats struct {
+	MovementSquares int `json:"movementSquares"`
+	AttackDice      int `json:"attackDice"`
+	DefendDice      int `json:"defendDice"`
+	BodyPoints      int `json:"bodyPoints"`
+	MindPoints      int `json:"mindPoints"`
+}
+
+// MonsterRendering holds the images used to draw a monster
+type MonsterRendering struct {
+	CardImage        string `json:"cardImage,omitempty"`
+	CardBack         string `json:"cardBack,omitempty"`
+	TileImage        string `json:"tileImage,omitempty"`
+	TileImageCleaned string `json:"tileImageCleaned,omitempty"`
+}
+
+// MonsterGridSize is the number of squares a monster covers
+type MonsterGridSize struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// MonsterGameplayProperties describes how a monster behaves on the board
+type MonsterGameplayProperties struct {
+	BlocksLineOfSight bool                 `json:"blocksLineOfSight"`
+	BlocksMovement    bool                 `json:"blocksMovement"`
+	Faction           string               `json:"faction,omitempty"`
+	SubType           string               `json:"subType,omitempty"` // e.g., "undead"
+	Abilities         []MonsterAbilityCard `json:"abilities,omitempty"`
+	CustomProperties  map[string]any       `json:"customProperties,omitempty"`
+}
+
+// MonsterAbilityCard is a special ability declared on a monster card
+type MonsterAbilityCard struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Type           string         `json:"type"` // "passive", "active"
+	UsesPerTurn    int            `json:"usesPerTurn,omitempty"`
+	UsesPerQuest   int            `json:"usesPerQuest,omitempty"`
+	RequiresAction bool           `json:"requiresAction,omitempty"`
+	Range          int            `json:"range,omitempty"` // 0 = self, -1 = unlimited
+	Description    string         `json:"description,omitempty"`
+	Effect         map[string]any `json:"effect,omitempty"`
+}
@@ -27,6 +27,7 @@ type DebugSystem struct {
 	gameState       *GameState
 	broadcaster     Broadcaster
 	logger          Logger
+	snapshotter     GameSnapshotter
 	diceOverride    map[string]int   // Override next dice rolls (single die)
 	diceOverrideSeq map[string][]int // Override sequences for multiple dice
 }
@@ -43,6 +44,11 @@ func NewDebugSystem(config DebugConfig, gameState *GameState, broadcaster Broadc
 	}
 }
 
+// SetSnapshotter wires the system used to export and import the full game state
+func (ds *DebugSystem) SetSnapshotter(snapshotter GameSnapshotter) {
+	ds.snapshotter = snapshotter
+}
+
 // SetDiceOverride sets an override for a specific dice roll type (for testing)
 func (ds *DebugSystem) SetDiceOverride(rollType string, value int) {
 	if ds.config.AllowDiceOverride {
@@ -730,26 +736,26 @@ func (ds *DebugSystem) handleExportState(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ds.gameState.Lock.Lock()
-	exportData := map[string]any{
-		"entities":        ds.gameState.Entities,
-		"doors":           ds.gameState.Doors,
-		"revealedRegions": ds.gameState.RevealedRegions,
-		"knownRegions":    ds.gameState.KnownRegions,
-		"knownDoors":      ds.gameState.KnownDoors,
-		"timestamp":       time.Now(),
-		"version":         "1.0",
+	if ds.snapshotter == nil {
+		http.Error(w, "State export not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	snapshot, err := ds.snapshotter.CaptureSnapshot()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to export state: %v", err), http.StatusInternalServerError)
+		return
 	}
-	ds.gameState.Lock.Unlock()
 
 	ds.logDebugAction("export_state", map[string]any{
-		"exportSize": fmt.Sprintf("%d entities, %d doors", len(ds.gameState.Entities), len(ds.gameState.Doors)),
+		"cycleNumber": snapshot.CycleNumber,
+		"lastEventId": snapshot.LastEventID,
 	})
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]any{
 		"success": true,
-		"data":    exportData,
+		"data":    snapshot,
 	})
 }
 
@@ -759,18 +765,41 @@ func (ds *DebugSystem) handleImportState(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if !ds.config.AllowStateChanges {
+		http.Error(w, "State changes not allowed", http.StatusForbidden)
+		return
+	}
+
+	if ds.snapshotter == nil {
+		http.Error(w, "State import not available", http.StatusServiceUnavailable)
+		return
+	}
+
 	var req struct {
-		Data map[string]any `json:"data"`
+		Data *GameSnapshotData `json:"data"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid JSON", http.StatusBadRequest)
 		return
 	}
+	if req.Data == nil {
+		http.Error(w, "Missing state data", http.StatusBadRequest)
+		return
+	}
+
+	if err := ds.snapshotter.ImportSnapshot(req.Data); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to import state: %v", err), http.StatusBadRequest)
+		return
+	}
 
-	// TODO: Implement state import logic with validation
 	ds.logDebugAction("import_state", map[string]any{
-		"hasData": req.Data != nil,
+		"cycleNumber": req.Data.CycleNumber,
+	})
+
+	// Clients rebuild their view from a fresh snapshot
+	ds.broadcaster.BroadcastEvent("SessionLoaded", protocol.SessionLoaded{
+		CycleNumber: req.Data.CycleNumber,
 	})
 
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// Dread spell effects beyond the shared spell vocabulary (damage, attack, sleep)
+const (
+	SpellEffectMindRoll     = "mind_roll"     // Hero rolls one die per mind point; without a 6 loses EffectValue mind points
+	SpellEffectSummonUndead = "summon_undead" // EffectValue skeletons appear next to the caster
+)
+
+// DreadSpellResult describes the outcome of a monster casting a dread spell
+type DreadSpellResult struct {
+	MonsterID string     `json:"monsterId"`
+	SpellID   string     `json:"spellId"`
+	SpellName string     `json:"spellName"`
+	TargetID  string     `json:"targetId,omitempty"`
+	Effect    string     `json:"effect"`
+	Value     int        `json:"value"`
+	Resisted  bool       `json:"resisted"`
+	DiceRolls []DiceRoll `json:"diceRolls,omitempty"`
+	Summoned  []string   `json:"summoned,omitempty"`
+	UsesLeft  int        `json:"usesLeft"`
+}
+
+// SetHeroLifecycle sets the hero lifecycle system told when a dread spell brings a hero down
+func (ms *MonsterSystem) SetHeroLifecycle(heroLifecycle *HeroLifecycleSystem) {
+	ms.heroLifecycle = heroLifecycle
+}
+
+// SetFurnitureSystem sets the furniture system whose pieces summoned and spawned monsters cannot stand on
+func (ms *MonsterSystem) SetFurnitureSystem(furnitureSystem *FurnitureSystem) {
+	ms.furnitureSystem = furnitureSystem
+}
+
+// AssignDreadSpell gives a monster a dread spell it may cast uses times this quest
+func (ms *MonsterSystem) AssignDreadSpell(monsterID string, spellID string, uses int) error {
+	monster, exists := ms.monsters[monsterID]
+	if !exists {
+		return fmt.Errorf("monster %s not found", monsterID)
+	}
+
+	if ms.contentManager != nil {
+		if _, ok := ms.contentManager.GetDreadSpellCard(spellID); !ok {
+			return fmt.Errorf("dread spell %s not found in content", spellID)
+		}
+	}
+
+	if uses <= 0 {
+		uses = 1
+	}
+	if monster.DreadSpells == nil {
+		monster.DreadSpells = make(map[string]int)
+	}
+	monster.DreadSpells[spellID] += uses
+
+	ms.logger.Printf("Monster %s can cast %s %d time(s) this quest", monsterID, spellID, monster.DreadSpells[spellID])
+	return nil
+}
+
+// HasDreadSpell reports whether a monster was assigned a dread spell
+func (ms *MonsterSystem) HasDreadSpell(monsterID string, spellID string) bool {
+	monster, exists := ms.monsters[monsterID]
+	if !exists {
+		return false
+	}
+	_, ok := monster.DreadSpells[spellID]
+	return ok
+}
+
+// CastDreadSpell casts one of a monster's dread spells at a hero (or, for summons, at no target)
+func (ms *MonsterSystem) CastDreadSpell(monsterID string, spellID string, targetID string) (*DreadSpellResult, error) {
+	monster, exists := ms.monsters[monsterID]
+	if !exists {
+		return nil, fmt.Errorf("monster %s not found", monsterID)
+	}
+	if !monster.IsAlive {
+		return nil, fmt.Errorf("monster %s is dead", monsterID)
+	}
+	if monster.IsAsleep {
+		return nil, &GameError{Code: "monster_asleep", Message: fmt.Sprintf("monster %s is asleep", monsterID)}
+	}
+
+	usesLeft, known := monster.DreadSpells[spellID]
+	if !known {
+		return nil, &GameError{Code: "spell_not_known", Message: fmt.Sprintf("monster %s cannot cast %s", monsterID, spellID)}
+	}
+	if usesLeft <= 0 {
+		return nil, &GameError{Code: "no_uses_left", Message: fmt.Sprintf("%s has no casts of %s left this quest", monsterID, spellID)}
+	}
+
+	if ms.contentManager == nil {
+		return nil, fmt.Errorf("content manager not initialized")
+	}
+	spell, ok := ms.contentManager.GetDreadSpellCard(spellID)
+	if !ok {
+		return nil, fmt.Errorf("dread spell %s not found in content", spellID)
+	}
+
+	result := &DreadSpellResult{
+		MonsterID: monsterID,
+		SpellID:   spell.ID,
+		SpellName: spell.Name,
+		Effect:    spell.Effect,
+		Value:     spellEffectValue(spell),
+	}
+
+	if spell.Effect == SpellEffectSummonUndead {
+		if err := ms.summonUndead(monster, result); err != nil {
+			return nil, err
+		}
+	} else {
+		hero, err := ms.resolveDreadSpellTarget(monster, spell, targetID)
+		if err != nil {
+			return nil, err
+		}
+		result.TargetID = targetID
+		if err := ms.applyDreadSpellToHero(spell, hero, result); err != nil {
+			return nil, err
+		}
+	}
+
+	monster.DreadSpells[spellID]--
+	result.UsesLeft = monster.DreadSpells[spellID]
+	ms.broadcastMonsterUpdate(monster)
+
+	ms.logger.Printf("Monster %s cast %s on %s (resisted: %t, %d casts left)", monsterID, spell.Name, result.TargetID, result.Resisted, result.UsesLeft)
+	return result, nil
+}
+
+// resolveDreadSpellTarget finds the target hero and checks range and line of sight from the caster
+func (ms *MonsterSystem) resolveDreadSpellTarget(monster *Monster, spell *SpellCard, targetID string) (*Player, error) {
+	if targetID == "" {
+		return nil, &GameError{Code: "missing_target", Message: fmt.Sprintf("%s needs a target", spell.Name)}
+	}
+	if ms.turnManager == nil {
+		return nil, fmt.Errorf("turn manager not initialized")
+	}
+
+	var hero *Player
+	for _, player := range ms.turnManager.GetHeroPlayers() {
+		if player.EntityID == targetID {
+			hero = player
+			break
+		}
+	}
+	if hero == nil || hero.Character == nil {
+		return nil, &GameError{Code: "invalid_target", Message: fmt.Sprintf("%s can only target heroes", spell.Name)}
+	}
+
+	ms.gameState.Lock.Lock()
+	defer ms.gameState.Lock.Unlock()
+
+	heroPos, onBoard := ms.gameState.Entities[targetID]
+	if !onBoard {
+		return nil, &GameError{Code: "invalid_target", Message: fmt.Sprintf("hero %s is not on the board", targetID)}
+	}
+
+	if spell.Range > 0 {
+		if distance := ms.calculateDistance(monster.Position, heroPos); distance > spell.Range {
+			return nil, &GameError{Code: "out_of_range", Message: fmt.Sprintf("%s is %d squares away; %s reaches %d", targetID, distance, spell.Name, spell.Range)}
+		}
+	}
+
+	if ms.rules.checksLineOfSight() && !ms.visibility.IsTileCenterVisible(ms.gameState, monster.Position.X, monster.Position.Y, heroPos.X, heroPos.Y) {
+		return nil, &GameError{Code: "no_line_of_sight", Message: fmt.Sprintf("%s cannot see %s", monster.ID, targetID)}
+	}
+
+	return hero, nil
+}
+
+// applyDreadSpellToHero resolves a dread spell's effect on a hero. Heroes resist damage with
+// white and black shields; sleep and mind rolls are resisted by rolling a 6 on any die.
+// A hero holding an artifact that wards off the spell is not affected at all.
+func (ms *MonsterSystem) applyDreadSpellToHero(spell *SpellCard, hero *Player, result *DreadSpellResult) error {
+	character := hero.Character
+
+	if ms.inventoryManager != nil {
+		if ward := ms.inventoryManager.SpellWard(hero.EntityID, spell); ward != nil {
+			result.Value = 0
+			result.Resisted = true
+			ms.logger.Printf("%s protected %s from %s", ward.Name, hero.EntityID, spell.Name)
+			return nil
+		}
+	}
+
+	switch spell.Effect {
+	case SpellEffectDamage, SpellEffectAttack:
+		damage := result.Value
+		if spell.Effect == SpellEffectAttack {
+			attackRolls := ms.diceSystem.RollAttackDice(result.Value)
+			defenseRolls := ms.diceSystem.RollDefenseDice(character.GetEffectiveDefenseDice())
+			result.DiceRolls = append(attackRolls, defenseRolls...)
+			damage = CalculateHeroCombatDamage(attackRolls, defenseRolls)
+		} else if spell.AttackType == "resistible" {
+			result.DiceRolls = ms.diceSystem.RollDice(CombatDie, result.Value, "spell_resist")
+			damage = CalculateHeroCombatDamage(skullsFor(result.Value), result.DiceRolls)
+		}
+
+		result.Value = damage
+		result.Resisted = damage == 0
+		if damage > 0 {
+			character.TakeDamage(damage)
+			character.IsAsleep = false
+		}
+		ms.logger.Printf("%s dealt %d damage to %s (%d/%d body)", spell.Name, damage, hero.EntityID, character.CurrentBody, character.BaseStats.BodyPoints)
+		if damage > 0 && ms.heroLifecycle != nil {
+			ms.heroLifecycle.CheckHero(hero.EntityID)
+		}
+
+	case SpellEffectSleep, SpellEffectMindRoll:
+		result.DiceRolls = ms.diceSystem.RollDice(CombatDie, character.CurrentMind, "spell_resist")
+		for _, roll := range result.DiceRolls {
+			if roll.CombatResult == BlackShield {
+				result.Resisted = true
+				break
+			}
+		}
+		if result.Resisted {
+			break
+		}
+
+		if spell.Effect == SpellEffectSleep {
+			character.IsAsleep = true
+			ms.logger.Printf("%s put %s to sleep", spell.Name, hero.EntityID)
+		} else {
+			character.CurrentMind = max(character.CurrentMind-result.Value, 0)
+			ms.logger.Printf("%s cost %s %d mind points (%d left)", spell.Name, hero.EntityID, result.Value, character.CurrentMind)
+		}
+
+	default:
+		return &GameError{Code: "unknown_spell_effect", Message: fmt.Sprintf("dread spell effect %q is not supported", spell.Effect)}
+	}
+
+	return nil
+}
+
+// summonUndead places skeletons on free squares within two squares of the caster, on its
+// side of any walls and closed doors
+func (ms *MonsterSystem) summonUndead(caster *Monster, result *DreadSpellResult) error {
+	count := max(result.Value, 1)
+
+	for _, position := range ms.freeSquaresNear(caster.Position, 2) {
+		if len(result.Summoned) == count {
+			break
+		}
+
+		summoned, err := ms.SpawnMonster(Skeleton, position)
+		if err != nil {
+			continue
+		}
+		summoned.IsVisible = caster.IsVisible
+		if summoned.IsVisible {
+			ms.broadcastMonsterUpdate(summoned)
+		}
+		result.Summoned = append(result.Summoned, summoned.ID)
+	}
+
+	if len(result.Summoned) == 0 {
+		return &GameError{Code: "no_space", Message: "no free squares to summon undead"}
+	}
+	result.Value = len(result.Summoned)
+	return nil
+}
+
+// freeSquaresNear lists the free squares within reach steps of a square, nearest first,
+// spreading through open edges only (diagonally round either corner)
+func (ms *MonsterSystem) freeSquaresNear(from protocol.TileAddress, reach int) []protocol.TileAddress {
+	free := []protocol.TileAddress{}
+	visited := map[protocol.TileAddress]bool{from: true}
+	frontier := []protocol.TileAddress{from}
+	for distance := 1; distance <= reach; distance++ {
+		next := []protocol.TileAddress{}
+		for _, current := range frontier {
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					square := protocol.TileAddress{SegmentID: from.SegmentID, X: current.X + dx, Y: current.Y + dy}
+					if visited[square] || ms.validatePosition(square) != nil || !ms.onBoard(square) || !ms.adjacentOpen(current, square) {
+						continue
+					}
+					visited[square] = true
+					next = append(next, square)
+					if ms.isFreeSquare(square) {
+						free = append(free, square)
+					}
+				}
+			}
+		}
+		frontier = next
+	}
+	return free
+}
+
+// adjacentOpen reports whether nothing closed lies between two adjacent squares
+func (ms *MonsterSystem) adjacentOpen(from, to protocol.TileAddress) bool {
+	ms.gameState.Lock.Lock()
+	defer ms.gameState.Lock.Unlock()
+
+	return adjacentOpen(ms.gameState, from, to)
+}
+
+// isFreeSquare checks that a square is on the board, not blocked by a wall tile or furniture,
+// and not occupied by any entity
+func (ms *MonsterSystem) isFreeSquare(position protocol.TileAddress) bool {
+	if ms.validatePosition(position) != nil {
+		return false
+	}
+	if ms.furnitureSystem != nil && ms.furnitureSystem.BlocksMovement(position.X, position.Y) {
+		return false
+	}
+
+	ms.gameState.Lock.Lock()
+	defer ms.gameState.Lock.Unlock()
+
+	if ms.gameState.Segment.Width > 0 && (position.X >= ms.gameState.Segment.Width || position.Y >= ms.gameState.Segment.Height) {
+		return false
+	}
+	if ms.gameState.BlockedTiles[protocol.TileAddress{X: position.X, Y: position.Y}] {
+		return false
+	}
+	for _, occupied := range ms.gameState.Entities {
+		if occupied.X == position.X && occupied.Y == position.Y {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// createTestDreadCaster places a spellcasting monster three squares from hero-1
+func createTestDreadCaster(t *testing.T, spells ...*SpellCard) (*HeroActionSystem, *MonsterSystem) {
+	has := createTestHeroActionSystem()
+
+	contentManager := NewContentManager(&MockLogger{})
+	for _, spell := range spells {
+		contentManager.dreadSpellCards[spell.ID] = spell
+	}
+	contentManager.monsterCards["skeleton"] = &MonsterCard{ID: "skeleton", Name: "Skeleton", Stats: MonsterStats{MovementSquares: 6, AttackDice: 2, DefendDice: 2, BodyPoints: 1}}
+
+	ms := NewMonsterSystem(has.gameState, has.turnManager, has.diceSystem, &MockBroadcaster{}, &MockLogger{})
+	ms.SetContentManager(contentManager)
+	ms.monsters["caster"] = &Monster{ID: "caster", Type: Orc, Position: protocol.TileAddress{X: 8, Y: 5}, Body: 1, MaxBody: 1, IsAlive: true, IsVisible: true}
+	has.gameState.Entities["caster"] = protocol.TileAddress{X: 8, Y: 5}
+	has.SetMonsterSystem(ms)
+
+	for _, spell := range spells {
+		if err := ms.AssignDreadSpell("caster", spell.ID, 1); err != nil {
+			t.Fatalf("Failed to assign %s: %v", spell.ID, err)
+		}
+	}
+	return has, ms
+}
+
+func TestDreadSpell_DamageUsesUpCasts(t *testing.T) {
+	has, ms := createTestDreadCaster(t, &SpellCard{ID: "lightning_bolt", Name: "Lightning Bolt", Effect: SpellEffectDamage, EffectValue: 2, AttackType: "resistible"})
+	has.debugSystem.SetDiceOverride("spell_resist", 1)
+
+	result, err := ms.CastDreadSpell("caster", "lightning_bolt", "hero-1")
+	if err != nil {
+		t.Fatalf("Expected dread spell to be cast, got: %v", err)
+	}
+	if result.Value != 2 || result.UsesLeft != 0 {
+		t.Errorf("Expected 2 damage and no casts left, got %d damage / %d left", result.Value, result.UsesLeft)
+	}
+
+	player := has.turnManager.GetPlayer("player-1")
+	if player.Character.CurrentBody != player.Character.BaseStats.BodyPoints-2 {
+		t.Errorf("Expected hero to lose 2 body, got %d/%d", player.Character.CurrentBody, player.Character.BaseStats.BodyPoints)
+	}
+
+	if _, err := ms.CastDreadSpell("caster", "lightning_bolt", "hero-1"); err == nil {
+		t.Error("Expected a spent dread spell to fail")
+	}
+}
+
+func TestDreadSpell_RequiresLineOfSight(t *testing.T) {
+	has, ms := createTestDreadCaster(t, &SpellCard{ID: "lightning_bolt", Name: "Lightning Bolt", Effect: SpellEffectDamage, EffectValue: 2})
+
+	// Wall between the caster at (8,5) and the hero at (5,5)
+	has.gameState.BlockedWalls = map[geometry.EdgeAddress]bool{
+		{X: 7, Y: 5, Orientation: geometry.Vertical}: true,
+	}
+
+	if _, err := ms.CastDreadSpell("caster", "lightning_bolt", "hero-1"); err == nil {
+		t.Fatal("Expected casting without line of sight to fail")
+	}
+	if monster, _ := ms.GetMonsterByID("caster"); monster.DreadSpells["lightning_bolt"] != 1 {
+		t.Error("Expected a failed cast not to use up the spell")
+	}
+}
+
+func TestDreadSpell_SleepBlocksHeroActions(t *testing.T) {
+	has, ms := createTestDreadCaster(t, &SpellCard{ID: "dread_sleep", Name: "Sleep", Effect: SpellEffectSleep})
+	has.debugSystem.SetDiceOverride("spell_resist", 2)
+
+	result, err := ms.CastDreadSpell("caster", "dread_sleep", "hero-1")
+	if err != nil {
+		t.Fatalf("Expected sleep to be cast, got: %v", err)
+	}
+	if result.Resisted {
+		t.Fatal("Expected hero without a black shield to fall asleep")
+	}
+
+	_, err = has.ProcessAction(ActionRequest{
+		PlayerID:   "player-1",
+		EntityID:   "hero-1",
+		Action:     SearchTreasureAction,
+		Parameters: map[string]any{},
+	})
+	if gameErr, ok := err.(*GameError); !ok || gameErr.Code != "hero_asleep" {
+		t.Errorf("Expected hero_asleep error, got: %v", err)
+	}
+}
+
+func TestDreadSpell_SummonUndeadNextToCaster(t *testing.T) {
+	_, ms := createTestDreadCaster(t, &SpellCard{ID: "summon_undead", Name: "Summon Undead", Effect: SpellEffectSummonUndead, EffectValue: 2})
+
+	result, err := ms.CastDreadSpell("caster", "summon_undead", "")
+	if err != nil {
+		t.Fatalf("Expected summon to succeed, got: %v", err)
+	}
+	if len(result.Summoned) != 2 {
+		t.Fatalf("Expected 2 skeletons, got %d", len(result.Summoned))
+	}
+
+	for _, id := range result.Summoned {
+		skeleton, err := ms.GetMonsterByID(id)
+		if err != nil {
+			t.Fatalf("Summoned monster missing: %v", err)
+		}
+		if skeleton.Type != Skeleton || !skeleton.IsVisible {
+			t.Errorf("Expected a visible skeleton, got %s (visible: %t)", skeleton.Type, skeleton.IsVisible)
+		}
+		if distance := max(absInt(skeleton.Position.X-8), absInt(skeleton.Position.Y-5)); distance != 1 {
+			t.Errorf("Expected skeleton next to the caster, got (%d,%d)", skeleton.Position.X, skeleton.Position.Y)
+		}
+	}
+}
+
+func TestDreadSpell_SummonUndeadStaysOnCastersSideOfWalls(t *testing.T) {
+	has, ms := createTestDreadCaster(t, &SpellCard{ID: "summon_undead", Name: "Summon Undead", Effect: SpellEffectSummonUndead, EffectValue: 2})
+
+	// The caster at (8,5) is walled in on three sides and the square west of it is rock
+	has.gameState.BlockedWalls = map[geometry.EdgeAddress]bool{
+		edgeForStep(8, 5, 0, -1): true,
+		edgeForStep(8, 5, 1, 0):  true,
+		edgeForStep(8, 5, 0, 1):  true,
+	}
+	has.gameState.BlockedTiles = map[protocol.TileAddress]bool{{X: 7, Y: 5}: true}
+
+	// A table covers the square south-west of the caster
+	table := &FurnitureDefinition{ID: "table", BlocksMovement: true}
+	table.GridSize.Width, table.GridSize.Height = 1, 1
+	furniture := NewFurnitureSystem(nil)
+	furniture.instances["table-1"] = &FurnitureInstance{ID: "table-1", Position: protocol.TileAddress{X: 7, Y: 6}, Definition: table}
+	ms.SetFurnitureSystem(furniture)
+
+	result, err := ms.CastDreadSpell("caster", "summon_undead", "")
+	if err != nil || len(result.Summoned) != 2 {
+		t.Fatalf("Expected 2 skeletons summoned, got %+v (%v)", result, err)
+	}
+
+	// Only (7,4) is open next to the caster; the second skeleton goes on round the corner
+	want := []protocol.TileAddress{{X: 7, Y: 4}, {X: 6, Y: 3}}
+	for i, id := range result.Summoned {
+		skeleton, _ := ms.GetMonsterByID(id)
+		if skeleton.Position.X != want[i].X || skeleton.Position.Y != want[i].Y {
+			t.Errorf("Expected skeleton %d at (%d,%d), got (%d,%d)", i+1, want[i].X, want[i].Y, skeleton.Position.X, skeleton.Position.Y)
+		}
+	}
+}
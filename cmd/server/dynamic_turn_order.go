@@ -1,7 +1,10 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 )
@@ -21,8 +24,24 @@ const (
 
 	// GMPhase - GM controlling monsters and environment
 	GMPhase TurnPhaseType = "gm_phase"
+
+	// QuestEndedPhase - the quest is over; no further turns are taken
+	QuestEndedPhase TurnPhaseType = "quest_ended"
+)
+
+// Quest results (QuestOutcome.Result)
+const (
+	QuestVictory = "victory"
+	QuestDefeat  = "defeat"
 )
 
+// QuestOutcome records how and when a quest ended
+type QuestOutcome struct {
+	Result string `json:"result"` // QuestVictory or QuestDefeat
+	Reason string `json:"reason"`
+	Cycle  int    `json:"cycle"`
+}
+
 // SimpleMonsterTurnState tracks basic state of a monster during the GM phase
 // This is a lightweight version used by DynamicTurnOrderManager for phase tracking
 type SimpleMonsterTurnState struct {
@@ -39,16 +58,23 @@ type DynamicTurnOrderManager struct {
 	// Hero phase tracking
 	activeHeroPlayerID   string          // Currently acting hero (during HeroPhaseActive)
 	heroesActedThisCycle map[string]bool // PlayerID -> has acted this cycle
+	heroesOutOfPlay      map[string]bool // PlayerID -> dying or dead; skipped in the hero phase
 	electedPlayerID      string          // Player who elected themselves as next (during election)
 	electionStartTime    *time.Time      // When election started (for timeout handling)
 
 	// Quest setup tracking
 	playersReady         map[string]bool     // PlayerID -> ready state
 	playerStartPositions map[string]Position // PlayerID -> chosen starting position
+	spellElements        []string            // Spell schools offered to casters
+	spellDraft           []SpellDraftPick    // Remaining picks, in order
+	spellSchools         map[string]string   // Element -> PlayerID who took it
 
 	// GM phase tracking
 	monsterTurnStates map[string]*SimpleMonsterTurnState // MonsterID -> turn state
 
+	// Quest end
+	questOutcome *QuestOutcome
+
 	// Configuration
 	electionTimeoutSec int  // Seconds before auto-selecting a random player (0 = disabled)
 	requireAllHeroes   bool // Whether all heroes must act before advancing to GM phase
@@ -57,6 +83,12 @@ type DynamicTurnOrderManager struct {
 	mutex  sync.RWMutex
 }
 
+// SpellDraftPick is one step of the spell school draft
+type SpellDraftPick struct {
+	PlayerID string `json:"playerId"`
+	TakeRest bool   `json:"takeRest,omitempty"` // Receives every school still unchosen
+}
+
 // Position represents a tile position
 type Position struct {
 	X int `json:"x"`
@@ -69,8 +101,10 @@ func NewDynamicTurnOrderManager(logger Logger) *DynamicTurnOrderManager {
 		currentPhase:         QuestSetupPhase,
 		cycleNumber:          0,
 		heroesActedThisCycle: make(map[string]bool),
+		heroesOutOfPlay:      make(map[string]bool),
 		playersReady:         make(map[string]bool),
 		playerStartPositions: make(map[string]Position),
+		spellSchools:         make(map[string]string),
 		monsterTurnStates:    make(map[string]*SimpleMonsterTurnState),
 		electionTimeoutSec:   0,    // Disabled by default
 		requireAllHeroes:     true, // All heroes must act by default
@@ -124,6 +158,92 @@ func (dtom *DynamicTurnOrderManager) AreAllPlayersReady() bool {
 	return len(dtom.playersReady) > 0
 }
 
+// StartSpellSelection begins the spell school draft with the given picks in order
+func (dtom *DynamicTurnOrderManager) StartSpellSelection(elements []string, picks []SpellDraftPick) error {
+	dtom.mutex.Lock()
+	defer dtom.mutex.Unlock()
+
+	if dtom.currentPhase != QuestSetupPhase {
+		return &GameError{Code: "invalid_phase", Message: "spell schools can only be chosen during quest setup"}
+	}
+
+	dtom.spellElements = append([]string(nil), elements...)
+	dtom.spellDraft = append([]SpellDraftPick(nil), picks...)
+	dtom.spellSchools = make(map[string]string)
+	dtom.advanceSpellDraftLocked()
+
+	dtom.logger.Printf("Spell selection started: %d schools, %d picks", len(dtom.spellElements), len(dtom.spellDraft))
+	return nil
+}
+
+// ChooseSpellSchool records the current picker's chosen spell school
+func (dtom *DynamicTurnOrderManager) ChooseSpellSchool(playerID string, element string) error {
+	dtom.mutex.Lock()
+	defer dtom.mutex.Unlock()
+
+	if dtom.currentPhase != QuestSetupPhase {
+		return &GameError{Code: "invalid_phase", Message: "spell schools can only be chosen during quest setup"}
+	}
+
+	if len(dtom.spellDraft) == 0 {
+		return &GameError{Code: "selection_complete", Message: "all spell schools have been chosen"}
+	}
+
+	if dtom.spellDraft[0].PlayerID != playerID {
+		return &GameError{Code: "not_your_pick", Message: "another player chooses a spell school next"}
+	}
+
+	if !slices.Contains(dtom.spellElements, element) {
+		return &GameError{Code: "unknown_element", Message: fmt.Sprintf("no spell school %q", element)}
+	}
+
+	if owner, taken := dtom.spellSchools[element]; taken {
+		return &GameError{Code: "element_taken", Message: fmt.Sprintf("%s spells were already taken by %s", element, owner)}
+	}
+
+	dtom.spellSchools[element] = playerID
+	dtom.spellDraft = dtom.spellDraft[1:]
+	dtom.advanceSpellDraftLocked()
+
+	dtom.logger.Printf("Player %s chose %s spells", playerID, element)
+	return nil
+}
+
+// GetSpellSchools returns which player took each spell school
+func (dtom *DynamicTurnOrderManager) GetSpellSchools() map[string]string {
+	dtom.mutex.RLock()
+	defer dtom.mutex.RUnlock()
+
+	schools := make(map[string]string, len(dtom.spellSchools))
+	maps.Copy(schools, dtom.spellSchools)
+	return schools
+}
+
+// GetSpellElements returns the spell schools offered in the draft
+func (dtom *DynamicTurnOrderManager) GetSpellElements() []string {
+	dtom.mutex.RLock()
+	defer dtom.mutex.RUnlock()
+	return append([]string(nil), dtom.spellElements...)
+}
+
+// GetNextSpellPicker returns the player who chooses a spell school next, or "" when done
+func (dtom *DynamicTurnOrderManager) GetNextSpellPicker() string {
+	dtom.mutex.RLock()
+	defer dtom.mutex.RUnlock()
+
+	if len(dtom.spellDraft) == 0 {
+		return ""
+	}
+	return dtom.spellDraft[0].PlayerID
+}
+
+// IsSpellSelectionComplete checks whether every spell school pick has been made
+func (dtom *DynamicTurnOrderManager) IsSpellSelectionComplete() bool {
+	dtom.mutex.RLock()
+	defer dtom.mutex.RUnlock()
+	return len(dtom.spellDraft) == 0
+}
+
 // StartQuestAfterSetup transitions from setup to first hero phase election
 func (dtom *DynamicTurnOrderManager) StartQuestAfterSetup() error {
 	dtom.mutex.Lock()
@@ -137,6 +257,10 @@ func (dtom *DynamicTurnOrderManager) StartQuestAfterSetup() error {
 		return &GameError{Code: "not_ready", Message: "not all players are ready"}
 	}
 
+	if len(dtom.spellDraft) > 0 {
+		return &GameError{Code: "spells_not_chosen", Message: "spell schools have not all been chosen"}
+	}
+
 	// Start first hero phase cycle with election
 	dtom.currentPhase = HeroPhaseElection
 	dtom.cycleNumber = 1
@@ -164,6 +288,10 @@ func (dtom *DynamicTurnOrderManager) ElectSelfAsNextPlayer(playerID string) erro
 		return &GameError{Code: "already_acted", Message: "player has already acted this cycle"}
 	}
 
+	if dtom.heroesOutOfPlay[playerID] {
+		return &GameError{Code: "hero_out_of_play", Message: "player's hero is dying or dead"}
+	}
+
 	// Check if another player has already elected themselves
 	if dtom.electedPlayerID != "" && dtom.electedPlayerID != playerID {
 		return &GameError{Code: "already_elected", Message: "another player has already elected themselves"}
@@ -237,8 +365,8 @@ func (dtom *DynamicTurnOrderManager) GetEligibleHeroes(allPlayerIDs []string) []
 		if playerID == "gamemaster" {
 			continue
 		}
-		// Include only heroes who haven't acted this cycle
-		if !dtom.heroesActedThisCycle[playerID] {
+		// Include only heroes still in play who haven't acted this cycle
+		if !dtom.heroesActedThisCycle[playerID] && !dtom.heroesOutOfPlay[playerID] {
 			eligible = append(eligible, playerID)
 		}
 	}
@@ -246,6 +374,60 @@ func (dtom *DynamicTurnOrderManager) GetEligibleHeroes(allPlayerIDs []string) []
 	return eligible
 }
 
+// SetHeroOutOfPlay takes a dying or dead hero out of the hero phase, or brings a rescued hero back.
+// If every hero still in play has already acted, the GM phase begins.
+func (dtom *DynamicTurnOrderManager) SetHeroOutOfPlay(playerID string, outOfPlay bool) {
+	dtom.mutex.Lock()
+	defer dtom.mutex.Unlock()
+
+	if outOfPlay {
+		dtom.heroesOutOfPlay[playerID] = true
+		if dtom.electedPlayerID == playerID {
+			dtom.electedPlayerID = ""
+		}
+	} else {
+		delete(dtom.heroesOutOfPlay, playerID)
+	}
+	dtom.logger.Printf("Player %s out of play: %t", playerID, outOfPlay)
+
+	if dtom.currentPhase == HeroPhaseElection && dtom.shouldAdvanceToGMPhase() {
+		dtom.advanceToGMPhaseLocked()
+	}
+}
+
+// IsHeroOutOfPlay reports whether a player's hero is dying or dead
+func (dtom *DynamicTurnOrderManager) IsHeroOutOfPlay(playerID string) bool {
+	dtom.mutex.RLock()
+	defer dtom.mutex.RUnlock()
+	return dtom.heroesOutOfPlay[playerID]
+}
+
+// EndQuest ends the quest with the given outcome; no further turns are taken
+func (dtom *DynamicTurnOrderManager) EndQuest(result, reason string) *QuestOutcome {
+	dtom.mutex.Lock()
+	defer dtom.mutex.Unlock()
+
+	if dtom.questOutcome != nil {
+		return dtom.questOutcome
+	}
+
+	dtom.questOutcome = &QuestOutcome{Result: result, Reason: reason, Cycle: dtom.cycleNumber}
+	dtom.currentPhase = QuestEndedPhase
+	dtom.activeHeroPlayerID = ""
+	dtom.electedPlayerID = ""
+	dtom.electionStartTime = nil
+
+	dtom.logger.Printf("Quest ended in cycle %d: %s (%s)", dtom.cycleNumber, result, reason)
+	return dtom.questOutcome
+}
+
+// GetQuestOutcome returns how the quest ended, or nil while it is still being played
+func (dtom *DynamicTurnOrderManager) GetQuestOutcome() *QuestOutcome {
+	dtom.mutex.RLock()
+	defer dtom.mutex.RUnlock()
+	return dtom.questOutcome
+}
+
 // ==== Hero Turn Completion Methods ====
 
 // CompleteHeroTurn marks the active hero's turn as complete and transitions to election
@@ -483,18 +665,139 @@ func (dtom *DynamicTurnOrderManager) RegisterPlayer(playerID string) {
 	dtom.logger.Printf("Registered player %s for turn order", playerID)
 }
 
+// RestorePhase sets the phase state directly (used when rebuilding a game from persisted events)
+func (dtom *DynamicTurnOrderManager) RestorePhase(phase TurnPhaseType, cycleNumber int, activeHeroPlayerID, electedPlayerID string, heroesActed []string) {
+	dtom.mutex.Lock()
+	defer dtom.mutex.Unlock()
+
+	dtom.currentPhase = phase
+	dtom.cycleNumber = cycleNumber
+	dtom.activeHeroPlayerID = activeHeroPlayerID
+	dtom.electedPlayerID = electedPlayerID
+	dtom.heroesActedThisCycle = make(map[string]bool)
+	for _, playerID := range heroesActed {
+		dtom.heroesActedThisCycle[playerID] = true
+	}
+	dtom.electionStartTime = nil
+
+	dtom.logger.Printf("Restored turn order: phase %s, cycle %d", phase, cycleNumber)
+}
+
+// dynamicTurnOrderPersistence is the serialized form of DynamicTurnOrderManager
+type dynamicTurnOrderPersistence struct {
+	CurrentPhase         TurnPhaseType                      `json:"currentPhase"`
+	CycleNumber          int                                `json:"cycleNumber"`
+	ActiveHeroPlayerID   string                             `json:"activeHeroPlayerId"`
+	HeroesActedThisCycle map[string]bool                    `json:"heroesActedThisCycle"`
+	HeroesOutOfPlay      map[string]bool                    `json:"heroesOutOfPlay,omitempty"`
+	ElectedPlayerID      string                             `json:"electedPlayerId"`
+	PlayersReady         map[string]bool                    `json:"playersReady"`
+	PlayerStartPositions map[string]Position                `json:"playerStartPositions"`
+	SpellElements        []string                           `json:"spellElements,omitempty"`
+	SpellDraft           []SpellDraftPick                   `json:"spellDraft,omitempty"`
+	SpellSchools         map[string]string                  `json:"spellSchools,omitempty"`
+	MonsterTurnStates    map[string]*SimpleMonsterTurnState `json:"monsterTurnStates"`
+	QuestOutcome         *QuestOutcome                      `json:"questOutcome,omitempty"`
+}
+
+// SerializeForPersistence serializes the turn order state to JSON
+func (dtom *DynamicTurnOrderManager) SerializeForPersistence() ([]byte, error) {
+	dtom.mutex.RLock()
+	defer dtom.mutex.RUnlock()
+
+	return json.Marshal(dynamicTurnOrderPersistence{
+		CurrentPhase:         dtom.currentPhase,
+		CycleNumber:          dtom.cycleNumber,
+		ActiveHeroPlayerID:   dtom.activeHeroPlayerID,
+		HeroesActedThisCycle: dtom.heroesActedThisCycle,
+		HeroesOutOfPlay:      dtom.heroesOutOfPlay,
+		ElectedPlayerID:      dtom.electedPlayerID,
+		PlayersReady:         dtom.playersReady,
+		PlayerStartPositions: dtom.playerStartPositions,
+		SpellElements:        dtom.spellElements,
+		SpellDraft:           dtom.spellDraft,
+		SpellSchools:         dtom.spellSchools,
+		MonsterTurnStates:    dtom.monsterTurnStates,
+		QuestOutcome:         dtom.questOutcome,
+	})
+}
+
+// RestoreFromPersistence restores the turn order state from JSON produced by SerializeForPersistence
+func (dtom *DynamicTurnOrderManager) RestoreFromPersistence(data []byte) error {
+	var restored dynamicTurnOrderPersistence
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return err
+	}
+
+	dtom.mutex.Lock()
+	defer dtom.mutex.Unlock()
+
+	dtom.currentPhase = restored.CurrentPhase
+	dtom.cycleNumber = restored.CycleNumber
+	dtom.activeHeroPlayerID = restored.ActiveHeroPlayerID
+	dtom.heroesActedThisCycle = orEmpty(restored.HeroesActedThisCycle)
+	dtom.heroesOutOfPlay = orEmpty(restored.HeroesOutOfPlay)
+	dtom.electedPlayerID = restored.ElectedPlayerID
+	dtom.playersReady = orEmpty(restored.PlayersReady)
+	dtom.playerStartPositions = orEmpty(restored.PlayerStartPositions)
+	dtom.spellElements = restored.SpellElements
+	dtom.spellDraft = restored.SpellDraft
+	dtom.spellSchools = orEmpty(restored.SpellSchools)
+	dtom.monsterTurnStates = orEmpty(restored.MonsterTurnStates)
+	dtom.questOutcome = restored.QuestOutcome
+	dtom.electionStartTime = nil
+
+	dtom.logger.Printf("Restored turn order from persistence: phase %s, cycle %d", dtom.currentPhase, dtom.cycleNumber)
+	return nil
+}
+
 // ==== Private Helper Methods ====
 
+// advanceSpellDraftLocked hands out the remaining schools to a "take the rest" pick and
+// drops picks once every school is taken. Caller must hold the lock.
+func (dtom *DynamicTurnOrderManager) advanceSpellDraftLocked() {
+	for len(dtom.spellDraft) > 0 {
+		remaining := make([]string, 0, len(dtom.spellElements))
+		for _, element := range dtom.spellElements {
+			if _, taken := dtom.spellSchools[element]; !taken {
+				remaining = append(remaining, element)
+			}
+		}
+
+		if len(remaining) == 0 {
+			dtom.spellDraft = nil
+			return
+		}
+
+		pick := dtom.spellDraft[0]
+		if !pick.TakeRest {
+			return
+		}
+
+		for _, element := range remaining {
+			dtom.spellSchools[element] = pick.PlayerID
+		}
+		dtom.spellDraft = dtom.spellDraft[1:]
+		dtom.logger.Printf("Player %s takes the remaining spell schools: %v", pick.PlayerID, remaining)
+	}
+}
+
 func (dtom *DynamicTurnOrderManager) shouldAdvanceToGMPhase() bool {
 	if !dtom.requireAllHeroes {
 		// If we don't require all heroes, check if at least one has acted
 		return len(dtom.heroesActedThisCycle) > 0
 	}
 
-	// Check if all registered heroes have acted
+	// Check if all registered heroes have acted or are out of play
 	// Note: This assumes all non-GM players are registered
 	// In practice, we'd compare against total hero count from TurnManager
-	return len(dtom.heroesActedThisCycle) >= len(dtom.playersReady)
+	done := len(dtom.heroesActedThisCycle)
+	for playerID := range dtom.heroesOutOfPlay {
+		if !dtom.heroesActedThisCycle[playerID] {
+			done++
+		}
+	}
+	return done >= len(dtom.playersReady)
 }
 
 func (dtom *DynamicTurnOrderManager) advanceToGMPhaseLocked() error {
@@ -253,6 +253,35 @@ func TestDynamicTurnOrderManager_AdvanceToGMPhase(t *testing.T) {
 	}
 }
 
+func TestDynamicTurnOrderManager_HeroesOutOfPlayAreSkipped(t *testing.T) {
+	logger := &MockLogger{messages: []string{}}
+	dtom := NewDynamicTurnOrderManager(logger)
+
+	dtom.RegisterPlayer("player-1")
+	dtom.RegisterPlayer("player-2")
+	dtom.SetPlayerReady("player-1", true)
+	dtom.SetPlayerReady("player-2", true)
+	dtom.StartQuestAfterSetup()
+
+	dtom.SetHeroOutOfPlay("player-2", true)
+	if eligible := dtom.GetEligibleHeroes([]string{"player-1", "player-2"}); len(eligible) != 1 || eligible[0] != "player-1" {
+		t.Errorf("Expected only player-1 to be eligible, got %v", eligible)
+	}
+	if err := dtom.ElectSelfAsNextPlayer("player-2"); err == nil {
+		t.Error("Expected a hero out of play not to be elected")
+	}
+
+	// Once the only hero in play has acted, the GM phase begins
+	dtom.ElectSelfAsNextPlayer("player-1")
+	dtom.ConfirmElectionAndStartHeroTurn()
+	if err := dtom.CompleteHeroTurn(); err != nil {
+		t.Fatalf("Failed to complete hero turn: %v", err)
+	}
+	if dtom.GetCurrentPhase() != GMPhase {
+		t.Errorf("Expected phase to be GMPhase, got %s", dtom.GetCurrentPhase())
+	}
+}
+
 func TestDynamicTurnOrderManager_CompleteGMTurn(t *testing.T) {
 	logger := &MockLogger{messages: []string{}}
 	dtom := NewDynamicTurnOrderManager(logger)
@@ -525,3 +554,59 @@ func TestDynamicTurnOrderManager_MultipleHeroCycles(t *testing.T) {
 		t.Errorf("Expected GM phase, got %s", dtom.GetCurrentPhase())
 	}
 }
+
+func TestDynamicTurnOrder_SpellSchoolDraft(t *testing.T) {
+	dtom := NewDynamicTurnOrderManager(&MockLogger{})
+	dtom.RegisterPlayer("wizard")
+	dtom.RegisterPlayer("elf")
+
+	elements := []string{"air", "earth", "fire", "water"}
+	picks := []SpellDraftPick{{PlayerID: "wizard"}, {PlayerID: "elf"}, {PlayerID: "wizard", TakeRest: true}}
+	if err := dtom.StartSpellSelection(elements, picks); err != nil {
+		t.Fatalf("Failed to start spell selection: %v", err)
+	}
+
+	if err := dtom.ChooseSpellSchool("elf", "fire"); err == nil {
+		t.Error("Expected the Elf to wait for the Wizard's pick")
+	}
+	if err := dtom.ChooseSpellSchool("wizard", "shadow"); err == nil {
+		t.Error("Expected an unknown element to be rejected")
+	}
+	if err := dtom.ChooseSpellSchool("wizard", "fire"); err != nil {
+		t.Fatalf("Wizard pick failed: %v", err)
+	}
+	if err := dtom.ChooseSpellSchool("elf", "fire"); err == nil {
+		t.Error("Expected an element to be taken only once")
+	}
+	if err := dtom.ChooseSpellSchool("elf", "water"); err != nil {
+		t.Fatalf("Elf pick failed: %v", err)
+	}
+
+	if !dtom.IsSpellSelectionComplete() {
+		t.Fatal("Expected the Wizard to take the remaining schools automatically")
+	}
+
+	schools := dtom.GetSpellSchools()
+	expected := map[string]string{"air": "wizard", "earth": "wizard", "fire": "wizard", "water": "elf"}
+	for element, owner := range expected {
+		if schools[element] != owner {
+			t.Errorf("Expected %s spells to go to %s, got %q", element, owner, schools[element])
+		}
+	}
+}
+
+func TestDynamicTurnOrder_QuestWaitsForSpellSelection(t *testing.T) {
+	dtom := NewDynamicTurnOrderManager(&MockLogger{})
+	dtom.RegisterPlayer("elf")
+	dtom.SetPlayerReady("elf", true)
+	dtom.StartSpellSelection([]string{"air", "fire"}, []SpellDraftPick{{PlayerID: "elf"}})
+
+	if err := dtom.StartQuestAfterSetup(); err == nil {
+		t.Fatal("Expected the quest to wait for spell selection")
+	}
+
+	dtom.ChooseSpellSchool("elf", "air")
+	if err := dtom.StartQuestAfterSetup(); err != nil {
+		t.Errorf("Expected the quest to start after spell selection, got: %v", err)
+	}
+}
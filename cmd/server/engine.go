@@ -84,7 +84,7 @@ func (e *GameEngineImpl) ProcessMove(req protocol.RequestMove) (*MoveResult, err
 func (e *GameEngineImpl) ProcessDoorToggle(req protocol.RequestToggleDoor) (*DoorToggleResult, error) {
 	e.state.Lock.Lock()
 	info, ok := e.state.Doors[req.ThresholdID]
-	if !ok || info == nil || info.State == "open" {
+	if !ok || info == nil || info.State == "open" || info.State == "locked" {
 		e.state.Lock.Unlock()
 		return nil, errors.New("door cannot be opened")
 	}
@@ -92,15 +92,7 @@ func (e *GameEngineImpl) ProcessDoorToggle(req protocol.RequestToggleDoor) (*Doo
 	info.State = "open"
 
 	// Calculate regions to reveal
-	var toReveal []int
-	a, b := info.RegionA, info.RegionB
-	if e.state.RevealedRegions[a] && !e.state.RevealedRegions[b] {
-		e.state.RevealedRegions[b] = true
-		toReveal = append(toReveal, b)
-	} else if e.state.RevealedRegions[b] && !e.state.RevealedRegions[a] {
-		e.state.RevealedRegions[a] = true
-		toReveal = append(toReveal, a)
-	}
+	toReveal := revealAcrossDoor(e.state, info)
 	e.state.Lock.Unlock()
 
 	// Calculate visibility updates
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Item restrictions (ItemCard.Restrictions)
+const (
+	ItemRestrictionTwoHanded    = "two_handed"      // Cannot be used together with a shield
+	ItemRestrictionNotUsableBy  = "not_usable_by:"  // Followed by a hero class, e.g. "not_usable_by:wizard"
+	ItemRestrictionWizardArmour = "wizard_may_wear" // Armour the wizard may wear despite the class rule
+)
+
+// checkEquipRules checks whether a hero of the given class may equip an item alongside
+// what they already wear: class restrictions first, then two-handed weapons against shields
+func checkEquipRules(inventory *HeroInventory, item *ItemCard, class HeroClass) error {
+	if err := checkClassRules(item, class); err != nil {
+		return err
+	}
+
+	switch determineSlot(item) {
+	case "weapon":
+		if shield := inventory.Equipment["shield"]; shield != nil && hasRestriction(item, ItemRestrictionTwoHanded) {
+			return &GameError{Code: "slot_conflict", Message: fmt.Sprintf("%s needs both hands and cannot be used with %s", item.Name, shield.Name)}
+		}
+	case "shield":
+		if weapon := inventory.Equipment["weapon"]; weapon != nil && hasRestriction(weapon, ItemRestrictionTwoHanded) {
+			return &GameError{Code: "slot_conflict", Message: fmt.Sprintf("%s needs both hands and cannot be used with %s", weapon.Name, item.Name)}
+		}
+	}
+	return nil
+}
+
+// checkClassRules checks whether a hero of the given class may use an item at all
+func checkClassRules(item *ItemCard, class HeroClass) error {
+	if !isUsableBy(item, class) || hasRestriction(item, ItemRestrictionNotUsableBy+string(class)) {
+		return &GameError{Code: "item_not_usable", Message: fmt.Sprintf("%s cannot be used by the %s", item.Name, class)}
+	}
+	if class == Wizard && item.Type == "armor" && !hasRestriction(item, ItemRestrictionWizardArmour) {
+		return &GameError{Code: "item_not_usable", Message: "the wizard cannot wear armour"}
+	}
+	return nil
+}
+
+// hasRestriction reports whether an item carries a restriction, ignoring case
+func hasRestriction(item *ItemCard, restriction string) bool {
+	for _, r := range item.Restrictions {
+		if strings.EqualFold(r, restriction) {
+			return true
+		}
+	}
+	return false
+}
+
+// equipmentMods derives stat modifications from everything a hero has equipped.
+// The weapon's attack dice replace the hero's base attack; armour adds defense dice.
+// Bonuses from any equipped item stack, as do the passive bonuses of artifacts the hero holds.
+func equipmentMods(inventory *HeroInventory) StatMods {
+	mods := StatMods{}
+	for slot, item := range inventory.Equipment {
+		if item == nil {
+			continue
+		}
+		switch {
+		case slot == "weapon":
+			mods.AttackDice = item.AttackDice
+		case item.Type == "armor":
+			mods.DefenseBonus += item.DefenseDice
+		}
+		mods.AttackBonus += item.AttackBonus
+		mods.DefenseBonus += item.DefenseBonus
+	}
+	eachHeldArtifact(inventory, func(item *ItemCard, effect ArtifactEffect) {
+		if effect.Mods != nil {
+			effect.Mods(item, &mods)
+		}
+	})
+	return mods
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"testing"
+)
+
+// createTestOutfitter registers a hero of the given class with an inventory carrying the given cards
+func createTestOutfitter(t *testing.T, class HeroClass, items ...*ItemCard) (*InventoryManager, *Player) {
+	contentManager := NewContentManager(&MockLogger{})
+	for _, item := range items {
+		contentManager.equipmentCards[item.ID] = item
+	}
+
+	im := NewInventoryManager(contentManager, &MockLogger{})
+	if err := im.InitializeHeroInventory("hero-1"); err != nil {
+		t.Fatalf("Failed to initialize inventory: %v", err)
+	}
+	for _, item := range items {
+		if err := im.AddItem("hero-1", item.ID); err != nil {
+			t.Fatalf("Failed to add %s: %v", item.ID, err)
+		}
+	}
+
+	player := NewPlayer("player-1", "Test Hero", "hero-1", class)
+	im.RegisterHero(player)
+	return im, player
+}
+
+func expectGameErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	if gameErr, ok := err.(*GameError); !ok || gameErr.Code != code {
+		t.Errorf("Expected %s, got: %v", code, err)
+	}
+}
+
+func TestEquipment_WeaponReplacesBaseAttack(t *testing.T) {
+	im, barbarian := createTestOutfitter(t, Barbarian,
+		&ItemCard{ID: "broadsword", Name: "Broadsword", Type: "weapon", AttackDice: 4},
+		&ItemCard{ID: "cursed_dagger", Name: "Cursed Dagger", Type: "weapon", AttackDice: 1, AttackBonus: -2},
+	)
+
+	if err := im.EquipItem("hero-1", "broadsword"); err != nil {
+		t.Fatalf("Expected broadsword to be equipped, got: %v", err)
+	}
+	if dice := barbarian.Character.GetEffectiveAttackDice(); dice != 4 {
+		t.Errorf("Expected the broadsword's 4 attack dice to replace the base 3, got %d", dice)
+	}
+
+	if err := im.EquipItem("hero-1", "cursed_dagger"); err != nil {
+		t.Fatalf("Expected dagger to be equipped, got: %v", err)
+	}
+	if dice := barbarian.Character.GetEffectiveAttackDice(); dice != 1 {
+		t.Errorf("Expected attack dice never to fall below 1, got %d", dice)
+	}
+
+	if err := im.UnequipItem("hero-1", "weapon"); err != nil {
+		t.Fatalf("Expected dagger to be unequipped, got: %v", err)
+	}
+	if dice := barbarian.Character.GetEffectiveAttackDice(); dice != 3 {
+		t.Errorf("Expected the base 3 attack dice unarmed, got %d", dice)
+	}
+}
+
+func TestEquipment_ArmourAddsToDefense(t *testing.T) {
+	im, dwarf := createTestOutfitter(t, Dwarf,
+		&ItemCard{ID: "plate_armour", Name: "Plate Armour", Type: "armor", Subtype: "body", DefenseDice: 2},
+		&ItemCard{ID: "chain_mail", Name: "Chain Mail", Type: "armor", Subtype: "body", DefenseDice: 1},
+		&ItemCard{ID: "helmet", Name: "Helmet", Type: "armor", Subtype: "helmet", DefenseDice: 1},
+		&ItemCard{ID: "shield", Name: "Shield", Type: "armor", Subtype: "shield", DefenseDice: 1},
+	)
+
+	for _, itemID := range []string{"plate_armour", "chain_mail", "helmet", "shield"} {
+		if err := im.EquipItem("hero-1", itemID); err != nil {
+			t.Fatalf("Expected %s to be equipped, got: %v", itemID, err)
+		}
+	}
+
+	// The chain mail replaced the plate armour in the body slot
+	if dice := dwarf.Character.GetEffectiveDefenseDice(); dice != 5 {
+		t.Errorf("Expected 2 base + 1 chain mail + 1 helmet + 1 shield = 5 defense dice, got %d", dice)
+	}
+	if !im.HasItem("hero-1", "plate_armour") {
+		t.Error("Expected the plate armour to be carried again")
+	}
+
+	if err := im.UnequipItem("hero-1", "armor_helmet"); err != nil {
+		t.Fatalf("Expected helmet to be unequipped, got: %v", err)
+	}
+	if dice := dwarf.Character.GetEffectiveDefenseDice(); dice != 4 {
+		t.Errorf("Expected 4 defense dice without the helmet, got %d", dice)
+	}
+}
+
+func TestEquipment_ClassAndSlotRestrictions(t *testing.T) {
+	im, wizard := createTestOutfitter(t, Wizard,
+		&ItemCard{ID: "helmet", Name: "Helmet", Type: "armor", Subtype: "helmet", DefenseDice: 1},
+		&ItemCard{ID: "longsword", Name: "Longsword", Type: "weapon", AttackDice: 3, Restrictions: []string{"not_usable_by:wizard"}},
+	)
+
+	expectGameErrorCode(t, im.EquipItem("hero-1", "helmet"), "item_not_usable")
+	expectGameErrorCode(t, im.EquipItem("hero-1", "longsword"), "item_not_usable")
+	if wizard.Character.EquipmentMods != (StatMods{}) {
+		t.Errorf("Expected refused items not to change stats, got %+v", wizard.Character.EquipmentMods)
+	}
+
+	im, _ = createTestOutfitter(t, Dwarf,
+		&ItemCard{ID: "battle_axe", Name: "Battle Axe", Type: "weapon", AttackDice: 4, Restrictions: []string{ItemRestrictionTwoHanded}},
+		&ItemCard{ID: "shield", Name: "Shield", Type: "armor", Subtype: "shield", DefenseDice: 1},
+	)
+	if err := im.EquipItem("hero-1", "battle_axe"); err != nil {
+		t.Fatalf("Expected battle axe to be equipped, got: %v", err)
+	}
+	expectGameErrorCode(t, im.EquipItem("hero-1", "shield"), "slot_conflict")
+}
+
+func TestEquipment_TradedAwayWeaponUpdatesStats(t *testing.T) {
+	has := createTestTraders(t, []*ItemCard{
+		{ID: "broadsword", Name: "Broadsword", Type: "weapon", AttackDice: 4},
+	}, nil)
+	if err := has.inventoryManager.EquipItem("hero-1", "broadsword"); err != nil {
+		t.Fatalf("Expected broadsword to be equipped, got: %v", err)
+	}
+	if has.turnManager.GetPlayer("player-1").Character.GetEffectiveAttackDice() != 4 {
+		t.Fatal("Expected the broadsword to set hero-1's attack dice")
+	}
+
+	if err := has.inventoryManager.Trade("hero-1", TradeGoods{Items: []string{"broadsword"}}, "hero-2", TradeGoods{}); err != nil {
+		t.Fatalf("Expected the equipped broadsword to be traded, got: %v", err)
+	}
+	barbarian := has.turnManager.GetPlayer("player-1")
+	if dice := barbarian.Character.GetEffectiveAttackDice(); dice != 3 {
+		t.Errorf("Expected hero-1 back to base attack after trading away the equipped sword, got %d", dice)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// ReplayEvents rebuilds game state by re-applying stored events in order.
+// Events are applied directly to the systems; nothing is re-broadcast.
+func ReplayEvents(gm *GameManager, events []StoredEvent) error {
+	for _, event := range events {
+		if err := applyStoredEvent(gm, event); err != nil {
+			return fmt.Errorf("failed to replay event %d (%s): %w", event.EventID, event.Type, err)
+		}
+	}
+	return nil
+}
+
+// replayedEvents are the event types applyStoredEvent restores. Every other event changes
+// state only a snapshot holds (hero damage, inventories, traps, turn states, ...), so the game
+// is snapshotted once the message that raised it has been handled.
+var replayedEvents = map[string]bool{
+	"EntityUpdated":        true,
+	"DoorStateChanged":     true,
+	"RegionsRevealed":      true,
+	"RegionsKnown":         true,
+	"DoorsVisible":         true,
+	"BlockingWallsVisible": true,
+	"FurnitureVisible":     true,
+	"MonstersVisible":      true,
+	"MonsterUpdate":        true,
+	"MonsterKilled":        true,
+	"TurnPhaseChanged":     true,
+}
+
+// applyStoredEvent applies a single event. Events not in replayedEvents are skipped; the
+// snapshot saved after them already holds their state.
+func applyStoredEvent(gm *GameManager, event StoredEvent) error {
+	state := gm.gameState
+
+	switch event.Type {
+	case "EntityUpdated":
+		var payload protocol.EntityUpdated
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		state.Lock.Lock()
+		state.Entities[payload.ID] = payload.Tile
+		state.Lock.Unlock()
+		if monster, exists := gm.monsterSystem.GetMonsters()[payload.ID]; exists {
+			monster.Position = payload.Tile
+		}
+
+	case "DoorStateChanged":
+		var payload protocol.DoorStateChanged
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		state.Lock.Lock()
+		if door, exists := state.Doors[payload.ThresholdID]; exists {
+			door.State = payload.State
+		}
+		state.Lock.Unlock()
+
+	case "RegionsRevealed":
+		var payload protocol.RegionsRevealed
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		state.Lock.Lock()
+		for _, id := range payload.IDs {
+			state.RevealedRegions[id] = true
+		}
+		state.Lock.Unlock()
+
+	case "RegionsKnown":
+		var payload protocol.RegionsKnown
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		state.Lock.Lock()
+		addKnownRegions(state, payload.IDs)
+		state.Lock.Unlock()
+
+	case "DoorsVisible":
+		var payload protocol.DoorsVisible
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		state.Lock.Lock()
+		for _, door := range payload.Doors {
+			// Doors missing from the map were secret doors found by searching
+			if _, exists := state.Doors[door.ID]; !exists {
+				orientation := geometry.Vertical
+				if door.Orientation == string(geometry.Horizontal) {
+					orientation = geometry.Horizontal
+				}
+				state.RevealSecretDoor(geometry.EdgeAddress{X: door.X, Y: door.Y, Orientation: orientation})
+			}
+			state.KnownDoors[door.ID] = true
+		}
+		state.Lock.Unlock()
+
+	case "BlockingWallsVisible":
+		var payload protocol.BlockingWallsVisible
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		state.Lock.Lock()
+		for _, wall := range payload.BlockingWalls {
+			state.KnownBlockingWalls[wall.ID] = true
+		}
+		state.Lock.Unlock()
+
+	case "FurnitureVisible":
+		var payload protocol.FurnitureVisible
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		state.Lock.Lock()
+		for _, furniture := range payload.Furniture {
+			state.KnownFurniture[furniture.ID] = true
+		}
+		state.Lock.Unlock()
+
+	case "MonstersVisible":
+		var payload protocol.MonstersVisible
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		state.Lock.Lock()
+		for _, monster := range payload.Monsters {
+			state.KnownMonsters[monster.ID] = true
+		}
+		state.Lock.Unlock()
+		for _, lite := range payload.Monsters {
+			if monster, exists := gm.monsterSystem.GetMonsters()[lite.ID]; exists {
+				monster.IsVisible = true
+			}
+		}
+
+	case "MonsterUpdate":
+		var payload struct {
+			Monster Monster `json:"monster"`
+		}
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		gm.monsterSystem.RestoreMonster(&payload.Monster)
+
+	case "MonsterKilled":
+		var payload struct {
+			MonsterID string `json:"monsterId"`
+		}
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		if monster, exists := gm.monsterSystem.GetMonsters()[payload.MonsterID]; exists {
+			killed := *monster
+			killed.IsAlive = false
+			killed.Body = 0
+			gm.monsterSystem.RestoreMonster(&killed)
+		}
+
+	case "TurnPhaseChanged":
+		var payload protocol.TurnPhaseChanged
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		gm.dynamicTurnOrder.RestorePhase(TurnPhaseType(payload.CurrentPhase), payload.CycleNumber,
+			payload.ActiveHeroPlayerID, payload.ElectedPlayerID, payload.HeroesActedIDs)
+	}
+
+	return nil
+}
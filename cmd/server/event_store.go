@@ -0,0 +1,528 @@
+package main
+
+import (
+	"bufio"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // registers the "pgx" database/sql driver
+)
+
+// StoredEvent is a single domain event as persisted in the event log.
+// TurnNumber holds the DynamicTurnOrderManager cycle the event happened in.
+type StoredEvent struct {
+	EventID     int64           `json:"eventId"`
+	GameID      string          `json:"gameId"`
+	TurnNumber  int             `json:"turnNumber"`
+	IndexInTurn int             `json:"indexInTurn"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedAt   time.Time       `json:"createdAt"`
+}
+
+// StoredSnapshot is a serialized copy of the whole game, taken at a cycle boundary or after a
+// change the event log cannot replay
+// (or when a saved session is loaded). Recovery restores it and replays only events
+// after LastEventID.
+type StoredSnapshot struct {
+	GameID      string    `json:"gameId"`
+	TurnNumber  int       `json:"turnNumber"`
+	StateBinary []byte    `json:"stateBinary"`
+	LastEventID int64     `json:"lastEventId"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// GameRecord describes a game row that events are attached to
+type GameRecord struct {
+	ID       string `json:"id"`
+	MapID    string `json:"mapId"`
+	QuestID  string `json:"questId"`
+	DMUserID string `json:"dmUserId"`
+}
+
+// EventStore persists domain events so a game can be rebuilt by replaying them
+type EventStore interface {
+	// EnsureGame registers a game if it is not already known to the store
+	EnsureGame(game GameRecord) error
+	// Append stores an event and returns its assigned event ID
+	Append(event StoredEvent) (int64, error)
+	// LoadEvents returns all events of a game with an ID greater than afterEventID, in order
+	LoadEvents(gameID string, afterEventID int64) ([]StoredEvent, error)
+	// SaveSnapshot stores a snapshot of the game
+	SaveSnapshot(snapshot StoredSnapshot) error
+	// LoadLatestSnapshot returns the most recent snapshot (highest LastEventID), or nil if there is none
+	LoadLatestSnapshot(gameID string) (*StoredSnapshot, error)
+	Close() error
+}
+
+// EventStoreConfig controls which event store backend is used
+type EventStoreConfig struct {
+	Backend     string // "file", "postgres" or "" (disabled)
+	Directory   string // Directory used by the file backend
+	DatabaseURL string // Connection string used by the postgres backend
+	GameID      string // Game to resume; a new ID is generated when empty
+}
+
+// GetEventStoreConfigFromEnv reads event store configuration from environment variables
+func GetEventStoreConfigFromEnv() EventStoreConfig {
+	dir := os.Getenv("EVENT_STORE_DIR")
+	if dir == "" {
+		dir = "data/events"
+	}
+
+	return EventStoreConfig{
+		Backend:     os.Getenv("EVENT_STORE"),
+		Directory:   dir,
+		DatabaseURL: os.Getenv("DATABASE_URL"),
+		GameID:      os.Getenv("GAME_ID"),
+	}
+}
+
+// OpenEventStore opens the configured event store. It returns nil when persistence is disabled.
+func OpenEventStore(config EventStoreConfig) (EventStore, error) {
+	switch config.Backend {
+	case "":
+		return nil, nil
+	case "file":
+		return NewFileEventStore(config.Directory)
+	case "postgres":
+		if config.DatabaseURL == "" {
+			return nil, fmt.Errorf("DATABASE_URL is required for the postgres event store")
+		}
+		db, err := sql.Open("pgx", config.DatabaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database: %w", err)
+		}
+		if err := db.Ping(); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to connect to database: %w", err)
+		}
+		return NewPostgresEventStore(db), nil
+	default:
+		return nil, fmt.Errorf("unknown event store backend: %s", config.Backend)
+	}
+}
+
+// NewGameID generates a random (version 4) UUID suitable for the game table
+func NewGameID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("failed to generate game id: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// ==== File-backed event store ====
+
+// FileEventStore stores events as JSON lines, one file per game, for local play without a database
+type FileEventStore struct {
+	directory string
+	lastIDs   map[string]int64 // GameID -> last assigned event ID
+	mutex     sync.Mutex
+}
+
+// NewFileEventStore creates a file event store rooted at directory
+func NewFileEventStore(directory string) (*FileEventStore, error) {
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create event directory: %w", err)
+	}
+
+	return &FileEventStore{
+		directory: directory,
+		lastIDs:   make(map[string]int64),
+	}, nil
+}
+
+func (fs *FileEventStore) eventsPath(gameID string) string {
+	return filepath.Join(fs.directory, gameID+".events.jsonl")
+}
+
+func (fs *FileEventStore) gamePath(gameID string) string {
+	return filepath.Join(fs.directory, gameID+".game.json")
+}
+
+// EnsureGame writes the game record if it does not exist yet
+func (fs *FileEventStore) EnsureGame(game GameRecord) error {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	path := fs.gamePath(game.ID)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(game, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal game record: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Append adds an event to the game's log file
+func (fs *FileEventStore) Append(event StoredEvent) (int64, error) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	lastID, ok := fs.lastIDs[event.GameID]
+	if !ok {
+		events, err := fs.readEvents(event.GameID, 0)
+		if err != nil {
+			return 0, err
+		}
+		if len(events) > 0 {
+			lastID = events[len(events)-1].EventID
+		}
+	}
+
+	event.EventID = lastID + 1
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	file, err := os.OpenFile(fs.eventsPath(event.GameID), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open event log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return 0, fmt.Errorf("failed to write event: %w", err)
+	}
+
+	fs.lastIDs[event.GameID] = event.EventID
+	return event.EventID, nil
+}
+
+// LoadEvents reads the game's log file
+func (fs *FileEventStore) LoadEvents(gameID string, afterEventID int64) ([]StoredEvent, error) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	return fs.readEvents(gameID, afterEventID)
+}
+
+func (fs *FileEventStore) readEvents(gameID string, afterEventID int64) ([]StoredEvent, error) {
+	file, err := os.Open(fs.eventsPath(gameID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log: %w", err)
+	}
+	defer file.Close()
+
+	var events []StoredEvent
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var event StoredEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			// A crash mid-write can leave a truncated last line; stop there
+			break
+		}
+		if event.EventID > afterEventID {
+			events = append(events, event)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read event log: %w", err)
+	}
+
+	return events, nil
+}
+
+// snapshotPath names snapshots by the last event they include, then the cycle. Loading a saved
+// session can move the cycle number backwards, so the event ID is what orders snapshots.
+func (fs *FileEventStore) snapshotPath(gameID string, lastEventID int64, cycle int) string {
+	return filepath.Join(fs.directory, fmt.Sprintf("%s.snapshot-%012d-cycle-%d.json", gameID, lastEventID, cycle))
+}
+
+// SaveSnapshot writes the snapshot to its own file, via a temp file so a crash never leaves a
+// partial snapshot. Like the Postgres store it keeps one snapshot per cycle, so older snapshots
+// of the same cycle are removed once the new one is in place.
+func (fs *FileEventStore) SaveSnapshot(snapshot StoredSnapshot) error {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	if snapshot.CreatedAt.IsZero() {
+		snapshot.CreatedAt = time.Now()
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	path := fs.snapshotPath(snapshot.GameID, snapshot.LastEventID, snapshot.TurnNumber)
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+
+	older, err := filepath.Glob(filepath.Join(fs.directory, fmt.Sprintf("%s.snapshot-*-cycle-%d.json", snapshot.GameID, snapshot.TurnNumber)))
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	for _, stale := range older {
+		if stale != path {
+			if err := os.Remove(stale); err != nil {
+				return fmt.Errorf("failed to remove snapshot: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// LoadLatestSnapshot reads the snapshot file with the highest last event ID
+func (fs *FileEventStore) LoadLatestSnapshot(gameID string) (*StoredSnapshot, error) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	// Zero-padded event IDs make lexical order match numeric order
+	paths, err := filepath.Glob(filepath.Join(fs.directory, gameID+".snapshot-*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	sort.Strings(paths)
+
+	data, err := os.ReadFile(paths[len(paths)-1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snapshot StoredSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// Close is a no-op for the file store
+func (fs *FileEventStore) Close() error {
+	return nil
+}
+
+// ==== Postgres event store ====
+
+// PostgresEventStore stores events in the `event` table from db/migrations
+type PostgresEventStore struct {
+	db *sql.DB
+}
+
+// NewPostgresEventStore creates a postgres event store using an open database handle
+func NewPostgresEventStore(db *sql.DB) *PostgresEventStore {
+	return &PostgresEventStore{db: db}
+}
+
+// EnsureGame inserts the game row if it does not exist yet
+func (ps *PostgresEventStore) EnsureGame(game GameRecord) error {
+	_, err := ps.db.Exec(
+		`INSERT INTO game (id, map_id, quest_id, dm_user_id) VALUES ($1, $2, $3, $4) ON CONFLICT (id) DO NOTHING`,
+		game.ID, game.MapID, game.QuestID, game.DMUserID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert game: %w", err)
+	}
+	return nil
+}
+
+// Append inserts an event row and returns the generated event ID
+func (ps *PostgresEventStore) Append(event StoredEvent) (int64, error) {
+	var eventID int64
+	err := ps.db.QueryRow(
+		`INSERT INTO event (game_id, turn_number, index_in_turn, type, payload_json)
+		 VALUES ($1, $2, $3, $4, $5) RETURNING event_id`,
+		event.GameID, event.TurnNumber, event.IndexInTurn, event.Type, string(event.Payload),
+	).Scan(&eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert event: %w", err)
+	}
+	return eventID, nil
+}
+
+// LoadEvents selects a game's events in event ID order
+func (ps *PostgresEventStore) LoadEvents(gameID string, afterEventID int64) ([]StoredEvent, error) {
+	rows, err := ps.db.Query(
+		`SELECT event_id, game_id, turn_number, index_in_turn, type, payload_json, created_at
+		   FROM event
+		  WHERE game_id = $1 AND event_id > $2
+		  ORDER BY event_id`,
+		gameID, afterEventID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []StoredEvent
+	for rows.Next() {
+		var event StoredEvent
+		var payload []byte
+		if err := rows.Scan(&event.EventID, &event.GameID, &event.TurnNumber, &event.IndexInTurn, &event.Type, &payload, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		event.Payload = payload
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// SaveSnapshot upserts a row into the `snapshot` table
+func (ps *PostgresEventStore) SaveSnapshot(snapshot StoredSnapshot) error {
+	_, err := ps.db.Exec(
+		`INSERT INTO snapshot (game_id, turn_number, state_binary, last_event_id)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (game_id, turn_number)
+		 DO UPDATE SET state_binary = EXCLUDED.state_binary, last_event_id = EXCLUDED.last_event_id, created_at = now()`,
+		snapshot.GameID, snapshot.TurnNumber, snapshot.StateBinary, snapshot.LastEventID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadLatestSnapshot selects the game's snapshot with the highest last event ID
+func (ps *PostgresEventStore) LoadLatestSnapshot(gameID string) (*StoredSnapshot, error) {
+	var snapshot StoredSnapshot
+	err := ps.db.QueryRow(
+		`SELECT game_id, turn_number, state_binary, last_event_id, created_at
+		   FROM snapshot
+		  WHERE game_id = $1
+		  ORDER BY last_event_id DESC, created_at DESC
+		  LIMIT 1`,
+		gameID,
+	).Scan(&snapshot.GameID, &snapshot.TurnNumber, &snapshot.StateBinary, &snapshot.LastEventID, &snapshot.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// Close closes the underlying database handle
+func (ps *PostgresEventStore) Close() error {
+	return ps.db.Close()
+}
+
+// ==== Event recorder ====
+
+// EventRecorder appends broadcast events to an EventStore, tracking the cycle number
+// and index within the cycle
+type EventRecorder struct {
+	store       EventStore
+	gameID      string
+	cycleNumber func() int
+	lastCycle   int
+	indexInTurn int
+	lastEventID int64
+	snapshotDue bool // An event recorded since the last TakeSnapshotDue is not replayed
+	mutex       sync.Mutex
+}
+
+// NewEventRecorder creates a recorder for a game. cycleNumber reports the current turn cycle.
+func NewEventRecorder(store EventStore, gameID string, cycleNumber func() int) *EventRecorder {
+	return &EventRecorder{
+		store:       store,
+		gameID:      gameID,
+		cycleNumber: cycleNumber,
+		lastCycle:   -1,
+	}
+}
+
+// GameID returns the game the recorder writes to
+func (er *EventRecorder) GameID() string {
+	return er.gameID
+}
+
+// Resume continues numbering after the last event already in the log
+func (er *EventRecorder) Resume(last StoredEvent) {
+	er.mutex.Lock()
+	defer er.mutex.Unlock()
+
+	er.lastCycle = last.TurnNumber
+	er.indexInTurn = last.IndexInTurn + 1
+	er.lastEventID = last.EventID
+}
+
+// LastEventID returns the ID of the most recently recorded event
+func (er *EventRecorder) LastEventID() int64 {
+	er.mutex.Lock()
+	defer er.mutex.Unlock()
+	return er.lastEventID
+}
+
+// Record persists an event and returns its event ID
+func (er *EventRecorder) Record(eventType string, payload any) (int64, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal %s payload: %w", eventType, err)
+	}
+
+	er.mutex.Lock()
+	defer er.mutex.Unlock()
+
+	cycle := 0
+	if er.cycleNumber != nil {
+		cycle = er.cycleNumber()
+	}
+	if cycle != er.lastCycle {
+		er.lastCycle = cycle
+		er.indexInTurn = 0
+	}
+
+	eventID, err := er.store.Append(StoredEvent{
+		GameID:      er.gameID,
+		TurnNumber:  cycle,
+		IndexInTurn: er.indexInTurn,
+		Type:        eventType,
+		Payload:     data,
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	er.indexInTurn++
+	er.lastEventID = eventID
+	if !replayedEvents[eventType] {
+		er.snapshotDue = true
+	}
+	return eventID, nil
+}
+
+// TakeSnapshotDue reports whether an event recorded since the last call changed state that
+// replay does not restore, and clears the flag
+func (er *EventRecorder) TakeSnapshotDue() bool {
+	er.mutex.Lock()
+	defer er.mutex.Unlock()
+
+	due := er.snapshotDue
+	er.snapshotDue = false
+	return due
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+func TestFileEventStore_AppendAndLoad(t *testing.T) {
+	store, err := NewFileEventStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	gameID := NewGameID()
+	if err := store.EnsureGame(GameRecord{ID: gameID, QuestID: "quest-01"}); err != nil {
+		t.Fatalf("EnsureGame failed: %v", err)
+	}
+
+	cycle := 0
+	recorder := NewEventRecorder(store, gameID, func() int { return cycle })
+
+	recorder.Record("DoorStateChanged", protocol.DoorStateChanged{ThresholdID: "door-1", State: "open"})
+	recorder.Record("RegionsRevealed", protocol.RegionsRevealed{IDs: []int{3}})
+	cycle = 1
+	id, err := recorder.Record("EntityUpdated", protocol.EntityUpdated{ID: "hero-1", Tile: protocol.TileAddress{X: 2, Y: 3}})
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if id != 3 {
+		t.Errorf("Expected third event ID to be 3, got %d", id)
+	}
+
+	events, err := store.LoadEvents(gameID, 0)
+	if err != nil {
+		t.Fatalf("LoadEvents failed: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+	if events[1].TurnNumber != 0 || events[1].IndexInTurn != 1 {
+		t.Errorf("Expected second event at cycle 0 index 1, got cycle %d index %d", events[1].TurnNumber, events[1].IndexInTurn)
+	}
+	if events[2].TurnNumber != 1 || events[2].IndexInTurn != 0 {
+		t.Errorf("Expected index to reset on new cycle, got cycle %d index %d", events[2].TurnNumber, events[2].IndexInTurn)
+	}
+
+	after, _ := store.LoadEvents(gameID, 2)
+	if len(after) != 1 || after[0].Type != "EntityUpdated" {
+		t.Errorf("Expected only the last event after ID 2, got %+v", after)
+	}
+
+	// A fresh store over the same directory continues numbering
+	reopened, _ := NewFileEventStore(store.directory)
+	nextID, err := reopened.Append(StoredEvent{GameID: gameID, Type: "VisibleNow", Payload: []byte(`{"ids":[]}`)})
+	if err != nil {
+		t.Fatalf("Append after reopen failed: %v", err)
+	}
+	if nextID != 4 {
+		t.Errorf("Expected event ID 4 after reopen, got %d", nextID)
+	}
+}
+
+func TestReplayEvents_RebuildsBoardState(t *testing.T) {
+	logger := &MockLogger{}
+	state := &GameState{
+		Doors: map[string]*DoorInfo{
+			"door-1": {Edge: geometry.EdgeAddress{X: 1, Y: 1}, State: "closed"},
+		},
+		Entities:           make(map[string]protocol.TileAddress),
+		RevealedRegions:    make(map[int]bool),
+		KnownRegions:       make(map[int]bool),
+		KnownDoors:         make(map[string]bool),
+		KnownBlockingWalls: make(map[string]bool),
+		KnownFurniture:     make(map[string]bool),
+		KnownMonsters:      make(map[string]bool),
+	}
+	gm := &GameManager{
+		gameState:        state,
+		monsterSystem:    NewMonsterSystem(state, nil, nil, &MockBroadcaster{}, logger),
+		dynamicTurnOrder: NewDynamicTurnOrderManager(logger),
+		logger:           logger,
+	}
+
+	store, _ := NewFileEventStore(t.TempDir())
+	recorder := NewEventRecorder(store, "game-1", nil)
+	recorder.Record("DoorStateChanged", protocol.DoorStateChanged{ThresholdID: "door-1", State: "open"})
+	recorder.Record("RegionsRevealed", protocol.RegionsRevealed{IDs: []int{4, 5}})
+	recorder.Record("EntityUpdated", protocol.EntityUpdated{ID: "hero-1", Tile: protocol.TileAddress{X: 7, Y: 8}})
+	recorder.Record("MonsterUpdate", map[string]any{"monster": &Monster{ID: "monster_3", Type: Orc, Body: 1, MaxBody: 1, IsAlive: true, Position: protocol.TileAddress{X: 9, Y: 9}}})
+	recorder.Record("MonsterKilled", map[string]any{"monsterId": "monster_3"})
+	recorder.Record("TurnPhaseChanged", protocol.TurnPhaseChanged{CurrentPhase: string(GMPhase), CycleNumber: 2})
+
+	events, _ := store.LoadEvents("game-1", 0)
+	if err := ReplayEvents(gm, events); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	if state.Doors["door-1"].State != "open" {
+		t.Errorf("Expected door to be open after replay")
+	}
+	if !state.RevealedRegions[4] || !state.RevealedRegions[5] {
+		t.Errorf("Expected regions 4 and 5 revealed")
+	}
+	if pos := state.Entities["hero-1"]; pos.X != 7 || pos.Y != 8 {
+		t.Errorf("Expected hero at (7,8), got (%d,%d)", pos.X, pos.Y)
+	}
+	monster, err := gm.monsterSystem.GetMonsterByID("monster_3")
+	if err != nil || monster.IsAlive {
+		t.Errorf("Expected monster_3 to be restored and dead, got %+v (%v)", monster, err)
+	}
+	if _, exists := state.Entities["monster_3"]; exists {
+		t.Errorf("Dead monster should not remain in entities")
+	}
+	if gm.dynamicTurnOrder.GetCurrentPhase() != GMPhase || gm.dynamicTurnOrder.GetCycleNumber() != 2 {
+		t.Errorf("Expected GM phase cycle 2, got %s cycle %d", gm.dynamicTurnOrder.GetCurrentPhase(), gm.dynamicTurnOrder.GetCycleNumber())
+	}
+}
@@ -22,8 +22,20 @@ type GameManager struct {
 	treasureResolver *TreasureResolver
 	heroActions      *HeroActionSystem
 	monsterSystem    *MonsterSystem
+	trapSystem       *TrapSystem
+	tradeSystem      *TradeSystem
+	heroLifecycle    *HeroLifecycleSystem
+	objectives       *QuestObjectiveTracker
+	triggers         *QuestTriggerSystem
+	armoury          *Armoury
 	furnitureSystem  *FurnitureSystem
 	debugSystem      *DebugSystem
+	eventStore       EventStore
+	eventRecorder    *EventRecorder
+	saveSlots        *SaveSlotStore
+	rules            *RuleSet // House rules shared by every system
+	campaign         *CampaignSession
+	startQuest       func(CampaignQuestStart) error
 	broadcaster      Broadcaster
 	logger           Logger
 	sequenceGen      SequenceGenerator
@@ -70,8 +82,12 @@ func createGameManager(gameState *GameState, furnitureSystem *FurnitureSystem, q
 	// Create debug system first
 	debugSystem := NewDebugSystem(debugConfig, gameState, broadcaster, logger)
 
+	// House rules start by the book; the lobby's choice is applied with SetRuleSet
+	rules := &RuleSet{}
+
 	// Create dice system
 	diceSystem := NewDiceSystem(debugSystem)
+	diceSystem.SetRuleSet(rules)
 
 	// Create turn manager with dice system
 	turnManager := NewTurnManager(broadcaster, logger, diceSystem)
@@ -106,16 +122,51 @@ func createGameManager(gameState *GameState, furnitureSystem *FurnitureSystem, q
 
 	// Create monster system
 	monsterSystem := NewMonsterSystem(gameState, turnManager, diceSystem, broadcaster, logger)
+	monsterSystem.SetContentManager(contentManager)
+	monsterSystem.SetTurnStateManager(turnStateManager)
+	monsterSystem.SetRuleSet(rules)
+	monsterSystem.SetFurnitureSystem(furnitureSystem)
+	turnManager.SetRuleSet(rules, monsterSystem.MonsterInSightOf)
+
+	// Create trap system with the quest's traps
+	trapSystem := NewTrapSystem(gameState, turnManager, diceSystem, broadcaster, logger)
+	trapSystem.LoadQuestTraps(quest)
+
+	// Create trade system for passing items between heroes
+	tradeSystem := NewTradeSystem(gameState, turnManager, inventoryManager, broadcaster, logger)
+	tradeSystem.SetTurnStateManager(turnStateManager)
+
+	// Create hero lifecycle system for dying, rescued and dead heroes
+	heroLifecycle := NewHeroLifecycleSystem(gameState, turnManager, dynamicTurnOrder, inventoryManager, broadcaster, logger)
+	monsterSystem.SetHeroLifecycle(heroLifecycle)
+	monsterSystem.SetInventoryManager(inventoryManager)
+	trapSystem.SetHeroLifecycle(heroLifecycle)
 
 	// Update hero action system with complete movement validator and monster system
 	movementValidator := NewMovementValidatorWithSystems(logger, monsterSystem, furnitureSystem)
 	heroActions.SetMovementValidator(movementValidator)
 	heroActions.SetMonsterSystem(monsterSystem)
+	heroActions.SetTrapSystem(trapSystem)
+	heroActions.SetTradeSystem(tradeSystem)
+	heroActions.SetHeroLifecycle(heroLifecycle)
 	heroActions.SetQuest(quest)
 	heroActions.SetTurnStateManager(turnStateManager)
 	heroActions.SetDynamicTurnOrderManager(dynamicTurnOrder)
 	heroActions.SetInventoryManager(inventoryManager)
 	heroActions.SetTreasureResolver(treasureResolver)
+	heroActions.SetRuleSet(rules)
+
+	// Create objective tracker to end the quest once its objectives are met
+	objectives := NewQuestObjectiveTracker(quest, gameState, turnManager, monsterSystem, inventoryManager, heroLifecycle, dynamicTurnOrder, broadcaster, logger)
+	heroLifecycle.SetQuestSummary(objectives.Summary)
+
+	// Create trigger system to run the quest's scripted events
+	triggers := NewQuestTriggerSystem(quest, gameState, turnManager, monsterSystem, inventoryManager, heroLifecycle, dynamicTurnOrder, broadcaster, logger)
+	triggers.SetQuestSummary(objectives.Summary)
+	heroActions.SetQuestTriggers(triggers)
+
+	// Create armoury for the heroes to shop in between quests
+	armoury := NewArmoury(contentManager, inventoryManager, turnManager, heroLifecycle, dynamicTurnOrder, broadcaster, logger)
 
 	// Create default player only if requested (not in lobby mode)
 	if createDefaultPlayer {
@@ -143,7 +194,7 @@ func createGameManager(gameState *GameState, furnitureSystem *FurnitureSystem, q
 		logger.Printf("Created default player: Barbarian (hero-1)")
 	}
 
-	return &GameManager{
+	gameManager := &GameManager{
 		gameState:        gameState,
 		turnManager:      turnManager,
 		turnStateManager: turnStateManager,
@@ -154,12 +205,36 @@ func createGameManager(gameState *GameState, furnitureSystem *FurnitureSystem, q
 		treasureResolver: treasureResolver,
 		heroActions:      heroActions,
 		monsterSystem:    monsterSystem,
+		trapSystem:       trapSystem,
+		tradeSystem:      tradeSystem,
+		heroLifecycle:    heroLifecycle,
+		objectives:       objectives,
+		triggers:         triggers,
+		armoury:          armoury,
 		furnitureSystem:  furnitureSystem,
 		debugSystem:      debugSystem,
+		rules:            rules,
 		broadcaster:      broadcaster,
 		logger:           logger,
 		sequenceGen:      sequenceGen,
-	}, nil
+	}
+
+	// Debug export/import work on the full game snapshot
+	debugSystem.SetSnapshotter(gameManager)
+
+	return gameManager, nil
+}
+
+// SetRuleSet replaces the house rules every system consults. The rules carry their own
+// lock, so systems reading them mid-action see either the old or the new set.
+func (gm *GameManager) SetRuleSet(rules protocol.HouseRules) {
+	gm.rules.Replace(rules)
+	gm.logger.Printf("House rules: %+v", rules)
+}
+
+// GetRuleSet returns the house rules in play
+func (gm *GameManager) GetRuleSet() *RuleSet {
+	return gm.rules
 }
 
 // ProcessHeroAction processes a hero action request
@@ -245,8 +320,7 @@ func (gm *GameManager) ProcessDoorToggle(req protocol.RequestToggleDoor) error {
 	}
 
 	// Use existing door toggle logic directly
-	seqPtr := &gm.sequenceGen.(*SequenceGeneratorImpl).counter
-	handleRequestToggleDoor(req, gm.gameState, gm.broadcaster.(*BroadcasterImpl).hub, seqPtr, quest, gm.furnitureSystem, gm.monsterSystem)
+	handleRequestToggleDoor(req, gm.gameState, gm.broadcaster, quest, gm.furnitureSystem, gm.monsterSystem)
 	return nil
 }
 
@@ -282,6 +356,11 @@ func (gm *GameManager) GetGameState() *GameState {
 	return gm.gameState
 }
 
+// GetBroadcaster returns the broadcaster sending (and, with the event log enabled, recording) game events
+func (gm *GameManager) GetBroadcaster() Broadcaster {
+	return gm.broadcaster
+}
+
 // GetMonsters returns all active monsters
 func (gm *GameManager) GetMonsters() map[string]*Monster {
 	gm.mutex.RLock()
@@ -316,6 +395,11 @@ func (gm *GameManager) GetTurnStateManager() *TurnStateManager {
 	return gm.turnStateManager
 }
 
+// GetInventoryManager returns the hero inventory manager
+func (gm *GameManager) GetInventoryManager() *InventoryManager {
+	return gm.inventoryManager
+}
+
 // GetDynamicTurnOrder returns the dynamic turn order manager
 func (gm *GameManager) GetDynamicTurnOrder() *DynamicTurnOrderManager {
 	return gm.dynamicTurnOrder
@@ -327,7 +411,71 @@ func (gm *GameManager) Shutdown() {
 	defer gm.mutex.Unlock()
 
 	gm.logger.Printf("Game manager shutting down")
-	// TODO: Implement cleanup logic
+	if gm.eventStore != nil {
+		if err := gm.eventStore.Close(); err != nil {
+			gm.logger.Printf("Failed to close event store: %v", err)
+		}
+	}
+}
+
+// EnableEventLog restores the game from its latest snapshot and replays any events stored
+// after it, then persists every further broadcast event to the store. Returns the number
+// of events replayed.
+func (gm *GameManager) EnableEventLog(store EventStore, game GameRecord) (int, error) {
+	gm.mutex.Lock()
+	defer gm.mutex.Unlock()
+
+	broadcaster, ok := gm.broadcaster.(*BroadcasterImpl)
+	if !ok {
+		return 0, fmt.Errorf("event log requires a hub broadcaster")
+	}
+
+	if err := store.EnsureGame(game); err != nil {
+		return 0, fmt.Errorf("failed to register game: %w", err)
+	}
+
+	snapshot, err := gm.loadLatestSnapshot(store, game.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+
+	var afterEventID int64
+	if snapshot != nil {
+		afterEventID = snapshot.LastEventID
+	}
+
+	events, err := store.LoadEvents(game.ID, afterEventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load events: %w", err)
+	}
+
+	if err := ReplayEvents(gm, events); err != nil {
+		return 0, err
+	}
+
+	recorder := NewEventRecorder(store, game.ID, gm.dynamicTurnOrder.GetCycleNumber)
+	if len(events) > 0 {
+		recorder.Resume(events[len(events)-1])
+	} else if snapshot != nil {
+		// Nothing after the snapshot yet: the next event is the first of the snapshot's cycle
+		recorder.Resume(StoredEvent{EventID: snapshot.LastEventID, TurnNumber: snapshot.TurnNumber, IndexInTurn: -1})
+	}
+	broadcaster.SetEventRecorder(recorder)
+
+	gm.eventStore = store
+	gm.eventRecorder = recorder
+
+	if snapshot != nil {
+		gm.logger.Printf("Event log enabled for game %s (snapshot from cycle %d, %d events replayed)", game.ID, snapshot.TurnNumber, len(events))
+	} else {
+		gm.logger.Printf("Event log enabled for game %s (%d events replayed)", game.ID, len(events))
+	}
+	return len(events), nil
+}
+
+// GetEventRecorder returns the recorder persisting this game's events (nil if disabled)
+func (gm *GameManager) GetEventRecorder() *EventRecorder {
+	return gm.eventRecorder
 }
 
 // DebugTeleportHero teleports a hero to a specific position (debug only)
@@ -440,6 +588,57 @@ func (gm *GameManager) GetMonsterSystem() *MonsterSystem {
 	return gm.monsterSystem
 }
 
+// GetTrapSystem returns the trap system
+func (gm *GameManager) GetTrapSystem() *TrapSystem {
+	return gm.trapSystem
+}
+
+// GetTradeSystem returns the trade system
+func (gm *GameManager) GetTradeSystem() *TradeSystem {
+	return gm.tradeSystem
+}
+
+// GetHeroLifecycle returns the hero lifecycle system
+func (gm *GameManager) GetHeroLifecycle() *HeroLifecycleSystem {
+	return gm.heroLifecycle
+}
+
+// GetArmoury returns the armoury
+func (gm *GameManager) GetArmoury() *Armoury {
+	return gm.armoury
+}
+
+// GetQuestEndedForSnapshot returns how the quest ended for client snapshot, or nil while it is being played
+func (gm *GameManager) GetQuestEndedForSnapshot() *protocol.QuestEnded {
+	outcome := gm.dynamicTurnOrder.GetQuestOutcome()
+	if outcome == nil {
+		return nil
+	}
+	return &protocol.QuestEnded{Result: outcome.Result, Reason: outcome.Reason, Cycle: outcome.Cycle, Summary: gm.objectives.Summary()}
+}
+
+// CheckQuestObjectives fires the quest's triggers, then ends the quest in victory if its
+// objectives have been met
+func (gm *GameManager) CheckQuestObjectives() {
+	if outcome := gm.triggers.Check(); outcome != nil {
+		gm.logger.Printf("Quest ended in %s: %s", outcome.Result, outcome.Reason)
+		return
+	}
+	if outcome := gm.objectives.Check(); outcome != nil {
+		gm.logger.Printf("Quest ended in %s: %s", outcome.Result, outcome.Reason)
+	}
+}
+
+// GetArmouryForSnapshot returns the armoury's stock for client snapshot, or nil while the quest is being played
+func (gm *GameManager) GetArmouryForSnapshot() *protocol.ArmouryLite {
+	return gm.armoury.ToLite()
+}
+
+// GetQuestProgressForSnapshot returns progress towards each quest objective for client snapshot
+func (gm *GameManager) GetQuestProgressForSnapshot() []protocol.QuestObjectiveLite {
+	return gm.objectives.GetProgress()
+}
+
 // GetFurnitureForSnapshot returns furniture in revealed regions for client snapshot
 func (gm *GameManager) GetFurnitureForSnapshot() []protocol.FurnitureLite {
 	gm.mutex.RLock()
@@ -508,19 +707,7 @@ func (gm *GameManager) GetMonstersForSnapshot() []protocol.MonsterLite {
 	for _, monster := range allMonsters {
 		// Only include monsters that have been discovered
 		if gm.gameState.KnownMonsters[monster.ID] {
-			monsterItem := protocol.MonsterLite{
-				ID:          monster.ID,
-				Type:        string(monster.Type),
-				Tile:        monster.Position,
-				Body:        monster.Body,
-				MaxBody:     monster.MaxBody,
-				Mind:        monster.Mind,
-				MaxMind:     monster.MaxMind,
-				AttackDice:  monster.AttackDice,
-				DefenseDice: monster.DefenseDice,
-				IsVisible:   monster.IsVisible,
-				IsAlive:     monster.IsAlive,
-			}
+			monsterItem := toMonsterLite(monster)
 
 			monsters = append(monsters, monsterItem)
 			gm.logger.Printf("DEBUG: Added monster item to snapshot: %s (%s) at (%d,%d) - visible: %v, alive: %v",
@@ -604,3 +791,46 @@ func (gm *GameManager) GetHeroTurnStatesForSnapshot() map[string]protocol.HeroTu
 	gm.logger.Printf("DEBUG: Returning %d hero turn states for snapshot", len(result))
 	return result
 }
+
+// GetHeroStatsForSnapshot returns every hero's current and equipment-derived stats for client snapshot
+func (gm *GameManager) GetHeroStatsForSnapshot() map[string]protocol.HeroStatsLite {
+	gm.mutex.RLock()
+	defer gm.mutex.RUnlock()
+
+	result := make(map[string]protocol.HeroStatsLite)
+
+	for _, player := range gm.turnManager.GetHeroPlayers() {
+		character := player.Character
+		if character == nil {
+			continue
+		}
+
+		lite := protocol.HeroStatsLite{
+			HeroID:       player.EntityID,
+			Class:        string(player.Class),
+			BodyPoints:   character.BaseStats.BodyPoints,
+			CurrentBody:  character.CurrentBody,
+			MindPoints:   character.BaseStats.MindPoints,
+			CurrentMind:  character.CurrentMind,
+			AttackDice:   character.GetEffectiveAttackDice(),
+			DefenseDice:  character.GetEffectiveDefenseDice(),
+			MovementDice: character.BaseStats.MovementDice,
+			Condition:    gm.heroLifecycle.GetCondition(player.EntityID),
+		}
+
+		if gm.inventoryManager != nil {
+			if inventory, err := gm.inventoryManager.GetInventory(player.EntityID); err == nil {
+				lite.Equipment = make(map[string]string, len(inventory.Equipment))
+				for slot, item := range inventory.Equipment {
+					if item != nil {
+						lite.Equipment[slot] = item.ID
+					}
+				}
+			}
+		}
+
+		result[player.EntityID] = lite
+	}
+
+	return result
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// gameSnapshotVersion is bumped whenever the snapshot layout changes incompatibly
+const gameSnapshotVersion = 1
+
+// GameSnapshotData is the full serialized game, one section per system
+type GameSnapshotData struct {
+	Version            int             `json:"version"`
+	QuestID            string          `json:"questId,omitempty"`
+	CycleNumber        int             `json:"cycleNumber"`
+	LastEventID        int64           `json:"lastEventId"`
+	CreatedAt          time.Time       `json:"createdAt"`
+	GameState          json.RawMessage `json:"gameState"`
+	Monsters           json.RawMessage `json:"monsters"`
+	Inventories        json.RawMessage `json:"inventories"`
+	Players            json.RawMessage `json:"players"`
+	TurnStates         json.RawMessage `json:"turnStates"`
+	TurnOrder          json.RawMessage `json:"turnOrder"`
+	TreasureDeck       json.RawMessage `json:"treasureDeck"`
+	ConsumedQuestNotes json.RawMessage `json:"consumedQuestNotes"`
+	Traps              json.RawMessage `json:"traps,omitempty"`
+	FallenHeroes       json.RawMessage `json:"fallenHeroes,omitempty"`
+	GroundItems        json.RawMessage `json:"groundItems,omitempty"`
+	HouseRules         json.RawMessage `json:"houseRules,omitempty"`
+	QuestObjectives    json.RawMessage `json:"questObjectives,omitempty"`
+	QuestTriggers      json.RawMessage `json:"questTriggers,omitempty"`
+	Campaign           json.RawMessage `json:"campaign,omitempty"`
+}
+
+// snapshotSection pairs a snapshot field with the system that fills and restores it
+type snapshotSection struct {
+	name    string
+	field   *json.RawMessage
+	save    func() ([]byte, error)
+	restore func([]byte) error
+}
+
+func (gm *GameManager) snapshotSections(data *GameSnapshotData) []snapshotSection {
+	sections := []snapshotSection{
+		{"game state", &data.GameState, gm.gameState.SerializeForPersistence, gm.gameState.RestoreFromPersistence},
+		{"monsters", &data.Monsters, gm.monsterSystem.SerializeForPersistence, gm.monsterSystem.RestoreFromPersistence},
+		{"inventories", &data.Inventories, gm.inventoryManager.SerializeForPersistence, gm.inventoryManager.RestoreFromPersistence},
+		{"players", &data.Players, gm.turnManager.SerializeForPersistence, gm.turnManager.RestoreFromPersistence},
+		{"turn states", &data.TurnStates, gm.turnStateManager.SerializeForPersistence, gm.turnStateManager.RestoreFromPersistence},
+		{"turn order", &data.TurnOrder, gm.dynamicTurnOrder.SerializeForPersistence, gm.dynamicTurnOrder.RestoreFromPersistence},
+		{"treasure deck", &data.TreasureDeck, gm.treasureDeck.SerializeForPersistence, gm.treasureDeck.RestoreFromPersistence},
+		{"quest notes", &data.ConsumedQuestNotes, gm.treasureResolver.SerializeForPersistence, gm.treasureResolver.RestoreFromPersistence},
+		{"traps", &data.Traps, gm.trapSystem.SerializeForPersistence, gm.trapSystem.RestoreFromPersistence},
+		{"fallen heroes", &data.FallenHeroes, gm.heroLifecycle.SerializeForPersistence, gm.heroLifecycle.RestoreFromPersistence},
+		{"ground items", &data.GroundItems, gm.inventoryManager.SerializeGroundItems, gm.inventoryManager.RestoreGroundItems},
+		{"house rules", &data.HouseRules, gm.rules.SerializeForPersistence, gm.rules.RestoreFromPersistence},
+		{"quest objectives", &data.QuestObjectives, gm.objectives.SerializeForPersistence, gm.objectives.RestoreFromPersistence},
+		{"quest triggers", &data.QuestTriggers, gm.triggers.SerializeForPersistence, gm.triggers.RestoreFromPersistence},
+	}
+	if gm.campaign != nil {
+		sections = append(sections, snapshotSection{"campaign", &data.Campaign, gm.campaign.SerializeForPersistence, gm.campaign.RestoreFromPersistence})
+	}
+	return sections
+}
+
+// CaptureSnapshot serializes every game system into a single snapshot
+func (gm *GameManager) CaptureSnapshot() (*GameSnapshotData, error) {
+	gm.mutex.RLock()
+	defer gm.mutex.RUnlock()
+
+	return gm.captureSnapshotLocked()
+}
+
+func (gm *GameManager) captureSnapshotLocked() (*GameSnapshotData, error) {
+	data := &GameSnapshotData{
+		Version:     gameSnapshotVersion,
+		QuestID:     gm.currentQuestID(),
+		CycleNumber: gm.dynamicTurnOrder.GetCycleNumber(),
+		CreatedAt:   time.Now(),
+	}
+	if gm.eventRecorder != nil {
+		data.LastEventID = gm.eventRecorder.LastEventID()
+	}
+
+	for _, section := range gm.snapshotSections(data) {
+		raw, err := section.save()
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize %s: %w", section.name, err)
+		}
+		*section.field = raw
+	}
+
+	return data, nil
+}
+
+// RestoreSnapshot replaces the state of every game system with the snapshot's contents
+func (gm *GameManager) RestoreSnapshot(data *GameSnapshotData) error {
+	gm.mutex.Lock()
+	defer gm.mutex.Unlock()
+
+	return gm.restoreSnapshotLocked(data)
+}
+
+func (gm *GameManager) restoreSnapshotLocked(data *GameSnapshotData) error {
+	if data.Version != gameSnapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d (expected %d)", data.Version, gameSnapshotVersion)
+	}
+	if questID := gm.currentQuestID(); data.QuestID != "" && questID != "" && data.QuestID != questID {
+		return &GameError{Code: "quest_mismatch", Message: fmt.Sprintf("snapshot is for quest %s, current quest is %s", data.QuestID, questID)}
+	}
+
+	for _, section := range gm.snapshotSections(data) {
+		if len(*section.field) == 0 {
+			continue
+		}
+		if err := section.restore(*section.field); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", section.name, err)
+		}
+	}
+
+	gm.logger.Printf("Restored game snapshot from cycle %d (last event %d)", data.CycleNumber, data.LastEventID)
+	return nil
+}
+
+// ImportSnapshot replaces the running game with an externally supplied snapshot (a save
+// slot or a debug import). With the event log enabled the imported state is also stored as
+// the latest snapshot, so a restart resumes from it rather than from the discarded timeline.
+func (gm *GameManager) ImportSnapshot(data *GameSnapshotData) error {
+	if err := gm.RestoreSnapshot(data); err != nil {
+		return err
+	}
+
+	gm.SaveCycleSnapshot()
+	return nil
+}
+
+// SaveCycleSnapshot stores a snapshot of the current turn cycle in the event store.
+// Called at the start of each hero/GM cycle and after a session is loaded; a no-op when
+// the event log is disabled.
+func (gm *GameManager) SaveCycleSnapshot() {
+	if gm.eventStore == nil || gm.eventRecorder == nil {
+		return
+	}
+
+	data, err := gm.CaptureSnapshot()
+	if err != nil {
+		gm.logger.Printf("Failed to capture snapshot: %v", err)
+		return
+	}
+	cycleNumber := data.CycleNumber
+
+	stateBinary, err := json.Marshal(data)
+	if err != nil {
+		gm.logger.Printf("Failed to encode snapshot for cycle %d: %v", cycleNumber, err)
+		return
+	}
+
+	err = gm.eventStore.SaveSnapshot(StoredSnapshot{
+		GameID:      gm.eventRecorder.GameID(),
+		TurnNumber:  cycleNumber,
+		StateBinary: stateBinary,
+		LastEventID: data.LastEventID,
+		CreatedAt:   data.CreatedAt,
+	})
+	if err != nil {
+		gm.logger.Printf("Failed to save snapshot for cycle %d: %v", cycleNumber, err)
+		return
+	}
+
+	gm.logger.Printf("Saved snapshot for cycle %d (last event %d, %d bytes)", cycleNumber, data.LastEventID, len(stateBinary))
+}
+
+// SaveSnapshotIfDue stores a snapshot when an event handled since the last one changed state
+// that replaying the event log does not restore. Called once each message has been handled.
+func (gm *GameManager) SaveSnapshotIfDue() {
+	if gm.eventRecorder == nil || !gm.eventRecorder.TakeSnapshotDue() {
+		return
+	}
+	gm.SaveCycleSnapshot()
+}
+
+// GetLastEventID returns the ID of the last persisted event (0 when the event log is disabled)
+func (gm *GameManager) GetLastEventID() int64 {
+	if gm.eventRecorder == nil {
+		return 0
+	}
+	return gm.eventRecorder.LastEventID()
+}
+
+// loadLatestSnapshot restores the most recent stored snapshot for the game, if any.
+// Must be called with gm.mutex held.
+func (gm *GameManager) loadLatestSnapshot(store EventStore, gameID string) (*StoredSnapshot, error) {
+	snapshot, err := store.LoadLatestSnapshot(gameID)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+
+	var data GameSnapshotData
+	if err := json.Unmarshal(snapshot.StateBinary, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot for cycle %d: %w", snapshot.TurnNumber, err)
+	}
+	if err := gm.restoreSnapshotLocked(&data); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// currentQuestID returns the ID of the quest being played, if known
+func (gm *GameManager) currentQuestID() string {
+	if gm.treasureResolver == nil || gm.treasureResolver.quest == nil {
+		return ""
+	}
+	return gm.treasureResolver.quest.ID
+}
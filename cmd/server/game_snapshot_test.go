@@ -0,0 +1,182 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/ws"
+)
+
+// newSnapshotTestGameManager builds a game manager with every persisted system but no content
+func newSnapshotTestGameManager() *GameManager {
+	logger := &MockLogger{}
+	state := &GameState{
+		Doors:              make(map[string]*DoorInfo),
+		DoorByEdge:         make(map[geometry.EdgeAddress]string),
+		BlockedWalls:       make(map[geometry.EdgeAddress]bool),
+		BlockedTiles:       make(map[protocol.TileAddress]bool),
+		Entities:           make(map[string]protocol.TileAddress),
+		RevealedRegions:    make(map[int]bool),
+		KnownRegions:       make(map[int]bool),
+		KnownDoors:         make(map[string]bool),
+		KnownBlockingWalls: make(map[string]bool),
+		KnownFurniture:     make(map[string]bool),
+		KnownMonsters:      make(map[string]bool),
+	}
+	state.AddDoor("door-1", &DoorInfo{Edge: geometry.EdgeAddress{X: 1, Y: 1, Orientation: geometry.Vertical}, State: "closed"})
+
+	broadcaster := NewBroadcaster(ws.NewHub(), NewSequenceGenerator())
+	contentManager := NewContentManager(logger)
+	treasureDeck := NewTreasureDeckManager(contentManager, logger)
+
+	turnManager := NewTurnManager(broadcaster, logger, NewDiceSystem(nil))
+	dynamicTurnOrder := NewDynamicTurnOrderManager(logger)
+	inventoryManager := NewInventoryManager(contentManager, logger)
+	monsterSystem := NewMonsterSystem(state, nil, nil, broadcaster, logger)
+	heroLifecycle := NewHeroLifecycleSystem(state, turnManager, dynamicTurnOrder, inventoryManager, broadcaster, logger)
+
+	return &GameManager{
+		gameState:        state,
+		turnManager:      turnManager,
+		turnStateManager: NewTurnStateManager(logger),
+		dynamicTurnOrder: dynamicTurnOrder,
+		contentManager:   contentManager,
+		inventoryManager: inventoryManager,
+		treasureDeck:     treasureDeck,
+		treasureResolver: NewTreasureResolver(contentManager, treasureDeck, nil, logger),
+		monsterSystem:    monsterSystem,
+		trapSystem:       NewTrapSystem(state, turnManager, NewDiceSystem(nil), broadcaster, logger),
+		heroLifecycle:    heroLifecycle,
+		objectives:       NewQuestObjectiveTracker(nil, state, turnManager, monsterSystem, inventoryManager, heroLifecycle, dynamicTurnOrder, broadcaster, logger),
+		triggers:         NewQuestTriggerSystem(nil, state, turnManager, monsterSystem, inventoryManager, heroLifecycle, dynamicTurnOrder, broadcaster, logger),
+		rules:            &RuleSet{},
+		broadcaster:      broadcaster,
+		logger:           logger,
+	}
+}
+
+func TestFileEventStore_LoadLatestSnapshot(t *testing.T) {
+	store, _ := NewFileEventStore(t.TempDir())
+
+	snapshot, err := store.LoadLatestSnapshot("game-1")
+	if err != nil || snapshot != nil {
+		t.Fatalf("Expected no snapshot for a new game, got %+v (%v)", snapshot, err)
+	}
+
+	for cycle := 1; cycle <= 3; cycle++ {
+		err := store.SaveSnapshot(StoredSnapshot{GameID: "game-1", TurnNumber: cycle, StateBinary: []byte(`{}`), LastEventID: int64(cycle * 10)})
+		if err != nil {
+			t.Fatalf("SaveSnapshot failed: %v", err)
+		}
+	}
+
+	snapshot, err = store.LoadLatestSnapshot("game-1")
+	if err != nil {
+		t.Fatalf("LoadLatestSnapshot failed: %v", err)
+	}
+	if snapshot.TurnNumber != 3 || snapshot.LastEventID != 30 {
+		t.Errorf("Expected snapshot for cycle 3 at event 30, got cycle %d at event %d", snapshot.TurnNumber, snapshot.LastEventID)
+	}
+
+	// A later snapshot of the same cycle replaces the earlier one
+	if err := store.SaveSnapshot(StoredSnapshot{GameID: "game-1", TurnNumber: 3, StateBinary: []byte(`{}`), LastEventID: 35}); err != nil {
+		t.Fatalf("SaveSnapshot failed: %v", err)
+	}
+	if paths, _ := filepath.Glob(filepath.Join(store.directory, "game-1.snapshot-*.json")); len(paths) != 3 {
+		t.Errorf("Expected one snapshot per cycle, got %v", paths)
+	}
+	if snapshot, _ = store.LoadLatestSnapshot("game-1"); snapshot.LastEventID != 35 {
+		t.Errorf("Expected the cycle 3 snapshot at event 35, got event %d", snapshot.LastEventID)
+	}
+}
+
+func TestGameManager_RecoversFromSnapshotAndTrailingEvents(t *testing.T) {
+	store, _ := NewFileEventStore(t.TempDir())
+	game := GameRecord{ID: "game-1", QuestID: "quest-01"}
+
+	gm := newSnapshotTestGameManager()
+	if _, err := gm.EnableEventLog(store, game); err != nil {
+		t.Fatalf("EnableEventLog failed: %v", err)
+	}
+
+	// State that only the snapshot captures (no event carries it)
+	gm.inventoryManager.InitializeHeroInventory("hero-1")
+	gm.inventoryManager.AddGold("hero-1", 25)
+	gm.turnStateManager.StartHeroTurn("hero-1", "player-1", protocol.TileAddress{X: 2, Y: 2})
+
+	gm.gameState.Entities["hero-1"] = protocol.TileAddress{X: 3, Y: 2}
+	gm.broadcaster.BroadcastEvent("EntityUpdated", protocol.EntityUpdated{ID: "hero-1", Tile: protocol.TileAddress{X: 3, Y: 2}})
+	gm.SaveCycleSnapshot()
+
+	// Events after the snapshot must be replayed on top of it
+	gm.gameState.Doors["door-1"].State = "open"
+	gm.broadcaster.BroadcastEvent("DoorStateChanged", protocol.DoorStateChanged{ThresholdID: "door-1", State: "open"})
+	gm.broadcaster.BroadcastEvent("RegionsRevealed", protocol.RegionsRevealed{IDs: []int{6}})
+
+	recovered := newSnapshotTestGameManager()
+	replayed, err := recovered.EnableEventLog(store, game)
+	if err != nil {
+		t.Fatalf("Recovery failed: %v", err)
+	}
+	if replayed != 2 {
+		t.Errorf("Expected only the 2 events after the snapshot to be replayed, got %d", replayed)
+	}
+
+	inventory, err := recovered.inventoryManager.GetInventory("hero-1")
+	if err != nil || inventory.Gold != 25 {
+		t.Errorf("Expected hero-1 inventory with 25 gold, got %+v (%v)", inventory, err)
+	}
+	if recovered.turnStateManager.GetHeroTurnState("hero-1") == nil {
+		t.Errorf("Expected hero-1 turn state to be restored")
+	}
+	if pos := recovered.gameState.Entities["hero-1"]; pos.X != 3 || pos.Y != 2 {
+		t.Errorf("Expected hero at (3,2), got (%d,%d)", pos.X, pos.Y)
+	}
+	if recovered.gameState.Doors["door-1"].State != "open" {
+		t.Errorf("Expected door opened after the snapshot to be open")
+	}
+	if !recovered.gameState.RevealedRegions[6] {
+		t.Errorf("Expected region 6 revealed")
+	}
+	if recovered.GetLastEventID() != gm.GetLastEventID() {
+		t.Errorf("Expected recorder to resume at event %d, got %d", gm.GetLastEventID(), recovered.GetLastEventID())
+	}
+}
+
+func TestGameManager_SnapshotsChangesReplayCannotRestore(t *testing.T) {
+	store, _ := NewFileEventStore(t.TempDir())
+	game := GameRecord{ID: "game-1", QuestID: "quest-01"}
+
+	gm := newSnapshotTestGameManager()
+	if _, err := gm.EnableEventLog(store, game); err != nil {
+		t.Fatalf("EnableEventLog failed: %v", err)
+	}
+
+	// Board events are replayed, so they need no snapshot
+	gm.gameState.Entities["hero-1"] = protocol.TileAddress{X: 3, Y: 2}
+	gm.broadcaster.BroadcastEvent("EntityUpdated", protocol.EntityUpdated{ID: "hero-1", Tile: protocol.TileAddress{X: 3, Y: 2}})
+	gm.SaveSnapshotIfDue()
+	if snapshot, _ := store.LoadLatestSnapshot(game.ID); snapshot != nil {
+		t.Fatalf("Expected no snapshot after a replayable event, got one at event %d", snapshot.LastEventID)
+	}
+
+	// An inventory change is only recorded, so the game is snapshotted after it
+	gm.inventoryManager.InitializeHeroInventory("hero-1")
+	gm.inventoryManager.AddGold("hero-1", 40)
+	gm.broadcaster.BroadcastEvent("InventoryChanged", map[string]any{"heroId": "hero-1"})
+	gm.SaveSnapshotIfDue()
+
+	recovered := newSnapshotTestGameManager()
+	if _, err := recovered.EnableEventLog(store, game); err != nil {
+		t.Fatalf("Recovery failed: %v", err)
+	}
+	inventory, err := recovered.inventoryManager.GetInventory("hero-1")
+	if err != nil || inventory.Gold != 40 {
+		t.Errorf("Expected hero-1 to keep 40 gold across a restart, got %+v (%v)", inventory, err)
+	}
+	if pos := recovered.gameState.Entities["hero-1"]; pos.X != 3 || pos.Y != 2 {
+		t.Errorf("Expected hero at (3,2), got (%d,%d)", pos.X, pos.Y)
+	}
+}
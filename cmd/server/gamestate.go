@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"sync"
 
 	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
@@ -73,6 +74,95 @@ func (gs *GameState) SetHeroPosition(heroID string, x, y int) {
 	gs.RevealedRegions[heroRegion] = true
 }
 
+// gameStatePersistence holds the mutable parts of GameState; board geometry is rebuilt from content
+type gameStatePersistence struct {
+	BlockedWalls       []geometry.EdgeAddress          `json:"blockedWalls"`
+	BlockedTiles       []protocol.TileAddress          `json:"blockedTiles"`
+	Doors              map[string]*DoorInfo            `json:"doors"`
+	Entities           map[string]protocol.TileAddress `json:"entities"`
+	RevealedRegions    map[int]bool                    `json:"revealedRegions"`
+	KnownRegions       map[int]bool                    `json:"knownRegions"`
+	KnownDoors         map[string]bool                 `json:"knownDoors"`
+	KnownBlockingWalls map[string]bool                 `json:"knownBlockingWalls"`
+	KnownFurniture     map[string]bool                 `json:"knownFurniture"`
+	KnownMonsters      map[string]bool                 `json:"knownMonsters"`
+}
+
+// SerializeForPersistence serializes the mutable game state to JSON
+func (gs *GameState) SerializeForPersistence() ([]byte, error) {
+	gs.Lock.Lock()
+	defer gs.Lock.Unlock()
+
+	data := gameStatePersistence{
+		BlockedWalls:       make([]geometry.EdgeAddress, 0, len(gs.BlockedWalls)),
+		BlockedTiles:       make([]protocol.TileAddress, 0, len(gs.BlockedTiles)),
+		Doors:              gs.Doors,
+		Entities:           gs.Entities,
+		RevealedRegions:    gs.RevealedRegions,
+		KnownRegions:       gs.KnownRegions,
+		KnownDoors:         gs.KnownDoors,
+		KnownBlockingWalls: gs.KnownBlockingWalls,
+		KnownFurniture:     gs.KnownFurniture,
+		KnownMonsters:      gs.KnownMonsters,
+	}
+	for edge, blocked := range gs.BlockedWalls {
+		if blocked {
+			data.BlockedWalls = append(data.BlockedWalls, edge)
+		}
+	}
+	for tile, blocked := range gs.BlockedTiles {
+		if blocked {
+			data.BlockedTiles = append(data.BlockedTiles, tile)
+		}
+	}
+
+	return json.Marshal(data)
+}
+
+// RestoreFromPersistence replaces the mutable game state with previously serialized data
+func (gs *GameState) RestoreFromPersistence(data []byte) error {
+	var restored gameStatePersistence
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return err
+	}
+
+	gs.Lock.Lock()
+	defer gs.Lock.Unlock()
+
+	gs.BlockedWalls = make(map[geometry.EdgeAddress]bool, len(restored.BlockedWalls))
+	for _, edge := range restored.BlockedWalls {
+		gs.BlockedWalls[edge] = true
+	}
+	gs.BlockedTiles = make(map[protocol.TileAddress]bool, len(restored.BlockedTiles))
+	for _, tile := range restored.BlockedTiles {
+		gs.BlockedTiles[tile] = true
+	}
+
+	gs.Doors = make(map[string]*DoorInfo, len(restored.Doors))
+	gs.DoorByEdge = make(map[geometry.EdgeAddress]string, len(restored.Doors))
+	for id, door := range restored.Doors {
+		gs.AddDoor(id, door)
+	}
+
+	gs.Entities = orEmpty(restored.Entities)
+	gs.RevealedRegions = orEmpty(restored.RevealedRegions)
+	gs.KnownRegions = orEmpty(restored.KnownRegions)
+	gs.KnownDoors = orEmpty(restored.KnownDoors)
+	gs.KnownBlockingWalls = orEmpty(restored.KnownBlockingWalls)
+	gs.KnownFurniture = orEmpty(restored.KnownFurniture)
+	gs.KnownMonsters = orEmpty(restored.KnownMonsters)
+
+	return nil
+}
+
+// orEmpty returns m, or an empty map if m is nil
+func orEmpty[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return make(map[K]V)
+	}
+	return m
+}
+
 func addKnownRegions(state *GameState, ids []int) (added []int) {
 	for _, rid := range ids {
 		if !state.KnownRegions[rid] {
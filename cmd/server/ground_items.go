@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// groundPile is the persisted form of the items lying on one tile
+type groundPile struct {
+	Tile  protocol.TileAddress `json:"tile"`
+	Items []*ItemCard          `json:"items"`
+}
+
+// EquippedItem returns the item a hero has equipped in a slot, or nil
+func (im *InventoryManager) EquippedItem(heroID string, slot string) *ItemCard {
+	im.mutex.RLock()
+	defer im.mutex.RUnlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return nil
+	}
+	return inventory.Equipment[slot]
+}
+
+// DropItem takes one copy of an item from a hero, carried or equipped, and leaves it on a tile
+func (im *InventoryManager) DropItem(heroID string, itemID string, tile protocol.TileAddress) (*ItemCard, error) {
+	im.mutex.Lock()
+	defer im.mutex.Unlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return nil, fmt.Errorf("inventory for hero %s not found", heroID)
+	}
+
+	tile = groundTile(tile)
+	item := removeInventoryItem(inventory, itemID)
+	if item == nil {
+		return nil, &GameError{Code: "item_not_held", Message: fmt.Sprintf("hero %s does not hold item %s", heroID, itemID)}
+	}
+	im.ground[tile] = append(im.ground[tile], item)
+	im.refreshEquipmentMods(heroID)
+
+	im.logger.Printf("Hero %s dropped %s at (%d,%d)", heroID, itemID, tile.X, tile.Y)
+	return item, nil
+}
+
+// PickUpItems hands everything lying on a tile to a hero as carried items
+func (im *InventoryManager) PickUpItems(heroID string, tile protocol.TileAddress) ([]*ItemCard, error) {
+	im.mutex.Lock()
+	defer im.mutex.Unlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return nil, fmt.Errorf("inventory for hero %s not found", heroID)
+	}
+
+	tile = groundTile(tile)
+	items := im.ground[tile]
+	if len(items) == 0 {
+		return nil, &GameError{Code: "nothing_to_pick_up", Message: fmt.Sprintf("nothing lies at (%d,%d)", tile.X, tile.Y)}
+	}
+	delete(im.ground, tile)
+	inventory.Carried = append(inventory.Carried, items...)
+	im.refreshEquipmentMods(heroID)
+
+	im.logger.Printf("Hero %s picked up %d items at (%d,%d)", heroID, len(items), tile.X, tile.Y)
+	return items, nil
+}
+
+// GetGroundItems lists every tile with items lying on it, in board order
+func (im *InventoryManager) GetGroundItems() []protocol.GroundItemsLite {
+	im.mutex.RLock()
+	defer im.mutex.RUnlock()
+
+	piles := make([]protocol.GroundItemsLite, 0, len(im.ground))
+	for _, pile := range im.sortedPilesLocked() {
+		piles = append(piles, protocol.GroundItemsLite{Tile: pile.Tile, Items: itemsToLite(pile.Items)})
+	}
+	return piles
+}
+
+// SerializeGroundItems serializes the items lying on the board to JSON
+func (im *InventoryManager) SerializeGroundItems() ([]byte, error) {
+	im.mutex.RLock()
+	defer im.mutex.RUnlock()
+
+	return json.Marshal(im.sortedPilesLocked())
+}
+
+// RestoreGroundItems replaces the items lying on the board with previously serialized data
+func (im *InventoryManager) RestoreGroundItems(data []byte) error {
+	var restored []groundPile
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return err
+	}
+
+	im.mutex.Lock()
+	defer im.mutex.Unlock()
+
+	im.ground = make(map[protocol.TileAddress][]*ItemCard, len(restored))
+	for _, pile := range restored {
+		if len(pile.Items) > 0 {
+			tile := groundTile(pile.Tile)
+			im.ground[tile] = append(im.ground[tile], pile.Items...)
+		}
+	}
+
+	im.logger.Printf("Restored items on %d tiles from persistence", len(im.ground))
+	return nil
+}
+
+// groundTile keys ground piles by board square alone
+func groundTile(tile protocol.TileAddress) protocol.TileAddress {
+	return protocol.TileAddress{X: tile.X, Y: tile.Y}
+}
+
+// sortedPilesLocked returns the ground piles ordered by row, then column
+func (im *InventoryManager) sortedPilesLocked() []groundPile {
+	piles := make([]groundPile, 0, len(im.ground))
+	for tile, items := range im.ground {
+		piles = append(piles, groundPile{Tile: tile, Items: items})
+	}
+	sort.Slice(piles, func(i, j int) bool {
+		if piles[i].Tile.Y != piles[j].Tile.Y {
+			return piles[i].Tile.Y < piles[j].Tile.Y
+		}
+		return piles[i].Tile.X < piles[j].Tile.X
+	})
+	return piles
+}
@@ -6,10 +6,9 @@ import (
 
 	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
 	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
-	"github.com/Ko-stant/dungeon-campaign-engine/internal/ws"
 )
 
-func handleRequestMove(req protocol.RequestMove, state *GameState, hub *ws.Hub, sequence *uint64, quest *geometry.QuestDefinition, furnitureSystem *FurnitureSystem, monsterSystem *MonsterSystem) {
+func handleRequestMove(req protocol.RequestMove, state *GameState, broadcaster Broadcaster, quest *geometry.QuestDefinition, furnitureSystem *FurnitureSystem, monsterSystem *MonsterSystem, trapSystem *TrapSystem) {
 	log.Printf("DEBUG: handleRequestMove called - entity=%s dx=%d dy=%d", req.EntityID, req.DX, req.DY)
 
 	if (req.DX != 0 && req.DY != 0) || req.DX < -1 || req.DX > 1 || req.DY < -1 || req.DY > 1 {
@@ -63,6 +62,14 @@ func handleRequestMove(req protocol.RequestMove, state *GameState, hub *ws.Hub,
 		return
 	}
 
+	// Check if the hero knows of a trap on the destination tile
+	if trapSystem != nil && trapSystem.IsKnownTrapAt(req.EntityID, nx, ny) {
+		log.Printf("DEBUG: Movement blocked by known trap: from (%d,%d) to (%d,%d)",
+			tile.X, tile.Y, nx, ny)
+		state.Lock.Unlock()
+		return
+	}
+
 	edge := edgeForStep(tile.X, tile.Y, req.DX, req.DY)
 	log.Printf("DEBUG: Checking edge for movement: %+v", edge)
 	if state.BlockedWalls[edge] {
@@ -79,13 +86,21 @@ func handleRequestMove(req protocol.RequestMove, state *GameState, hub *ws.Hub,
 			return
 		}
 	}
+	from := tile
 	tile.X = nx
 	tile.Y = ny
 	state.Entities[req.EntityID] = tile
 	state.Lock.Unlock()
 
 	log.Printf("DEBUG: Movement successful - entity %s moved to (%d,%d)", req.EntityID, nx, ny)
-	broadcastEvent(hub, sequence, "EntityUpdated", protocol.EntityUpdated{ID: req.EntityID, Tile: tile})
+	broadcaster.BroadcastEvent("EntityUpdated", protocol.EntityUpdated{ID: req.EntityID, Tile: tile})
+
+	// Stepping onto an unknown trap springs it
+	if trapSystem != nil {
+		if _, err := trapSystem.SpringTrapOnEntry(req.EntityID, from, tile); err != nil {
+			log.Printf("Failed to spring trap at (%d,%d): %v", nx, ny, err)
+		}
+	}
 
 	hero := state.Entities[req.EntityID]
 	visible := computeVisibleRoomRegionsNow(state, hero, state.CorridorRegion)
@@ -93,10 +108,10 @@ func handleRequestMove(req protocol.RequestMove, state *GameState, hub *ws.Hub,
 	newlyKnown := addKnownRegions(state, visible)
 	state.Lock.Unlock()
 	log.Printf("visibleNow (hero @ %d,%d): %v", hero.X, hero.Y, visible)
-	broadcastEvent(hub, sequence, "VisibleNow", protocol.VisibleNow{IDs: visible})
+	broadcaster.BroadcastEvent("VisibleNow", protocol.VisibleNow{IDs: visible})
 
 	if len(newlyKnown) > 0 {
-		broadcastEvent(hub, sequence, "RegionsKnown", protocol.RegionsKnown{IDs: newlyKnown})
+		broadcaster.BroadcastEvent("RegionsKnown", protocol.RegionsKnown{IDs: newlyKnown})
 	}
 
 	// Check for newly visible doors
@@ -106,50 +121,41 @@ func handleRequestMove(req protocol.RequestMove, state *GameState, hub *ws.Hub,
 	// Send newly visible doors to client (client will add them to existing ones)
 	if len(newlyVisibleDoors) > 0 {
 		log.Printf("sending %d newly visible doors to client", len(newlyVisibleDoors))
-		broadcastEvent(hub, sequence, "DoorsVisible", protocol.DoorsVisible{Doors: newlyVisibleDoors})
+		broadcaster.BroadcastEvent("DoorsVisible", protocol.DoorsVisible{Doors: newlyVisibleDoors})
 	}
 
 	// Check for newly visible blocking walls
 	_, newlyVisibleBlockingWalls := getVisibleBlockingWalls(state, hero, quest)
 	if len(newlyVisibleBlockingWalls) > 0 {
 		log.Printf("sending %d newly visible blocking walls to client", len(newlyVisibleBlockingWalls))
-		broadcastEvent(hub, sequence, "BlockingWallsVisible", protocol.BlockingWallsVisible{BlockingWalls: newlyVisibleBlockingWalls})
+		broadcaster.BroadcastEvent("BlockingWallsVisible", protocol.BlockingWallsVisible{BlockingWalls: newlyVisibleBlockingWalls})
 	}
 }
 
-func handleRequestToggleDoor(req protocol.RequestToggleDoor, state *GameState, hub *ws.Hub, sequence *uint64, quest *geometry.QuestDefinition, furnitureSystem *FurnitureSystem, monsterSystem *MonsterSystem) {
+func handleRequestToggleDoor(req protocol.RequestToggleDoor, state *GameState, broadcaster Broadcaster, quest *geometry.QuestDefinition, furnitureSystem *FurnitureSystem, monsterSystem *MonsterSystem) {
 	state.Lock.Lock()
 	info, ok := state.Doors[req.ThresholdID]
-	if !ok || info == nil || info.State == "open" {
+	if !ok || info == nil || info.State == "open" || info.State == "locked" {
 		state.Lock.Unlock()
 		return
 	}
 	info.State = "open"
-
-	var toReveal []int
-	a, b := info.RegionA, info.RegionB
-	if state.RevealedRegions[a] && !state.RevealedRegions[b] {
-		state.RevealedRegions[b] = true
-		toReveal = append(toReveal, b)
-	} else if state.RevealedRegions[b] && !state.RevealedRegions[a] {
-		state.RevealedRegions[a] = true
-		toReveal = append(toReveal, a)
-	}
+	toReveal := revealAcrossDoor(state, info)
 	state.Lock.Unlock()
 
-	broadcastEvent(hub, sequence, "DoorStateChanged", protocol.DoorStateChanged{ThresholdID: req.ThresholdID, State: "open"})
+	broadcaster.BroadcastEvent("DoorStateChanged", protocol.DoorStateChanged{ThresholdID: req.ThresholdID, State: "open"})
 
 	if len(toReveal) > 0 {
-		broadcastEvent(hub, sequence, "RegionsRevealed", protocol.RegionsRevealed{IDs: toReveal})
+		broadcaster.BroadcastEvent("RegionsRevealed", protocol.RegionsRevealed{IDs: toReveal})
 	}
 	hero := state.Entities["hero-1"]
 	visible := computeVisibleRoomRegionsNow(state, hero, state.CorridorRegion)
 	state.Lock.Lock()
 	newlyKnown := addKnownRegions(state, visible)
 	state.Lock.Unlock()
-	broadcastEvent(hub, sequence, "VisibleNow", protocol.VisibleNow{IDs: visible})
+	broadcaster.BroadcastEvent("VisibleNow", protocol.VisibleNow{IDs: visible})
 	if len(newlyKnown) > 0 {
-		broadcastEvent(hub, sequence, "RegionsKnown", protocol.RegionsKnown{IDs: newlyKnown})
+		broadcaster.BroadcastEvent("RegionsKnown", protocol.RegionsKnown{IDs: newlyKnown})
 	}
 
 	// Check for newly visible doors after opening door
@@ -157,26 +163,41 @@ func handleRequestToggleDoor(req protocol.RequestToggleDoor, state *GameState, h
 	newlyVisibleDoors := checkForNewlyVisibleDoors(state, hero)
 
 	if len(newlyVisibleDoors) > 0 {
-		broadcastEvent(hub, sequence, "DoorsVisible", protocol.DoorsVisible{Doors: newlyVisibleDoors})
+		broadcaster.BroadcastEvent("DoorsVisible", protocol.DoorsVisible{Doors: newlyVisibleDoors})
 	}
 
 	// Check for newly visible blocking walls after door toggle
 	_, newlyVisibleBlockingWalls := getVisibleBlockingWalls(state, hero, quest)
 	if len(newlyVisibleBlockingWalls) > 0 {
-		broadcastEvent(hub, sequence, "BlockingWallsVisible", protocol.BlockingWallsVisible{BlockingWalls: newlyVisibleBlockingWalls})
+		broadcaster.BroadcastEvent("BlockingWallsVisible", protocol.BlockingWallsVisible{BlockingWalls: newlyVisibleBlockingWalls})
 	}
 
 	// Check for newly visible furniture after door toggle
 	newlyVisibleFurniture := checkForNewlyVisibleFurniture(state, furnitureSystem)
 	if len(newlyVisibleFurniture) > 0 {
-		broadcastEvent(hub, sequence, "FurnitureVisible", protocol.FurnitureVisible{Furniture: newlyVisibleFurniture})
+		broadcaster.BroadcastEvent("FurnitureVisible", protocol.FurnitureVisible{Furniture: newlyVisibleFurniture})
 	}
 
 	// Check for newly visible monsters after door toggle
 	newlyVisibleMonsters := checkForNewlyVisibleMonsters(state, monsterSystem)
 	if len(newlyVisibleMonsters) > 0 {
-		broadcastEvent(hub, sequence, "MonstersVisible", protocol.MonstersVisible{Monsters: newlyVisibleMonsters})
+		broadcaster.BroadcastEvent("MonstersVisible", protocol.MonstersVisible{Monsters: newlyVisibleMonsters})
+	}
+}
+
+// revealAcrossDoor reveals the region beyond an open door when only one side of it is revealed.
+// Returns the regions revealed. Callers must hold the game state lock.
+func revealAcrossDoor(state *GameState, info *DoorInfo) []int {
+	var toReveal []int
+	a, b := info.RegionA, info.RegionB
+	if state.RevealedRegions[a] && !state.RevealedRegions[b] {
+		state.RevealedRegions[b] = true
+		toReveal = append(toReveal, b)
+	} else if state.RevealedRegions[b] && !state.RevealedRegions[a] {
+		state.RevealedRegions[a] = true
+		toReveal = append(toReveal, a)
 	}
+	return toReveal
 }
 
 func checkForNewlyVisibleFurniture(state *GameState, furnitureSystem *FurnitureSystem) []protocol.FurnitureLite {
@@ -238,8 +259,8 @@ func checkForNewlyVisibleFurniture(state *GameState, furnitureSystem *FurnitureS
 func checkForNewlyVisibleMonsters(state *GameState, monsterSystem *MonsterSystem) []protocol.MonsterLite {
 	var newlyVisible []protocol.MonsterLite
 
-	// If no monster system provided, return empty list
-	if monsterSystem == nil {
+	// If no monster system provided, or the GM reveals monsters under the house rules, return empty list
+	if monsterSystem == nil || !monsterSystem.RevealsOnSight() {
 		return newlyVisible
 	}
 
@@ -259,19 +280,7 @@ func checkForNewlyVisibleMonsters(state *GameState, monsterSystem *MonsterSystem
 			state.KnownMonsters[monster.ID] = true
 			monster.IsVisible = true
 
-			monsterItem := protocol.MonsterLite{
-				ID:          monster.ID,
-				Type:        string(monster.Type),
-				Tile:        monster.Position,
-				Body:        monster.Body,
-				MaxBody:     monster.MaxBody,
-				Mind:        monster.Mind,
-				MaxMind:     monster.MaxMind,
-				AttackDice:  monster.AttackDice,
-				DefenseDice: monster.DefenseDice,
-				IsVisible:   monster.IsVisible,
-				IsAlive:     monster.IsAlive,
-			}
+			monsterItem := toMonsterLite(monster)
 			newlyVisible = append(newlyVisible, monsterItem)
 			log.Printf("DEBUG: Newly visible monster %s (%s) in region %d at (%d,%d)",
 				monster.ID, monster.Type, monsterRegion, monster.Position.X, monster.Position.Y)
@@ -281,7 +290,7 @@ func checkForNewlyVisibleMonsters(state *GameState, monsterSystem *MonsterSystem
 	return newlyVisible
 }
 
-func handleWebSocketMessage(data []byte, state *GameState, hub *ws.Hub, sequence *uint64, quest *geometry.QuestDefinition, furnitureSystem *FurnitureSystem, monsterSystem *MonsterSystem, gameManager *GameManager, playerID string) {
+func handleWebSocketMessage(data []byte, state *GameState, broadcaster Broadcaster, quest *geometry.QuestDefinition, furnitureSystem *FurnitureSystem, monsterSystem *MonsterSystem, gameManager *GameManager, playerID string) {
 	var env protocol.IntentEnvelope
 	if err := json.Unmarshal(data, &env); err != nil {
 		return
@@ -293,14 +302,14 @@ func handleWebSocketMessage(data []byte, state *GameState, hub *ws.Hub, sequence
 		if err := json.Unmarshal(env.Payload, &req); err != nil {
 			return
 		}
-		handleRequestMove(req, state, hub, sequence, quest, furnitureSystem, monsterSystem)
+		handleRequestMove(req, state, broadcaster, quest, furnitureSystem, monsterSystem, gameManager.GetTrapSystem())
 
 	case "RequestToggleDoor":
 		var req protocol.RequestToggleDoor
 		if err := json.Unmarshal(env.Payload, &req); err != nil {
 			return
 		}
-		handleRequestToggleDoor(req, state, hub, sequence, quest, furnitureSystem, monsterSystem)
+		handleRequestToggleDoor(req, state, broadcaster, quest, furnitureSystem, monsterSystem)
 
 	// Quest Setup Phase
 	case "RequestSelectStartingPosition":
@@ -308,30 +317,37 @@ func handleWebSocketMessage(data []byte, state *GameState, hub *ws.Hub, sequence
 		if err := json.Unmarshal(env.Payload, &req); err != nil {
 			return
 		}
-		handleRequestSelectStartingPosition(req, playerID, gameManager, hub, sequence)
+		handleRequestSelectStartingPosition(req, playerID, gameManager, broadcaster)
+
+	case "RequestChooseSpellSchool":
+		var req protocol.RequestChooseSpellSchool
+		if err := json.Unmarshal(env.Payload, &req); err != nil {
+			return
+		}
+		handleRequestChooseSpellSchool(req, playerID, gameManager, broadcaster)
 
 	case "RequestQuestSetupToggleReady":
 		var req protocol.RequestToggleReady
 		if err := json.Unmarshal(env.Payload, &req); err != nil {
 			return
 		}
-		handleRequestQuestSetupToggleReady(playerID, req.IsReady, gameManager, hub, sequence)
+		handleRequestQuestSetupToggleReady(playerID, req.IsReady, gameManager, broadcaster)
 
 	// Dynamic Turn Order
 	case "RequestElectSelfAsNextPlayer":
-		handleRequestElectSelfAsNextPlayer(playerID, gameManager, hub, sequence)
+		handleRequestElectSelfAsNextPlayer(playerID, gameManager, broadcaster)
 
 	case "RequestCancelPlayerElection":
-		handleRequestCancelPlayerElection(playerID, gameManager, hub, sequence)
+		handleRequestCancelPlayerElection(playerID, gameManager, broadcaster)
 
 	case "RequestConfirmElectionAndStartTurn":
-		handleRequestConfirmElectionAndStartTurn(gameManager, hub, sequence)
+		handleRequestConfirmElectionAndStartTurn(gameManager, broadcaster)
 
 	case "RequestCompleteHeroTurn":
-		handleRequestCompleteHeroTurn(playerID, gameManager, hub, sequence)
+		handleRequestCompleteHeroTurn(playerID, gameManager, broadcaster)
 
 	case "RequestCompleteGMTurn":
-		handleRequestCompleteGMTurn(gameManager, hub, sequence)
+		handleRequestCompleteGMTurn(gameManager, broadcaster)
 
 	// Monster Management
 	case "RequestSelectMonster":
@@ -339,28 +355,104 @@ func handleWebSocketMessage(data []byte, state *GameState, hub *ws.Hub, sequence
 		if err := json.Unmarshal(env.Payload, &req); err != nil {
 			return
 		}
-		handleRequestSelectMonster(req, gameManager, hub, sequence)
+		handleRequestSelectMonster(req, gameManager, broadcaster)
 
 	case "RequestMoveMonster":
 		var req protocol.RequestMoveMonster
 		if err := json.Unmarshal(env.Payload, &req); err != nil {
 			return
 		}
-		handleRequestMoveMonster(req, gameManager, hub, sequence, state, furnitureSystem, monsterSystem)
+		handleRequestMoveMonster(req, gameManager, broadcaster, state, furnitureSystem, monsterSystem)
 
 	case "RequestMonsterAttack":
 		var req protocol.RequestMonsterAttack
 		if err := json.Unmarshal(env.Payload, &req); err != nil {
 			return
 		}
-		handleRequestMonsterAttack(req, gameManager, hub, sequence)
+		handleRequestMonsterAttack(req, gameManager, broadcaster)
+
+	case "RequestRollDefense":
+		var req protocol.RequestRollDefense
+		if err := json.Unmarshal(env.Payload, &req); err != nil {
+			return
+		}
+		handleRequestRollDefense(req, playerID, gameManager)
+
+	case "RequestRevealMonster":
+		var req protocol.RequestRevealMonster
+		if err := json.Unmarshal(env.Payload, &req); err != nil {
+			return
+		}
+		handleRequestRevealMonster(req, gameManager, broadcaster)
 
 	case "RequestUseMonsterAbility":
 		var req protocol.RequestUseMonsterAbility
 		if err := json.Unmarshal(env.Payload, &req); err != nil {
 			return
 		}
-		handleRequestUseMonsterAbility(req, gameManager, hub, sequence)
+		handleRequestUseMonsterAbility(req, gameManager, broadcaster)
+
+	// Trading
+	case "RequestProposeTrade":
+		var req protocol.RequestProposeTrade
+		if err := json.Unmarshal(env.Payload, &req); err != nil {
+			return
+		}
+		handleRequestProposeTrade(req, playerID, gameManager)
+
+	case "RequestRespondToTrade":
+		var req protocol.RequestRespondToTrade
+		if err := json.Unmarshal(env.Payload, &req); err != nil {
+			return
+		}
+		handleRequestRespondToTrade(req, playerID, gameManager)
+
+	// Fallen heroes
+	case "RequestLootCorpse":
+		var req protocol.RequestLootCorpse
+		if err := json.Unmarshal(env.Payload, &req); err != nil {
+			return
+		}
+		handleRequestLootCorpse(req, playerID, gameManager)
+
+	// Save Slots
+	case "RequestSaveSession":
+		var req protocol.RequestSaveSession
+		if err := json.Unmarshal(env.Payload, &req); err != nil {
+			return
+		}
+		handleRequestSaveSession(req, playerID, gameManager, broadcaster)
+
+	case "RequestLoadSession":
+		var req protocol.RequestLoadSession
+		if err := json.Unmarshal(env.Payload, &req); err != nil {
+			return
+		}
+		handleRequestLoadSession(req, playerID, gameManager)
+
+	case "RequestListSessions":
+		handleRequestListSessions(gameManager, broadcaster)
+
+	case "RequestAdvanceQuest":
+		var req protocol.RequestAdvanceQuest
+		if err := json.Unmarshal(env.Payload, &req); err != nil {
+			return
+		}
+		handleRequestAdvanceQuest(req, playerID, gameManager, broadcaster)
+
+	case "RequestBuyItem":
+		var req protocol.RequestBuyItem
+		if err := json.Unmarshal(env.Payload, &req); err != nil {
+			return
+		}
+		handleRequestBuyItem(req, playerID, gameManager)
+
+	case "RequestSellItem":
+		var req protocol.RequestSellItem
+		if err := json.Unmarshal(env.Payload, &req); err != nil {
+			return
+		}
+		handleRequestSellItem(req, playerID, gameManager)
 
 	default:
 		// Unknown message type
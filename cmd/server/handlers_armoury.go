@@ -0,0 +1,20 @@
+package main
+
+import (
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// handleRequestBuyItem buys an equipment card for the player's hero between quests.
+// The armoury broadcasts the purchase and the hero's new inventory.
+func handleRequestBuyItem(req protocol.RequestBuyItem, playerID string, gameManager *GameManager) {
+	if err := gameManager.GetArmoury().Buy(playerID, req.ItemID); err != nil {
+		gameManager.logger.Printf("Player %s failed to buy %s: %v", playerID, req.ItemID, err)
+	}
+}
+
+// handleRequestSellItem sells one of the player's hero's items back to the armoury between quests
+func handleRequestSellItem(req protocol.RequestSellItem, playerID string, gameManager *GameManager) {
+	if err := gameManager.GetArmoury().Sell(playerID, req.ItemID); err != nil {
+		gameManager.logger.Printf("Player %s failed to sell %s: %v", playerID, req.ItemID, err)
+	}
+}
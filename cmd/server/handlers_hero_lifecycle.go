@@ -0,0 +1,13 @@
+package main
+
+import (
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// handleRequestLootCorpse recovers the items a dead hero left where they fell.
+// The hero lifecycle system broadcasts what was taken.
+func handleRequestLootCorpse(req protocol.RequestLootCorpse, playerID string, gameManager *GameManager) {
+	if err := gameManager.GetHeroLifecycle().LootCorpse(playerID, req.HeroID); err != nil {
+		gameManager.logger.Printf("Player %s failed to loot %s: %v", playerID, req.HeroID, err)
+	}
+}
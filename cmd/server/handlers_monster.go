@@ -2,11 +2,10 @@ package main
 
 import (
 	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
-	"github.com/Ko-stant/dungeon-campaign-engine/internal/ws"
 )
 
 // handleRequestSelectMonster handles GM selecting a monster to control
-func handleRequestSelectMonster(req protocol.RequestSelectMonster, gameManager *GameManager, hub *ws.Hub, sequence *uint64) {
+func handleRequestSelectMonster(req protocol.RequestSelectMonster, gameManager *GameManager, broadcaster Broadcaster) {
 	turnStateManager := gameManager.GetTurnStateManager()
 	dynamicTurnOrder := gameManager.GetDynamicTurnOrder()
 
@@ -32,11 +31,11 @@ func handleRequestSelectMonster(req protocol.RequestSelectMonster, gameManager *
 	patch := protocol.MonsterSelectionChanged{
 		SelectedMonsterID: req.MonsterID,
 	}
-	broadcastEvent(hub, sequence, "MonsterSelectionChanged", patch)
+	broadcaster.BroadcastEvent("MonsterSelectionChanged", patch)
 }
 
 // handleRequestMoveMonster handles GM moving a monster
-func handleRequestMoveMonster(req protocol.RequestMoveMonster, gameManager *GameManager, hub *ws.Hub, sequence *uint64, state *GameState, furnitureSystem *FurnitureSystem, monsterSystem *MonsterSystem) {
+func handleRequestMoveMonster(req protocol.RequestMoveMonster, gameManager *GameManager, broadcaster Broadcaster, state *GameState, furnitureSystem *FurnitureSystem, monsterSystem *MonsterSystem) {
 	turnStateManager := gameManager.GetTurnStateManager()
 	dynamicTurnOrder := gameManager.GetDynamicTurnOrder()
 
@@ -60,6 +59,11 @@ func handleRequestMoveMonster(req protocol.RequestMoveMonster, gameManager *Game
 		return
 	}
 
+	if monster.IsAsleep {
+		gameManager.logger.Printf("Monster %s is asleep and cannot move", req.MonsterID)
+		return
+	}
+
 	// Validate movement
 	currentX, currentY := monster.Position.X, monster.Position.Y
 	targetX, targetY := req.ToX, req.ToY
@@ -146,17 +150,17 @@ func handleRequestMoveMonster(req protocol.RequestMoveMonster, gameManager *Game
 		req.MonsterID, currentX, currentY, targetX, targetY)
 
 	// Broadcast entity update
-	broadcastEvent(hub, sequence, "EntityUpdated", protocol.EntityUpdated{
+	broadcaster.BroadcastEvent("EntityUpdated", protocol.EntityUpdated{
 		ID:   monster.ID,
 		Tile: newPos,
 	})
 
 	// Broadcast updated monster turn state
-	broadcastMonsterTurnState(monsterState, hub, sequence)
+	broadcastMonsterTurnState(monsterState, broadcaster)
 }
 
 // handleRequestMonsterAttack handles GM initiating a monster attack
-func handleRequestMonsterAttack(req protocol.RequestMonsterAttack, gameManager *GameManager, hub *ws.Hub, sequence *uint64) {
+func handleRequestMonsterAttack(req protocol.RequestMonsterAttack, gameManager *GameManager, broadcaster Broadcaster) {
 	turnStateManager := gameManager.GetTurnStateManager()
 	dynamicTurnOrder := gameManager.GetDynamicTurnOrder()
 
@@ -166,11 +170,19 @@ func handleRequestMonsterAttack(req protocol.RequestMonsterAttack, gameManager *
 		return
 	}
 
-	// Get monster state
+	monster, err := gameManager.monsterSystem.GetMonsterByID(req.MonsterID)
+	if err != nil {
+		gameManager.logger.Printf("Cannot attack: %v", err)
+		return
+	}
+
+	// Start the monster's turn state on its first action this GM turn
 	monsterState := turnStateManager.GetMonsterTurnState(req.MonsterID)
 	if monsterState == nil {
-		gameManager.logger.Printf("Monster %s has no turn state", req.MonsterID)
-		return
+		if monsterState, err = startMonsterTurnState(gameManager, monster); err != nil {
+			gameManager.logger.Printf("Failed to start turn for monster %s: %v", req.MonsterID, err)
+			return
+		}
 	}
 
 	// Check if monster can take action
@@ -179,14 +191,18 @@ func handleRequestMonsterAttack(req protocol.RequestMonsterAttack, gameManager *
 		return
 	}
 
-	// TODO: Implement actual combat resolution
-	// For now, just record the action
+	// Attack dice are rolled now; damage lands once the hero rolls defense
+	attack, err := gameManager.monsterSystem.DeclareMonsterAttack(req.MonsterID, req.TargetID)
+	if err != nil {
+		gameManager.logger.Printf("Monster %s failed to attack %s: %v", req.MonsterID, req.TargetID, err)
+		return
+	}
 
 	action := MonsterActionRecord{
 		ActionType: "attack",
 		TargetID:   req.TargetID,
 		Success:    true,
-		Details:    map[string]interface{}{"type": "melee_attack"},
+		Details:    map[string]interface{}{"type": "melee_attack", "attackId": attack.ID, "skulls": countSkulls(attack.AttackRolls)},
 	}
 
 	if err := turnStateManager.RecordMonsterAction(req.MonsterID, action); err != nil {
@@ -199,14 +215,55 @@ func handleRequestMonsterAttack(req protocol.RequestMonsterAttack, gameManager *
 		gameManager.logger.Printf("Failed to record monster action taken in turn order: %v", err)
 	}
 
-	gameManager.logger.Printf("Monster %s attacked %s", req.MonsterID, req.TargetID)
+	gameManager.logger.Printf("Monster %s attacked %s (%s)", req.MonsterID, req.TargetID, attack.ID)
 
 	// Broadcast updated monster turn state
-	broadcastMonsterTurnState(monsterState, hub, sequence)
+	broadcastMonsterTurnState(monsterState, broadcaster)
+}
+
+// handleRequestRollDefense lets the attacked hero close the reaction window of a monster
+// attack and roll defense. The GM, who plays no hero, may roll it for them during the GM phase.
+func handleRequestRollDefense(req protocol.RequestRollDefense, playerID string, gameManager *GameManager) {
+	player := gameManager.turnManager.GetPlayer(playerID)
+	attack := gameManager.monsterSystem.GetPendingMonsterAttack()
+	byGM := player == nil && gameManager.GetDynamicTurnOrder().GetCurrentPhase() == GMPhase
+	if attack == nil || attack.ID != req.AttackID || (!byGM && (player == nil || attack.HeroID != player.EntityID)) {
+		gameManager.logger.Printf("Player %s cannot defend against %s", playerID, req.AttackID)
+		return
+	}
+
+	if _, err := gameManager.monsterSystem.ResolveMonsterAttack(req.AttackID); err != nil {
+		gameManager.logger.Printf("Player %s failed to defend against %s: %v", playerID, req.AttackID, err)
+	}
+}
+
+// handleRequestRevealMonster handles the GM placing a hidden monster on the board under the
+// DelayedMonsterReveal house rule
+func handleRequestRevealMonster(req protocol.RequestRevealMonster, gameManager *GameManager, broadcaster Broadcaster) {
+	dynamicTurnOrder := gameManager.GetDynamicTurnOrder()
+
+	// Only allow during GM phase
+	if dynamicTurnOrder.GetCurrentPhase() != GMPhase {
+		gameManager.logger.Printf("Cannot reveal monster outside GM phase")
+		return
+	}
+
+	monster, err := gameManager.monsterSystem.GetMonsterByID(req.MonsterID)
+	if err != nil || !monster.IsAlive {
+		gameManager.logger.Printf("Cannot reveal monster %s: not found", req.MonsterID)
+		return
+	}
+
+	if err := gameManager.RevealMonster(req.MonsterID); err != nil {
+		gameManager.logger.Printf("Failed to reveal monster %s: %v", req.MonsterID, err)
+		return
+	}
+
+	broadcaster.BroadcastEvent("MonstersVisible", protocol.MonstersVisible{Monsters: []protocol.MonsterLite{toMonsterLite(monster)}})
 }
 
 // handleRequestUseMonsterAbility handles GM using a monster special ability
-func handleRequestUseMonsterAbility(req protocol.RequestUseMonsterAbility, gameManager *GameManager, hub *ws.Hub, sequence *uint64) {
+func handleRequestUseMonsterAbility(req protocol.RequestUseMonsterAbility, gameManager *GameManager, broadcaster Broadcaster) {
 	turnStateManager := gameManager.GetTurnStateManager()
 	dynamicTurnOrder := gameManager.GetDynamicTurnOrder()
 
@@ -216,11 +273,24 @@ func handleRequestUseMonsterAbility(req protocol.RequestUseMonsterAbility, gameM
 		return
 	}
 
-	// Get monster state
+	// Dread spells are cast from the monster's quest spell list
+	if gameManager.monsterSystem.HasDreadSpell(req.MonsterID, req.AbilityID) {
+		handleDreadSpellCast(req, gameManager, broadcaster)
+		return
+	}
+
+	// Get monster state, starting it on the monster's first action this GM turn
 	monsterState := turnStateManager.GetMonsterTurnState(req.MonsterID)
 	if monsterState == nil {
-		gameManager.logger.Printf("Monster %s has no turn state", req.MonsterID)
-		return
+		monster, err := gameManager.monsterSystem.GetMonsterByID(req.MonsterID)
+		if err != nil {
+			gameManager.logger.Printf("Monster %s not found: %v", req.MonsterID, err)
+			return
+		}
+		if monsterState, err = startMonsterTurnState(gameManager, monster); err != nil {
+			gameManager.logger.Printf("Failed to start turn for monster %s: %v", req.MonsterID, err)
+			return
+		}
 	}
 
 	// Check if monster can use this ability
@@ -248,11 +318,91 @@ func handleRequestUseMonsterAbility(req protocol.RequestUseMonsterAbility, gameM
 	gameManager.logger.Printf("Monster %s used ability %s", req.MonsterID, req.AbilityID)
 
 	// Broadcast updated monster turn state
-	broadcastMonsterTurnState(monsterState, hub, sequence)
+	broadcastMonsterTurnState(monsterState, broadcaster)
+}
+
+// handleDreadSpellCast casts a monster's dread spell as its action for the turn
+func handleDreadSpellCast(req protocol.RequestUseMonsterAbility, gameManager *GameManager, broadcaster Broadcaster) {
+	turnStateManager := gameManager.GetTurnStateManager()
+	dynamicTurnOrder := gameManager.GetDynamicTurnOrder()
+
+	monster, err := gameManager.monsterSystem.GetMonsterByID(req.MonsterID)
+	if err != nil {
+		gameManager.logger.Printf("Cannot cast dread spell: %v", err)
+		return
+	}
+
+	// Start the monster's turn state on its first action this GM turn
+	monsterState := turnStateManager.GetMonsterTurnState(req.MonsterID)
+	if monsterState == nil {
+		if monsterState, err = startMonsterTurnState(gameManager, monster); err != nil {
+			gameManager.logger.Printf("Failed to start turn for monster %s: %v", req.MonsterID, err)
+			return
+		}
+	}
+
+	if canAct, reason := monsterState.CanTakeAction(); !canAct {
+		gameManager.logger.Printf("Monster %s cannot take action: %s", req.MonsterID, reason)
+		return
+	}
+
+	result, err := gameManager.monsterSystem.CastDreadSpell(req.MonsterID, req.AbilityID, req.TargetID)
+	if err != nil {
+		gameManager.logger.Printf("Monster %s failed to cast %s: %v", req.MonsterID, req.AbilityID, err)
+		return
+	}
+
+	action := MonsterActionRecord{
+		ActionType: "dread_spell",
+		TargetID:   req.TargetID,
+		Success:    true,
+		Details:    map[string]interface{}{"spellId": result.SpellID, "resisted": result.Resisted},
+	}
+	if err := turnStateManager.RecordMonsterAction(req.MonsterID, action); err != nil {
+		gameManager.logger.Printf("Failed to record monster action: %v", err)
+	}
+	if err := dynamicTurnOrder.SetMonsterActionTaken(req.MonsterID, true); err != nil {
+		gameManager.logger.Printf("Failed to record monster action taken in turn order: %v", err)
+	}
+
+	event := protocol.DreadSpellCast{
+		MonsterID: result.MonsterID,
+		SpellID:   result.SpellID,
+		SpellName: result.SpellName,
+		TargetID:  result.TargetID,
+		Effect:    result.Effect,
+		Value:     result.Value,
+		Resisted:  result.Resisted,
+		Summoned:  result.Summoned,
+		UsesLeft:  result.UsesLeft,
+	}
+	for _, player := range gameManager.turnManager.GetHeroPlayers() {
+		if player.EntityID == result.TargetID && player.Character != nil {
+			event.TargetBody = player.Character.CurrentBody
+			event.TargetMind = player.Character.CurrentMind
+		}
+	}
+	broadcaster.BroadcastEvent("DreadSpellCast", event)
+
+	broadcastMonsterTurnState(monsterState, broadcaster)
+}
+
+// startMonsterTurnState starts a monster's turn state with the abilities its monster card gives it
+func startMonsterTurnState(gameManager *GameManager, monster *Monster) (*MonsterTurnState, error) {
+	turnStateManager := gameManager.GetTurnStateManager()
+	if err := turnStateManager.StartMonsterTurn(monster.ID, monster.ID, monster.Position, monster.MovementRange, monster.AttackDice, monster.DefenseDice, monster.MaxBody, monster.Body); err != nil {
+		return nil, err
+	}
+	for _, ability := range gameManager.monsterSystem.GetMonsterAbilities(monster.ID) {
+		if err := turnStateManager.AddMonsterAbility(monster.ID, ability); err != nil {
+			return nil, err
+		}
+	}
+	return turnStateManager.GetMonsterTurnState(monster.ID), nil
 }
 
 // broadcastMonsterTurnState broadcasts a monster turn state update
-func broadcastMonsterTurnState(state *MonsterTurnState, hub *ws.Hub, sequence *uint64) {
+func broadcastMonsterTurnState(state *MonsterTurnState, broadcaster Broadcaster) {
 	// Convert special abilities to protocol format
 	abilities := make([]protocol.MonsterAbilityLite, 0, len(state.SpecialAbilities))
 	for _, ability := range state.SpecialAbilities {
@@ -296,7 +446,7 @@ func broadcastMonsterTurnState(state *MonsterTurnState, hub *ws.Hub, sequence *u
 		ActiveEffectsCount:   len(state.ActiveEffects),
 	}
 
-	broadcastEvent(hub, sequence, "MonsterTurnStateChanged", patch)
+	broadcaster.BroadcastEvent("MonsterTurnStateChanged", patch)
 }
 
 func absInt(x int) int {
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"time"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// handleRequestSaveSession saves the running game into a named slot
+func handleRequestSaveSession(req protocol.RequestSaveSession, playerID string, gameManager *GameManager, broadcaster Broadcaster) {
+	info, err := gameManager.SaveSession(playerID, req.Slot)
+	if err != nil {
+		gameManager.logger.Printf("Failed to save session to slot %q: %v", req.Slot, err)
+		return
+	}
+
+	broadcaster.BroadcastEvent("SessionSaved", protocol.SessionSaved{Session: sessionSlotLite(*info)})
+}
+
+// handleRequestLoadSession replaces the running game with a saved slot.
+// Clients are told via SessionLoaded and re-fetch their snapshot.
+func handleRequestLoadSession(req protocol.RequestLoadSession, playerID string, gameManager *GameManager) {
+	if _, err := gameManager.LoadSession(playerID, req.Slot); err != nil {
+		gameManager.logger.Printf("Failed to load session from slot %q: %v", req.Slot, err)
+	}
+}
+
+// handleRequestListSessions broadcasts the available save slots
+func handleRequestListSessions(gameManager *GameManager, broadcaster Broadcaster) {
+	slots, err := gameManager.ListSessions()
+	if err != nil {
+		gameManager.logger.Printf("Failed to list sessions: %v", err)
+		return
+	}
+
+	sessions := make([]protocol.SessionSlotLite, 0, len(slots))
+	for _, slot := range slots {
+		sessions = append(sessions, sessionSlotLite(slot))
+	}
+
+	broadcaster.BroadcastEvent("SessionList", protocol.SessionList{Sessions: sessions})
+}
+
+// handleRequestAdvanceQuest moves the campaign on to the next quest once the current one has ended.
+// Clients are told via QuestStarted and re-fetch their snapshot.
+func handleRequestAdvanceQuest(req protocol.RequestAdvanceQuest, playerID string, gameManager *GameManager, broadcaster Broadcaster) {
+	quest, err := gameManager.AdvanceCampaign(playerID, req.QuestID)
+	if err != nil {
+		gameManager.logger.Printf("Cannot advance the campaign: %v", err)
+		return
+	}
+
+	broadcaster.BroadcastEvent("QuestStarted", protocol.QuestStarted{
+		QuestID:  quest.ID,
+		Name:     quest.Name,
+		Campaign: *gameManager.GetCampaignForSnapshot(),
+	})
+}
+
+// sessionSlotLite converts save slot metadata to its protocol form
+func sessionSlotLite(info SaveSlotInfo) protocol.SessionSlotLite {
+	return protocol.SessionSlotLite{
+		Slot:        info.Slot,
+		QuestID:     info.QuestID,
+		CycleNumber: info.CycleNumber,
+		TurnPhase:   info.TurnPhase,
+		SavedAt:     info.SavedAt.Format(time.RFC3339),
+	}
+}
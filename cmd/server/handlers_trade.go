@@ -0,0 +1,48 @@
+package main
+
+import (
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// handleRequestProposeTrade offers a trade to an adjacent hero on the proposer's turn.
+// The trade system broadcasts the offer for the recipient to answer.
+func handleRequestProposeTrade(req protocol.RequestProposeTrade, playerID string, gameManager *GameManager) {
+	player := gameManager.turnManager.GetPlayer(playerID)
+	if player == nil {
+		gameManager.logger.Printf("Cannot propose trade: player %s not found", playerID)
+		return
+	}
+
+	request := InstantActionRequest{
+		PlayerID: playerID,
+		EntityID: player.EntityID,
+		Action:   TradeItemInstant,
+		Parameters: map[string]any{
+			"targetEntityId": req.TargetEntityID,
+			"itemIds":        req.GiveItems,
+			"gold":           req.GiveGold,
+			"requestItemIds": req.RequestItems,
+			"requestGold":    req.RequestGold,
+		},
+	}
+	if _, err := gameManager.ProcessInstantAction(request); err != nil {
+		gameManager.logger.Printf("Player %s failed to propose trade to %s: %v", playerID, req.TargetEntityID, err)
+	}
+}
+
+// handleRequestRespondToTrade accepts or declines a pending trade. Responding does not
+// need to be the player's turn, so a dying hero can take a potion passed to them.
+func handleRequestRespondToTrade(req protocol.RequestRespondToTrade, playerID string, gameManager *GameManager) {
+	tradeSystem := gameManager.GetTradeSystem()
+
+	if !req.Accept {
+		if err := tradeSystem.DeclineTrade(playerID, req.TradeID); err != nil {
+			gameManager.logger.Printf("Player %s failed to decline %s: %v", playerID, req.TradeID, err)
+		}
+		return
+	}
+
+	if _, err := tradeSystem.AcceptTrade(playerID, req.TradeID, req.Equip); err != nil {
+		gameManager.logger.Printf("Player %s failed to accept %s: %v", playerID, req.TradeID, err)
+	}
+}
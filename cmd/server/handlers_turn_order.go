@@ -2,11 +2,10 @@ package main
 
 import (
 	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
-	"github.com/Ko-stant/dungeon-campaign-engine/internal/ws"
 )
 
 // handleRequestSelectStartingPosition handles a player selecting their starting position during quest setup
-func handleRequestSelectStartingPosition(req protocol.RequestSelectStartingPosition, playerID string, gameManager *GameManager, hub *ws.Hub, sequence *uint64) {
+func handleRequestSelectStartingPosition(req protocol.RequestSelectStartingPosition, playerID string, gameManager *GameManager, broadcaster Broadcaster) {
 	dynamicTurnOrder := gameManager.GetDynamicTurnOrder()
 
 	// Create position from request
@@ -21,11 +20,22 @@ func handleRequestSelectStartingPosition(req protocol.RequestSelectStartingPosit
 	gameManager.logger.Printf("Player %s selected starting position (%d, %d)", playerID, req.X, req.Y)
 
 	// Broadcast quest setup state update
-	broadcastQuestSetupState(dynamicTurnOrder, hub, sequence)
+	broadcastQuestSetupState(dynamicTurnOrder, broadcaster)
+}
+
+// handleRequestChooseSpellSchool handles a caster choosing a spell school during quest setup
+func handleRequestChooseSpellSchool(req protocol.RequestChooseSpellSchool, playerID string, gameManager *GameManager, broadcaster Broadcaster) {
+	if err := gameManager.ChooseSpellSchool(playerID, req.Element); err != nil {
+		gameManager.logger.Printf("Failed to choose spell school %s for player %s: %v", req.Element, playerID, err)
+		return
+	}
+
+	broadcastSpellSelectionState(gameManager.GetDynamicTurnOrder(), broadcaster)
+	startQuestIfReady(gameManager, broadcaster)
 }
 
 // handleRequestQuestSetupToggleReady handles a player toggling ready status during quest setup
-func handleRequestQuestSetupToggleReady(playerID string, ready bool, gameManager *GameManager, hub *ws.Hub, sequence *uint64) {
+func handleRequestQuestSetupToggleReady(playerID string, ready bool, gameManager *GameManager, broadcaster Broadcaster) {
 	dynamicTurnOrder := gameManager.GetDynamicTurnOrder()
 
 	// Set player ready status
@@ -37,88 +47,96 @@ func handleRequestQuestSetupToggleReady(playerID string, ready bool, gameManager
 	gameManager.logger.Printf("Player %s set ready status: %t", playerID, ready)
 
 	// Broadcast quest setup state update
-	broadcastQuestSetupState(dynamicTurnOrder, hub, sequence)
+	broadcastQuestSetupState(dynamicTurnOrder, broadcaster)
 
-	// Check if all players are ready and can start quest
-	if dynamicTurnOrder.AreAllPlayersReady() {
-		// Spawn hero entities at selected positions FIRST
-		if err := spawnHeroesAtStartingPositions(gameManager, hub, sequence); err != nil {
-			gameManager.logger.Printf("Failed to spawn heroes at starting positions: %v", err)
-			return
-		}
+	startQuestIfReady(gameManager, broadcaster)
+}
 
-		// Count hero players (exclude GM)
-		turnManager := gameManager.turnManager
-		heroPlayers := turnManager.GetHeroPlayers()
-		heroCount := len(heroPlayers)
+// startQuestIfReady spawns the heroes and leaves quest setup once every player is ready
+// and the casters have chosen their spell schools
+func startQuestIfReady(gameManager *GameManager, broadcaster Broadcaster) {
+	dynamicTurnOrder := gameManager.GetDynamicTurnOrder()
+	if !dynamicTurnOrder.IsQuestSetup() || !dynamicTurnOrder.AreAllPlayersReady() || !dynamicTurnOrder.IsSpellSelectionComplete() {
+		return
+	}
+
+	// Spawn hero entities at selected positions FIRST
+	if err := spawnHeroesAtStartingPositions(gameManager, broadcaster); err != nil {
+		gameManager.logger.Printf("Failed to spawn heroes at starting positions: %v", err)
+		return
+	}
 
-		gameManager.logger.Printf("All players ready, found %d hero player(s)", heroCount)
+	// Count hero players (exclude GM)
+	turnManager := gameManager.turnManager
+	heroPlayers := turnManager.GetHeroPlayers()
+	heroCount := len(heroPlayers)
 
-		if heroCount == 1 {
-			// Single hero: auto-select and start their turn immediately
-			gameManager.logger.Printf("Single hero detected, auto-starting their turn")
+	gameManager.logger.Printf("All players ready, found %d hero player(s)", heroCount)
 
-			// Transition from quest setup to hero election (required for state machine)
-			if err := dynamicTurnOrder.StartQuestAfterSetup(); err != nil {
-				gameManager.logger.Printf("Failed to start quest after setup: %v", err)
-				return
-			}
+	if heroCount == 1 {
+		// Single hero: auto-select and start their turn immediately
+		gameManager.logger.Printf("Single hero detected, auto-starting their turn")
 
-			// Get the single hero player
-			singlePlayerID := heroPlayers[0].ID
+		// Transition from quest setup to hero election (required for state machine)
+		if err := dynamicTurnOrder.StartQuestAfterSetup(); err != nil {
+			gameManager.logger.Printf("Failed to start quest after setup: %v", err)
+			return
+		}
 
-			// Auto-elect the single player
-			if err := dynamicTurnOrder.ElectSelfAsNextPlayer(singlePlayerID); err != nil {
-				gameManager.logger.Printf("Failed to auto-elect single hero: %v", err)
-				return
-			}
+		// Get the single hero player
+		singlePlayerID := heroPlayers[0].ID
 
-			// Immediately confirm and start their turn
-			playerID, err := dynamicTurnOrder.ConfirmElectionAndStartHeroTurn()
-			if err != nil {
-				gameManager.logger.Printf("Failed to start single hero turn: %v", err)
-				return
-			}
+		// Auto-elect the single player
+		if err := dynamicTurnOrder.ElectSelfAsNextPlayer(singlePlayerID); err != nil {
+			gameManager.logger.Printf("Failed to auto-elect single hero: %v", err)
+			return
+		}
 
-			// Start hero turn state
-			player := turnManager.GetPlayer(playerID)
-			if player != nil {
-				turnStateManager := gameManager.GetTurnStateManager()
-				gameState := gameManager.GetGameState()
-				gameState.Lock.Lock()
-				heroPos := gameState.Entities[player.EntityID]
-				gameState.Lock.Unlock()
-
-				if err := turnStateManager.StartHeroTurn(player.EntityID, playerID, heroPos); err != nil {
-					gameManager.logger.Printf("Failed to start hero turn state: %v", err)
-					return
-				}
-
-				// Broadcast hero turn state
-				heroState := turnStateManager.GetHeroTurnState(player.EntityID)
-				if heroState != nil {
-					broadcastHeroTurnState(heroState, hub, sequence)
-				}
-			}
+		// Immediately confirm and start their turn
+		playerID, err := dynamicTurnOrder.ConfirmElectionAndStartHeroTurn()
+		if err != nil {
+			gameManager.logger.Printf("Failed to start single hero turn: %v", err)
+			return
+		}
 
-			gameManager.logger.Printf("Single hero %s turn started automatically", singlePlayerID)
-		} else {
-			// Multiple heroes: transition to election phase
-			gameManager.logger.Printf("Multiple heroes detected, transitioning to hero election")
+		// Start hero turn state
+		player := turnManager.GetPlayer(playerID)
+		if player != nil {
+			turnStateManager := gameManager.GetTurnStateManager()
+			gameState := gameManager.GetGameState()
+			gameState.Lock.Lock()
+			heroPos := gameState.Entities[player.EntityID]
+			gameState.Lock.Unlock()
 
-			if err := dynamicTurnOrder.StartQuestAfterSetup(); err != nil {
-				gameManager.logger.Printf("Failed to start quest after setup: %v", err)
+			if err := turnStateManager.StartHeroTurn(player.EntityID, playerID, heroPos); err != nil {
+				gameManager.logger.Printf("Failed to start hero turn state: %v", err)
 				return
 			}
+
+			// Broadcast hero turn state
+			heroState := turnStateManager.GetHeroTurnState(player.EntityID)
+			if heroState != nil {
+				broadcastHeroTurnState(heroState, broadcaster)
+			}
 		}
 
-		// Broadcast turn phase change
-		broadcastTurnPhaseState(dynamicTurnOrder, hub, sequence)
+		gameManager.logger.Printf("Single hero %s turn started automatically", singlePlayerID)
+	} else {
+		// Multiple heroes: transition to election phase
+		gameManager.logger.Printf("Multiple heroes detected, transitioning to hero election")
+
+		if err := dynamicTurnOrder.StartQuestAfterSetup(); err != nil {
+			gameManager.logger.Printf("Failed to start quest after setup: %v", err)
+			return
+		}
 	}
+
+	// Broadcast turn phase change
+	broadcastTurnPhaseState(dynamicTurnOrder, broadcaster)
 }
 
 // handleRequestElectSelfAsNextPlayer handles a player electing themselves to go next
-func handleRequestElectSelfAsNextPlayer(playerID string, gameManager *GameManager, hub *ws.Hub, sequence *uint64) {
+func handleRequestElectSelfAsNextPlayer(playerID string, gameManager *GameManager, broadcaster Broadcaster) {
 	dynamicTurnOrder := gameManager.GetDynamicTurnOrder()
 	turnStateManager := gameManager.GetTurnStateManager()
 
@@ -153,18 +171,18 @@ func handleRequestElectSelfAsNextPlayer(playerID string, gameManager *GameManage
 		// Broadcast hero turn state
 		heroState := turnStateManager.GetHeroTurnState(player.EntityID)
 		if heroState != nil {
-			broadcastHeroTurnState(heroState, hub, sequence)
+			broadcastHeroTurnState(heroState, broadcaster)
 		}
 	}
 
 	gameManager.logger.Printf("Auto-started turn for player %s after election", confirmedPlayerID)
 
 	// Broadcast turn phase update
-	broadcastTurnPhaseState(dynamicTurnOrder, hub, sequence)
+	broadcastTurnPhaseState(dynamicTurnOrder, broadcaster)
 }
 
 // handleRequestCancelPlayerElection handles a player canceling their election
-func handleRequestCancelPlayerElection(playerID string, gameManager *GameManager, hub *ws.Hub, sequence *uint64) {
+func handleRequestCancelPlayerElection(playerID string, gameManager *GameManager, broadcaster Broadcaster) {
 	dynamicTurnOrder := gameManager.GetDynamicTurnOrder()
 	turnStateManager := gameManager.GetTurnStateManager()
 	turnManager := gameManager.turnManager
@@ -203,7 +221,7 @@ func handleRequestCancelPlayerElection(playerID string, gameManager *GameManager
 	var lastHeroID string
 
 	for _, heroPlayer := range heroPlayers {
-		if !heroesActed[heroPlayer.ID] {
+		if !heroesActed[heroPlayer.ID] && !dynamicTurnOrder.IsHeroOutOfPlay(heroPlayer.ID) {
 			heroesRemaining++
 			lastHeroID = heroPlayer.ID
 		}
@@ -242,7 +260,7 @@ func handleRequestCancelPlayerElection(playerID string, gameManager *GameManager
 			// Broadcast hero turn state
 			heroState := turnStateManager.GetHeroTurnState(lastPlayer.EntityID)
 			if heroState != nil {
-				broadcastHeroTurnState(heroState, hub, sequence)
+				broadcastHeroTurnState(heroState, broadcaster)
 			}
 		}
 
@@ -250,11 +268,11 @@ func handleRequestCancelPlayerElection(playerID string, gameManager *GameManager
 	}
 
 	// Broadcast turn phase update
-	broadcastTurnPhaseState(dynamicTurnOrder, hub, sequence)
+	broadcastTurnPhaseState(dynamicTurnOrder, broadcaster)
 }
 
 // handleRequestConfirmElectionAndStartTurn handles confirming the election and starting the elected hero's turn
-func handleRequestConfirmElectionAndStartTurn(gameManager *GameManager, hub *ws.Hub, sequence *uint64) {
+func handleRequestConfirmElectionAndStartTurn(gameManager *GameManager, broadcaster Broadcaster) {
 	dynamicTurnOrder := gameManager.GetDynamicTurnOrder()
 	turnStateManager := gameManager.GetTurnStateManager()
 
@@ -289,17 +307,17 @@ func handleRequestConfirmElectionAndStartTurn(gameManager *GameManager, hub *ws.
 	}
 
 	// Broadcast turn phase update
-	broadcastTurnPhaseState(dynamicTurnOrder, hub, sequence)
+	broadcastTurnPhaseState(dynamicTurnOrder, broadcaster)
 
 	// Broadcast hero turn state update
 	heroState := turnStateManager.GetHeroTurnState(heroID)
 	if heroState != nil {
-		broadcastHeroTurnState(heroState, hub, sequence)
+		broadcastHeroTurnState(heroState, broadcaster)
 	}
 }
 
 // handleRequestCompleteHeroTurn handles a hero completing their turn
-func handleRequestCompleteHeroTurn(playerID string, gameManager *GameManager, hub *ws.Hub, sequence *uint64) {
+func handleRequestCompleteHeroTurn(playerID string, gameManager *GameManager, broadcaster Broadcaster) {
 	dynamicTurnOrder := gameManager.GetDynamicTurnOrder()
 	turnManager := gameManager.turnManager
 	turnStateManager := gameManager.GetTurnStateManager()
@@ -311,6 +329,12 @@ func handleRequestCompleteHeroTurn(playerID string, gameManager *GameManager, hu
 
 	gameManager.logger.Printf("Player %s completed their turn", playerID)
 
+	// A hero put to sleep by a dread spell wakes after losing this turn
+	if player := turnManager.GetPlayer(playerID); player != nil && player.Character != nil && player.Character.IsAsleep {
+		player.Character.IsAsleep = false
+		gameManager.logger.Printf("%s wakes up after sleeping through their turn", player.EntityID)
+	}
+
 	// Check if only one hero remains - if so, auto-elect them
 	heroPlayers := turnManager.GetHeroPlayers()
 	heroesActed := dynamicTurnOrder.GetHeroesActedThisCycle()
@@ -318,7 +342,7 @@ func handleRequestCompleteHeroTurn(playerID string, gameManager *GameManager, hu
 	var lastHeroID string
 
 	for _, heroPlayer := range heroPlayers {
-		if !heroesActed[heroPlayer.ID] {
+		if !heroesActed[heroPlayer.ID] && !dynamicTurnOrder.IsHeroOutOfPlay(heroPlayer.ID) {
 			heroesRemaining++
 			lastHeroID = heroPlayer.ID
 		}
@@ -357,7 +381,7 @@ func handleRequestCompleteHeroTurn(playerID string, gameManager *GameManager, hu
 			// Broadcast hero turn state
 			heroState := turnStateManager.GetHeroTurnState(lastPlayer.EntityID)
 			if heroState != nil {
-				broadcastHeroTurnState(heroState, hub, sequence)
+				broadcastHeroTurnState(heroState, broadcaster)
 			}
 		}
 
@@ -365,14 +389,31 @@ func handleRequestCompleteHeroTurn(playerID string, gameManager *GameManager, hu
 	}
 
 	// Broadcast turn phase update
-	broadcastTurnPhaseState(dynamicTurnOrder, hub, sequence)
+	broadcastTurnPhaseState(dynamicTurnOrder, broadcaster)
 }
 
 // handleRequestCompleteGMTurn handles the GM completing their turn
-func handleRequestCompleteGMTurn(gameManager *GameManager, hub *ws.Hub, sequence *uint64) {
+func handleRequestCompleteGMTurn(gameManager *GameManager, broadcaster Broadcaster) {
 	dynamicTurnOrder := gameManager.GetDynamicTurnOrder()
 	turnStateManager := gameManager.GetTurnStateManager()
 
+	// An attack still waiting for its defense roll is rolled for the hero before the turn ends
+	if attack, err := gameManager.monsterSystem.ResolvePendingMonsterAttack(); err != nil {
+		gameManager.logger.Printf("Failed to resolve pending monster attack: %v", err)
+	} else if attack != nil {
+		gameManager.logger.Printf("Resolved unanswered attack %s on %s at the end of the GM turn", attack.ID, attack.HeroID)
+	}
+
+	// Dying heroes not rescued by the end of this GM phase die; if none are left the quest is lost
+	if dynamicTurnOrder.GetCurrentPhase() == GMPhase {
+		if outcome := gameManager.GetHeroLifecycle().ResolveGMPhaseEnd(dynamicTurnOrder.GetCycleNumber()); outcome != nil {
+			gameManager.logger.Printf("Quest over: %s (%s)", outcome.Result, outcome.Reason)
+			broadcastTurnPhaseState(dynamicTurnOrder, broadcaster)
+			gameManager.SaveCycleSnapshot()
+			return
+		}
+	}
+
 	if err := dynamicTurnOrder.CompleteGMTurn(); err != nil {
 		gameManager.logger.Printf("Failed to complete GM turn: %v", err)
 		return
@@ -387,16 +428,19 @@ func handleRequestCompleteGMTurn(gameManager *GameManager, hub *ws.Hub, sequence
 	}
 
 	// Broadcast turn phase update
-	broadcastTurnPhaseState(dynamicTurnOrder, hub, sequence)
+	broadcastTurnPhaseState(dynamicTurnOrder, broadcaster)
 
 	// Broadcast monster states cleared
-	broadcastEvent(hub, sequence, "AllMonsterStatesSync", protocol.AllMonsterStatesSync{
+	broadcaster.BroadcastEvent("AllMonsterStatesSync", protocol.AllMonsterStatesSync{
 		MonsterStates: make(map[string]*protocol.MonsterTurnStateChanged),
 	})
+
+	// Turn boundary: persist a snapshot so recovery only replays this cycle's events
+	gameManager.SaveCycleSnapshot()
 }
 
 // broadcastTurnPhaseState broadcasts the current turn phase state to all clients
-func broadcastTurnPhaseState(dynamicTurnOrder *DynamicTurnOrderManager, hub *ws.Hub, sequence *uint64) {
+func broadcastTurnPhaseState(dynamicTurnOrder *DynamicTurnOrderManager, broadcaster Broadcaster) {
 	heroesActed := dynamicTurnOrder.GetHeroesActedThisCycle()
 	heroesActedIDs := make([]string, 0, len(heroesActed))
 	for playerID := range heroesActed {
@@ -415,11 +459,11 @@ func broadcastTurnPhaseState(dynamicTurnOrder *DynamicTurnOrderManager, hub *ws.
 		EligibleHeroIDs:    eligibleHeroIDs,
 	}
 
-	broadcastEvent(hub, sequence, "TurnPhaseChanged", patch)
+	broadcaster.BroadcastEvent("TurnPhaseChanged", patch)
 }
 
 // broadcastQuestSetupState broadcasts the quest setup state to all clients
-func broadcastQuestSetupState(dynamicTurnOrder *DynamicTurnOrderManager, hub *ws.Hub, sequence *uint64) {
+func broadcastQuestSetupState(dynamicTurnOrder *DynamicTurnOrderManager, broadcaster Broadcaster) {
 	// Get players ready map
 	playersReady := dynamicTurnOrder.GetPlayersReady()
 	if playersReady == nil {
@@ -442,16 +486,31 @@ func broadcastQuestSetupState(dynamicTurnOrder *DynamicTurnOrderManager, hub *ws
 		AllPlayersReady:      dynamicTurnOrder.AreAllPlayersReady(),
 	}
 
-	broadcastEvent(hub, sequence, "QuestSetupStateChanged", patch)
+	broadcaster.BroadcastEvent("QuestSetupStateChanged", patch)
+}
+
+// broadcastSpellSelectionState broadcasts the spell school draft
+func broadcastSpellSelectionState(dynamicTurnOrder *DynamicTurnOrderManager, broadcaster Broadcaster) {
+	broadcaster.BroadcastEvent("SpellSelectionStateChanged", spellSelectionState(dynamicTurnOrder))
+}
+
+// spellSelectionState describes the spell school draft
+func spellSelectionState(dynamicTurnOrder *DynamicTurnOrderManager) protocol.SpellSelectionStateChanged {
+	return protocol.SpellSelectionStateChanged{
+		Elements:     dynamicTurnOrder.GetSpellElements(),
+		Schools:      dynamicTurnOrder.GetSpellSchools(),
+		NextPlayerID: dynamicTurnOrder.GetNextSpellPicker(),
+		Complete:     dynamicTurnOrder.IsSpellSelectionComplete(),
+	}
 }
 
 // broadcastHeroTurnState broadcasts a hero turn state update
-func broadcastHeroTurnState(state *HeroTurnState, hub *ws.Hub, sequence *uint64) {
+func broadcastHeroTurnState(state *HeroTurnState, broadcaster Broadcaster) {
 	// TODO: Add ActiveEffects and LocationSearches to TurnStateChanged protocol
 	// Currently these fields are tracked in HeroTurnState but not transmitted to clients
 
 	// Broadcast TurnStateChanged with hero turn state details
-	broadcastEvent(hub, sequence, "TurnStateChanged", protocol.TurnStateChanged{
+	broadcaster.BroadcastEvent("TurnStateChanged", protocol.TurnStateChanged{
 		HeroID:              state.HeroID,
 		PlayerID:            state.PlayerID,
 		TurnNumber:          state.TurnNumber,
@@ -478,7 +537,7 @@ func boolToInt(b bool) int {
 }
 
 // spawnHeroesAtStartingPositions spawns hero entities at their selected starting positions
-func spawnHeroesAtStartingPositions(gameManager *GameManager, hub *ws.Hub, sequence *uint64) error {
+func spawnHeroesAtStartingPositions(gameManager *GameManager, broadcaster Broadcaster) error {
 	dynamicTurnOrder := gameManager.GetDynamicTurnOrder()
 	gameState := gameManager.GetGameState()
 	turnManager := gameManager.turnManager
@@ -510,7 +569,7 @@ func spawnHeroesAtStartingPositions(gameManager *GameManager, hub *ws.Hub, seque
 		gameManager.logger.Printf("Spawned hero %s (player %s) at (%d, %d)", player.EntityID, playerID, pos.X, pos.Y)
 
 		// Broadcast entity update to notify clients
-		broadcastEvent(hub, sequence, "EntityUpdated", protocol.EntityUpdated{
+		broadcaster.BroadcastEvent("EntityUpdated", protocol.EntityUpdated{
 			ID:   player.EntityID,
 			Tile: tileAddr,
 		})
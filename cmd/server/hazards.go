@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// Hazard special mechanics (TreasureCard.SpecialMechanic)
+const (
+	HazardMechanicLoseGold = "lose_gold" // The hero loses the card's Value in gold, or all their gold when Value is 0
+)
+
+// HazardOutcome describes what a hazard treasure card did to the hero who drew it
+type HazardOutcome struct {
+	CardID   string `json:"cardId"`
+	Name     string `json:"name"`
+	HeroID   string `json:"heroId"`
+	Damage   int    `json:"damage"`
+	BodyLeft int    `json:"bodyLeft"`
+	EndTurn  bool   `json:"endTurn"`
+	Mechanic string `json:"mechanic,omitempty"`
+	GoldLost int    `json:"goldLost,omitempty"`
+	Message  string `json:"message"`
+}
+
+// HazardMechanic resolves a hazard card's special mechanic for the hero who drew it
+type HazardMechanic func(has *HeroActionSystem, hero *Player, card *TreasureCard, outcome *HazardOutcome) error
+
+// hazardMechanics holds the special mechanics hazard cards can name
+var hazardMechanics = map[string]HazardMechanic{
+	HazardMechanicLoseGold: loseGoldMechanic,
+}
+
+// RegisterHazardMechanic adds or replaces the special mechanic run for a hazard card
+func RegisterHazardMechanic(name string, mechanic HazardMechanic) {
+	hazardMechanics[name] = mechanic
+}
+
+// applyHazard resolves a hazard card drawn while searching for treasure: the hero takes its
+// damage, then its special mechanic runs, then their turn ends if the card says so
+func (has *HeroActionSystem) applyHazard(hero *Player, card *TreasureCard) (*HazardOutcome, error) {
+	if hero == nil || hero.Character == nil {
+		return nil, fmt.Errorf("no hero to apply %s to", card.Name)
+	}
+	heroID := hero.EntityID
+	character := hero.Character
+
+	outcome := &HazardOutcome{
+		CardID:   card.ID,
+		Name:     card.Name,
+		HeroID:   heroID,
+		Damage:   card.Damage,
+		EndTurn:  card.EndTurn,
+		Mechanic: card.SpecialMechanic,
+		Message:  card.Description,
+	}
+	if card.Damage > 0 {
+		character.TakeDamage(card.Damage)
+	}
+
+	if card.SpecialMechanic != "" {
+		if mechanic, exists := hazardMechanics[card.SpecialMechanic]; exists {
+			if err := mechanic(has, hero, card, outcome); err != nil {
+				has.logger.Printf("Warning: Hazard %s mechanic %s failed: %v", card.ID, card.SpecialMechanic, err)
+			}
+		} else {
+			has.logger.Printf("Warning: Hazard %s has unknown special mechanic %s", card.ID, card.SpecialMechanic)
+		}
+	}
+
+	if card.EndTurn {
+		has.turnManager.ForfeitMovement(fmt.Sprintf("%s hazard", card.Name))
+	}
+	outcome.BodyLeft = character.CurrentBody
+
+	has.logger.Printf("Hero %s triggered hazard %s: %d damage (%d/%d body), gold lost: %d, end turn: %v",
+		heroID, card.Name, outcome.Damage, character.CurrentBody, character.BaseStats.BodyPoints, outcome.GoldLost, outcome.EndTurn)
+
+	has.broadcaster.BroadcastEvent("HazardTriggered", protocol.HazardTriggered{
+		CardID:   outcome.CardID,
+		Name:     outcome.Name,
+		HeroID:   heroID,
+		Damage:   outcome.Damage,
+		BodyLeft: outcome.BodyLeft,
+		EndTurn:  outcome.EndTurn,
+		Mechanic: outcome.Mechanic,
+		GoldLost: outcome.GoldLost,
+	})
+
+	if card.Damage > 0 {
+		has.checkHeroLife(heroID)
+	}
+	return outcome, nil
+}
+
+// loseGoldMechanic takes gold from the hero who drew the card
+func loseGoldMechanic(has *HeroActionSystem, hero *Player, card *TreasureCard, outcome *HazardOutcome) error {
+	if has.inventoryManager == nil {
+		return fmt.Errorf("inventory manager not initialized")
+	}
+	lost, err := has.inventoryManager.RemoveGold(hero.EntityID, card.Value)
+	if err != nil {
+		return err
+	}
+	outcome.GoldLost = lost
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestHazard_DamageEndsTurn(t *testing.T) {
+	has := createTestItemUser(t, nil, nil)
+	barbarian := has.turnManager.GetPlayer("player-1")
+	pit := &TreasureCard{ID: "hazard_pit", Name: "Pit", Type: "hazard", Damage: 1, EndTurn: true}
+	if _, err := has.turnManager.RollMovementDice(); err != nil || !has.turnManager.CanMove() {
+		t.Fatalf("Expected the hero to have movement before the hazard, got: %v", err)
+	}
+
+	outcome, err := has.applyHazard(barbarian, pit)
+	if err != nil {
+		t.Fatalf("Expected the pit to be resolved, got: %v", err)
+	}
+	if outcome.Damage != 1 || outcome.BodyLeft != 7 || barbarian.Character.CurrentBody != 7 {
+		t.Errorf("Expected the barbarian to fall to 7 body, got %+v", outcome)
+	}
+	if !outcome.EndTurn || has.turnManager.CanMove() || has.turnManager.GetTurnState().MovementLeft != 0 {
+		t.Error("Expected the pit to end the hero's turn")
+	}
+}
+
+func TestHazard_LoseGoldMechanic(t *testing.T) {
+	has := createTestItemUser(t, nil, nil)
+	has.inventoryManager.AddGold("hero-1", 10)
+	barbarian := has.turnManager.GetPlayer("player-1")
+
+	outcome, err := has.applyHazard(barbarian, &TreasureCard{ID: "cut_purse", Name: "Cut Purse", Type: "hazard", SpecialMechanic: HazardMechanicLoseGold, Value: 3})
+	if err != nil || outcome.GoldLost != 3 {
+		t.Fatalf("Expected 3 gold lost, got %+v (%v)", outcome, err)
+	}
+
+	outcome, _ = has.applyHazard(barbarian, &TreasureCard{ID: "thief", Name: "Thief", Type: "hazard", SpecialMechanic: HazardMechanicLoseGold})
+	inventory, _ := has.inventoryManager.GetInventory("hero-1")
+	if outcome.GoldLost != 7 || inventory.Gold != 0 {
+		t.Errorf("Expected the thief to take the remaining 7 gold, got %d lost and %d left", outcome.GoldLost, inventory.Gold)
+	}
+	if barbarian.Character.CurrentBody != barbarian.Character.BaseStats.BodyPoints {
+		t.Error("Expected gold-only hazards to leave body points alone")
+	}
+}
+
+func TestHazard_RegisteredMechanicAndFatalDamage(t *testing.T) {
+	RegisterHazardMechanic("drop_everything", func(has *HeroActionSystem, hero *Player, card *TreasureCard, outcome *HazardOutcome) error {
+		outcome.Message = "the hero stumbles"
+		return nil
+	})
+	t.Cleanup(func() { delete(hazardMechanics, "drop_everything") })
+
+	has, lifecycle := createTestLifecycle(t, HeroPhaseActive)
+	barbarian := has.turnManager.GetPlayer("player-1")
+
+	outcome, err := has.applyHazard(barbarian, &TreasureCard{ID: "rockfall", Name: "Rockfall", Type: "hazard", Damage: 8, SpecialMechanic: "drop_everything"})
+	if err != nil {
+		t.Fatalf("Expected the rockfall to be resolved, got: %v", err)
+	}
+	if outcome.Message != "the hero stumbles" {
+		t.Errorf("Expected the registered mechanic to run, got %q", outcome.Message)
+	}
+	if lifecycle.GetCondition("hero-1") != HeroConditionDying {
+		t.Errorf("Expected the hero knocked to 0 body to be dying, got %q", lifecycle.GetCondition("hero-1"))
+	}
+}
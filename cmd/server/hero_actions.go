@@ -31,6 +31,7 @@ const (
 	TradeItemInstant    InstantAction = "trade_item"
 	PassTurnInstant     InstantAction = "pass_turn"
 	RollMovementInstant InstantAction = "roll_movement"
+	PickUpItemInstant   InstantAction = "pick_up_item"
 )
 
 // MovementAction represents the special movement action (once per turn, before or after main action)
@@ -67,21 +68,28 @@ type MovementRequest struct {
 
 // ActionResult contains the results of performing an action
 type ActionResult struct {
-	Success        bool          `json:"success"`
-	Action         HeroAction    `json:"action"`
-	PlayerID       string        `json:"playerId"`
-	EntityID       string        `json:"entityId"`
-	AttackRolls    []DiceRoll    `json:"attackRolls,omitempty"`   // Hero's attack dice
-	DefenseRolls   []DiceRoll    `json:"defenseRolls,omitempty"`  // Monster's defense dice
-	SearchRolls    []DiceRoll    `json:"searchRolls,omitempty"`   // Search action dice
-	MovementRolls  []DiceRoll    `json:"movementRolls,omitempty"` // Movement dice rolls
-	Damage         int           `json:"damage,omitempty"`
-	ItemsFound     []Item        `json:"itemsFound,omitempty"`
-	SecretRevealed *SecretDoor   `json:"secretRevealed,omitempty"`
-	SpellEffect    *SpellEffect  `json:"spellEffect,omitempty"`
-	Message        string        `json:"message"`
-	StateChanges   []StateChange `json:"stateChanges,omitempty"`
-	Timestamp      time.Time     `json:"timestamp"`
+	Success          bool                    `json:"success"`
+	Action           HeroAction              `json:"action"`
+	PlayerID         string                  `json:"playerId"`
+	EntityID         string                  `json:"entityId"`
+	AttackRolls      []DiceRoll              `json:"attackRolls,omitempty"`   // Hero's attack dice
+	DefenseRolls     []DiceRoll              `json:"defenseRolls,omitempty"`  // Monster's defense dice
+	SearchRolls      []DiceRoll              `json:"searchRolls,omitempty"`   // Search action dice
+	MovementRolls    []DiceRoll              `json:"movementRolls,omitempty"` // Movement dice rolls
+	Damage           int                     `json:"damage,omitempty"`
+	ItemsFound       []Item                  `json:"itemsFound,omitempty"`
+	TrapsFound       []string                `json:"trapsFound,omitempty"`
+	TrapsSprung      []*TrapResult           `json:"trapsSprung,omitempty"`
+	SecretRevealed   *SecretDoor             `json:"secretRevealed,omitempty"`
+	SpellEffect      *SpellEffect            `json:"spellEffect,omitempty"`
+	ItemUsed         *ItemUseResult          `json:"itemUsed,omitempty"`
+	TradeOffer       *TradeOffer             `json:"tradeOffer,omitempty"`
+	WanderingMonster *WanderingMonsterResult `json:"wanderingMonster,omitempty"`
+	Hazard           *HazardOutcome          `json:"hazard,omitempty"`
+	ThrownItem       string                  `json:"thrownItem,omitempty"` // Weapon left on the target's square
+	Message          string                  `json:"message"`
+	StateChanges     []StateChange           `json:"stateChanges,omitempty"`
+	Timestamp        time.Time               `json:"timestamp"`
 }
 
 // DiceRoll represents a single dice roll
@@ -191,8 +199,14 @@ type HeroActionSystem struct {
 	logger            Logger
 	debugSystem       *DebugSystem
 	movementValidator MovementValidator
+	visibility        VisibilityCalculator
 	monsterSystem     *MonsterSystem
+	trapSystem        *TrapSystem
+	tradeSystem       *TradeSystem
+	heroLifecycle     *HeroLifecycleSystem
 	quest             *geometry.QuestDefinition
+	rules             *RuleSet
+	questTriggers     *QuestTriggerSystem
 }
 
 // NewHeroActionSystem creates a new hero action system
@@ -205,6 +219,7 @@ func NewHeroActionSystem(gameState *GameState, turnManager *TurnManager, broadca
 		logger:            logger,
 		debugSystem:       debugSystem,
 		movementValidator: NewMovementValidator(logger), // Default validator without systems
+		visibility:        NewVisibilityCalculator(logger),
 	}
 }
 
@@ -218,6 +233,21 @@ func (has *HeroActionSystem) SetMonsterSystem(monsterSystem *MonsterSystem) {
 	has.monsterSystem = monsterSystem
 }
 
+// SetTrapSystem sets the trap system for trap searches, disarming and springing
+func (has *HeroActionSystem) SetTrapSystem(trapSystem *TrapSystem) {
+	has.trapSystem = trapSystem
+}
+
+// SetTradeSystem sets the trade system used to propose trades between heroes
+func (has *HeroActionSystem) SetTradeSystem(tradeSystem *TradeSystem) {
+	has.tradeSystem = tradeSystem
+}
+
+// SetHeroLifecycle sets the hero lifecycle system told when healing brings back a dying hero
+func (has *HeroActionSystem) SetHeroLifecycle(heroLifecycle *HeroLifecycleSystem) {
+	has.heroLifecycle = heroLifecycle
+}
+
 // SetQuest sets the quest definition for visibility calculations
 func (has *HeroActionSystem) SetQuest(quest *geometry.QuestDefinition) {
 	has.quest = quest
@@ -263,6 +293,13 @@ func (has *HeroActionSystem) ProcessAction(request ActionRequest) (*ActionResult
 		return nil, fmt.Errorf("entity %s does not belong to player %s", request.EntityID, request.PlayerID)
 	}
 
+	if err := checkHeroAwake(player); err != nil {
+		return nil, err
+	}
+	if err := checkHeroConscious(player); err != nil {
+		return nil, err
+	}
+
 	result := &ActionResult{
 		Action:    request.Action,
 		PlayerID:  request.PlayerID,
@@ -291,19 +328,21 @@ func (has *HeroActionSystem) ProcessAction(request ActionRequest) (*ActionResult
 
 // Search for treasure action
 func (has *HeroActionSystem) processSearchTreasure(request ActionRequest, result *ActionResult) (*ActionResult, error) {
-	// Get hero position and room
-	has.gameState.Lock.Lock()
-	heroPos, exists := has.gameState.Entities[request.EntityID]
-	if !exists {
-		has.gameState.Lock.Unlock()
+	// Treasure is searched for in rooms without monsters in sight
+	location, err := has.checkSearchAllowed(request.EntityID, "treasure")
+	if err == nil && location == nil {
+		err = &GameError{Code: "corridor_search", Message: "treasure can only be searched for in rooms"}
+	}
+	if err != nil {
 		result.Success = false
-		result.Message = "Hero not found"
-		return result, fmt.Errorf("hero %s not found", request.EntityID)
+		result.Message = err.Error()
+		return result, err
 	}
 
-	heroIdx := heroPos.Y*has.gameState.Segment.Width + heroPos.X
-	heroRoom := has.gameState.RegionMap.TileRegionIDs[heroIdx]
+	has.gameState.Lock.Lock()
+	heroPos := has.gameState.Entities[request.EntityID]
 	has.gameState.Lock.Unlock()
+	heroRoom := location.Region
 
 	// Check if treasure resolver and inventory manager are available
 	if has.treasureResolver == nil || has.inventoryManager == nil {
@@ -325,14 +364,13 @@ func (has *HeroActionSystem) processSearchTreasure(request ActionRequest, result
 		}
 	}
 
-	// Check if hero can search this room (via TurnStateManager)
+	// Each hero searches a room for treasure once per quest (via TurnStateManager)
 	if has.turnStateManager != nil {
-		locationKey := fmt.Sprintf("room-%d", heroRoom)
-		canSearch, reason := has.turnStateManager.CanSearchTreasure(request.EntityID, locationKey)
+		canSearch, reason := has.turnStateManager.CanSearchTreasure(request.EntityID, location.Key)
 		if !canSearch {
 			result.Success = false
 			result.Message = reason
-			return result, fmt.Errorf("cannot search: %s", reason)
+			return result, &GameError{Code: "already_searched", Message: fmt.Sprintf("cannot search: %s", reason)}
 		}
 	}
 
@@ -349,6 +387,18 @@ func (has *HeroActionSystem) processSearchTreasure(request ActionRequest, result
 		furnitureID = fid
 	}
 
+	// Opening trapped furniture springs its traps before the treasure is found
+	if has.trapSystem != nil {
+		sprung, err := has.trapSystem.SpringChestTraps(request.EntityID, furnitureID)
+		if err != nil {
+			has.logger.Printf("Warning: Failed to spring chest traps on %s: %v", furnitureID, err)
+		}
+		for _, trap := range sprung {
+			result.TrapsSprung = append(result.TrapsSprung, trap)
+			result.Damage += trap.Damage
+		}
+	}
+
 	// Resolve treasure search
 	treasureResult, err := has.treasureResolver.ResolveTreasureSearch(
 		request.EntityID,
@@ -376,9 +426,13 @@ func (has *HeroActionSystem) processSearchTreasure(request ActionRequest, result
 		}
 	}
 
+	// Searching furniture can set off the quest's triggers
+	if has.questTriggers != nil && furnitureID != "" {
+		has.questTriggers.RecordFurnitureSearch(request.EntityID, furnitureID)
+	}
+
 	// Record search in TurnStateManager
 	if has.turnStateManager != nil {
-		locationKey := fmt.Sprintf("room-%d", heroRoom)
 		foundItemIDs := make([]string, len(treasureResult.FoundItems))
 		for i, item := range treasureResult.FoundItems {
 			foundItemIDs[i] = item.ID
@@ -387,8 +441,8 @@ func (has *HeroActionSystem) processSearchTreasure(request ActionRequest, result
 		has.turnStateManager.RecordSearch(
 			request.EntityID,
 			"treasure",
-			locationKey,
-			"room",
+			location.Key,
+			location.Type,
 			heroPos,
 			treasureResult.Success,
 			foundItemIDs,
@@ -396,18 +450,34 @@ func (has *HeroActionSystem) processSearchTreasure(request ActionRequest, result
 	}
 
 	// Handle special cases
-	if treasureResult.IsHazard {
-		// TODO: Apply hazard damage to hero
-		has.logger.Printf("Hero %s triggered hazard: %d damage", request.EntityID, treasureResult.HazardDamage)
-		if treasureResult.EndTurn {
-			// TODO: End hero's turn
-			has.logger.Printf("Hero %s turn ended by hazard", request.EntityID)
+	if treasureResult.IsHazard && treasureResult.CardDrawn != nil {
+		hazard, err := has.applyHazard(has.turnManager.GetPlayer(request.PlayerID), treasureResult.CardDrawn)
+		if err != nil {
+			has.logger.Printf("Warning: Failed to apply hazard %s to %s: %v", treasureResult.CardDrawn.ID, request.EntityID, err)
+		} else {
+			result.Hazard = hazard
+			result.Damage += hazard.Damage
 		}
 	}
 
 	if treasureResult.IsMonster {
-		// TODO: Spawn wandering monster adjacent to hero
 		has.logger.Printf("Hero %s encountered wandering monster: %s", request.EntityID, treasureResult.MonsterType)
+		if treasureResult.MonsterType != "" && has.monsterSystem != nil {
+			wanderer := geometry.QuestMonster{Type: treasureResult.MonsterType, Overrides: treasureResult.MonsterOverrides}
+			attack, err := has.monsterSystem.SpawnWanderingMonster(wanderer, request.EntityID)
+			if err != nil {
+				has.logger.Printf("Warning: Failed to spawn wandering monster for %s: %v", request.EntityID, err)
+			} else {
+				result.WanderingMonster = attack
+				result.Damage += attack.Damage
+			}
+		}
+	}
+
+	if treasureResult.MonsterModifier != nil && has.monsterSystem != nil {
+		if _, err := has.monsterSystem.ApplyMonsterModifier(treasureResult.NoteID, treasureResult.MonsterModifier); err != nil {
+			has.logger.Printf("Warning: Failed to apply monster modifier from quest note %s: %v", treasureResult.NoteID, err)
+		}
 	}
 
 	// Set result
@@ -441,12 +511,7 @@ func (has *HeroActionSystem) processAttack(request ActionRequest, result *Action
 		return result, fmt.Errorf("missing targetId parameter")
 	}
 
-	// Consume action
-	if err := has.turnManager.ConsumeAction(); err != nil {
-		result.Success = false
-		result.Message = err.Error()
-		return result, err
-	}
+	thrown, _ := request.Parameters["thrown"].(bool)
 
 	// Get attacking player's character
 	player := has.turnManager.GetCurrentPlayer()
@@ -476,12 +541,71 @@ func (has *HeroActionSystem) processAttack(request ActionRequest, result *Action
 		return result, fmt.Errorf("target monster %s is already dead", targetID)
 	}
 
-	// Roll attack dice based on hero's effective attack dice
+	// The equipped weapon decides which squares the hero can attack
+	has.gameState.Lock.Lock()
+	heroPos, onBoard := has.gameState.Entities[request.EntityID]
+	has.gameState.Lock.Unlock()
+	if !onBoard {
+		result.Success = false
+		result.Message = "Hero is not on the board"
+		return result, fmt.Errorf("hero %s has no position", request.EntityID)
+	}
+
+	var weapon *ItemCard
+	if has.inventoryManager != nil {
+		weapon = has.inventoryManager.EquippedItem(request.EntityID, "weapon")
+	}
+	if err := has.checkAttackReach(weapon, heroPos, targetMonster.Position, thrown); err != nil {
+		result.Success = false
+		result.Message = err.Error()
+		return result, err
+	}
+
+	// Consume action
+	if err := has.turnManager.ConsumeAction(); err != nil {
+		result.Success = false
+		result.Message = err.Error()
+		return result, err
+	}
+
+	// Roll attack dice based on hero's effective attack dice plus any pending bonus
 	attackDice := player.Character.GetEffectiveAttackDice()
-	attackRolls := has.diceSystem.RollAttackDice(attackDice)
+	defenseDice := targetMonster.DefenseDice
+	rerolls := 0
+	if has.turnStateManager != nil {
+		for _, effect := range has.turnStateManager.TriggerEffects(request.EntityID, "next_attack") {
+			switch effect.EffectType {
+			case "bonus_attack_dice":
+				attackDice += effect.Value
+			case rerollAttackDice:
+				rerolls += effect.Value
+			case ignoreDefenseDice:
+				defenseDice = ignoreDefense(defenseDice, effect.Value)
+			}
+		}
+	}
+
+	// Artifacts the hero holds may add dice or pierce the monster's defense
+	if has.inventoryManager != nil {
+		attack := &ArtifactAttack{
+			Monster:     targetMonster,
+			Ranged:      max(absInt(targetMonster.Position.X-heroPos.X), absInt(targetMonster.Position.Y-heroPos.Y)) > 1,
+			AttackDice:  attackDice,
+			DefenseDice: defenseDice,
+		}
+		has.inventoryManager.ApplyArtifactAttack(request.EntityID, attack)
+		attackDice, defenseDice = attack.AttackDice, attack.DefenseDice
+	}
+	attackRolls := has.diceSystem.RerollMisses(has.diceSystem.RollAttackDice(max(attackDice, 1)), rerolls)
 
-	// Roll defense dice for monster
-	defenseRolls := has.diceSystem.RollDefenseDice(targetMonster.DefenseDice)
+	// Roll defense dice for monster; a sleeping monster cannot defend and wakes up
+	if targetMonster.IsAsleep {
+		defenseDice = 0
+		if err := has.monsterSystem.SetMonsterAsleep(targetID, false); err != nil {
+			has.logger.Printf("Warning: failed to wake %s: %v", targetID, err)
+		}
+	}
+	defenseRolls := has.diceSystem.RollDefenseDice(defenseDice)
 
 	// Calculate damage
 	damage := CalculateCombatDamage(attackRolls, defenseRolls)
@@ -516,6 +640,12 @@ func (has *HeroActionSystem) processAttack(request ActionRequest, result *Action
 		countSkulls(attackRolls), countShields(defenseRolls), damage,
 		targetMonster.Type, targetMonster.Body, targetMonster.MaxBody)
 
+	// A thrown weapon lands on the target's square, where it can be picked up again
+	if thrown {
+		has.dropThrownWeapon(request.EntityID, weapon, targetMonster.Position)
+		result.ThrownItem = weapon.ID
+	}
+
 	// Broadcast entity update for hero to ensure frontend keeps track of position
 	has.gameState.Lock.Lock()
 	heroTile, exists := has.gameState.Entities[request.EntityID]
@@ -552,8 +682,27 @@ func countShields(rolls []DiceRoll) int {
 	return count
 }
 
+// countHeroShields counts the white shields that block damage to a hero
+func countHeroShields(rolls []DiceRoll) int {
+	count := 0
+	for _, roll := range rolls {
+		if roll.CombatResult == WhiteShield {
+			count++
+		}
+	}
+	return count
+}
+
 // Search for traps action
 func (has *HeroActionSystem) processSearchTraps(request ActionRequest, result *ActionResult) (*ActionResult, error) {
+	// The room or corridor must be uninhabited by monsters
+	location, err := has.checkSearchAllowed(request.EntityID, "trap")
+	if err != nil {
+		result.Success = false
+		result.Message = err.Error()
+		return result, err
+	}
+
 	// Consume action
 	if err := has.turnManager.ConsumeAction(); err != nil {
 		result.Success = false
@@ -561,8 +710,6 @@ func (has *HeroActionSystem) processSearchTraps(request ActionRequest, result *A
 		return result, err
 	}
 
-	// TODO: Check if room/corridor is uninhabited by monsters
-
 	// Roll search dice for traps
 	searchRolls := has.diceSystem.RollDice(SearchDie, 1, "search_traps")
 	searchResult := searchRolls[0].Result
@@ -570,20 +717,44 @@ func (has *HeroActionSystem) processSearchTraps(request ActionRequest, result *A
 	result.SearchRolls = searchRolls
 	result.Success = true
 
-	if searchResult >= 5 { // Success on 5-6
-		// TODO: Reveal trap in current room/corridor
+	var found []*Trap
+	if searchResult >= 5 && has.trapSystem != nil { // Success on 5-6
+		has.gameState.Lock.Lock()
+		heroPos := has.gameState.Entities[request.EntityID]
+		has.gameState.Lock.Unlock()
+
+		found = has.trapSystem.RevealTrapsInRegion(request.EntityID, heroPos, has.visibility)
+	}
+
+	if len(found) > 0 {
+		for _, trap := range found {
+			result.TrapsFound = append(result.TrapsFound, trap.ID)
+		}
 		result.Message = "Found a trap!"
-		has.logger.Printf("Player %s found a trap with roll %d", request.PlayerID, searchResult)
+		if len(found) > 1 {
+			result.Message = fmt.Sprintf("Found %d traps!", len(found))
+		}
+		has.logger.Printf("Player %s found %d trap(s) with roll %d", request.PlayerID, len(found), searchResult)
 	} else {
 		result.Message = "No traps found"
 		has.logger.Printf("Player %s searched for traps and rolled %d: no traps found", request.PlayerID, searchResult)
 	}
 
+	has.recordSearch(request.EntityID, "trap", location, len(found) > 0)
+
 	return result, nil
 }
 
 // Search for secret doors action
 func (has *HeroActionSystem) processSearchSecret(request ActionRequest, result *ActionResult) (*ActionResult, error) {
+	// The room or corridor must be uninhabited by monsters
+	location, err := has.checkSearchAllowed(request.EntityID, "secret_door")
+	if err != nil {
+		result.Success = false
+		result.Message = err.Error()
+		return result, err
+	}
+
 	// Consume action
 	if err := has.turnManager.ConsumeAction(); err != nil {
 		result.Success = false
@@ -591,8 +762,6 @@ func (has *HeroActionSystem) processSearchSecret(request ActionRequest, result *
 		return result, err
 	}
 
-	// TODO: Check if room/corridor is uninhabited by monsters
-
 	// Roll search dice for secret doors
 	searchRolls := has.diceSystem.RollDice(SearchDie, 1, "search_secret")
 	searchResult := searchRolls[0].Result
@@ -600,60 +769,80 @@ func (has *HeroActionSystem) processSearchSecret(request ActionRequest, result *
 	result.SearchRolls = searchRolls
 	result.Success = true
 
+	var found []protocol.ThresholdLite
 	if searchResult == 6 { // Success only on 6
-		// Found secret door
-		secret := &SecretDoor{
-			ID:       fmt.Sprintf("secret_%d", time.Now().Unix()),
-			Position: has.gameState.Entities[request.EntityID],
-			LeadsTo:  "Unknown",
+		has.gameState.Lock.Lock()
+		heroPos := has.gameState.Entities[request.EntityID]
+		heroRegion, _ := regionAt(has.gameState, heroPos)
+		for _, edge := range findHiddenSecretDoors(has.gameState, has.quest, heroPos) {
+			id, door := has.gameState.RevealSecretDoor(edge)
+			found = append(found, protocol.ThresholdLite{
+				ID:          id,
+				X:           edge.X,
+				Y:           edge.Y,
+				Orientation: string(edge.Orientation),
+				Kind:        "DoorSocket",
+				State:       door.State,
+			})
+
+			if result.SecretRevealed == nil {
+				leadsTo := door.RegionB
+				if leadsTo == heroRegion {
+					leadsTo = door.RegionA
+				}
+				result.SecretRevealed = &SecretDoor{
+					ID:       id,
+					Position: protocol.TileAddress{SegmentID: has.gameState.Segment.ID, X: edge.X, Y: edge.Y},
+					LeadsTo:  fmt.Sprintf("room-%d", leadsTo),
+				}
+			}
 		}
-		result.SecretRevealed = secret
+		has.gameState.Lock.Unlock()
+	}
+
+	if len(found) > 0 {
+		has.broadcaster.BroadcastEvent("DoorsVisible", protocol.DoorsVisible{Doors: found})
+
 		result.Message = "Found a secret door!"
-		has.logger.Printf("Player %s found a secret door with roll %d", request.PlayerID, searchResult)
+		if len(found) > 1 {
+			result.Message = fmt.Sprintf("Found %d secret doors!", len(found))
+		}
+		has.logger.Printf("Player %s found %d secret door(s) with roll %d", request.PlayerID, len(found), searchResult)
 	} else {
 		result.Message = "No secret doors found"
 		has.logger.Printf("Player %s searched for secret doors and rolled %d: no secrets found", request.PlayerID, searchResult)
 	}
 
+	has.recordSearch(request.EntityID, "secret_door", location, len(found) > 0)
+
 	return result, nil
 }
 
-// Cast spell action
-func (has *HeroActionSystem) processCastSpell(request ActionRequest, result *ActionResult) (*ActionResult, error) {
-	spellID, ok := request.Parameters["spellId"].(string)
+// Disarm trap action
+func (has *HeroActionSystem) processDisarmTrap(request ActionRequest, result *ActionResult) (*ActionResult, error) {
+	trapID, ok := request.Parameters["trapId"].(string)
 	if !ok {
 		result.Success = false
-		result.Message = "No spell specified"
-		return result, fmt.Errorf("missing spellId parameter")
+		result.Message = "No trap specified"
+		return result, fmt.Errorf("missing trapId parameter")
 	}
 
-	// Consume action
-	if err := has.turnManager.ConsumeAction(); err != nil {
+	if has.trapSystem == nil {
 		result.Success = false
-		result.Message = err.Error()
-		return result, err
-	}
-
-	// TODO: Implement spell system
-	// For now, simple placeholder
-	spell := &SpellEffect{
-		SpellID:    spellID,
-		Name:       "Test Spell",
-		Duration:   3,
-		TargetType: "self",
-		TargetID:   request.EntityID,
+		result.Message = "Trap system not available"
+		return result, fmt.Errorf("trap system not initialized")
 	}
 
-	result.Success = true
-	result.SpellEffect = spell
-	result.Message = fmt.Sprintf("Cast %s", spell.Name)
+	player := has.turnManager.GetCurrentPlayer()
+	hasToolKit := has.inventoryManager != nil && has.inventoryManager.HasItem(request.EntityID, ToolKitItemID)
 
-	has.logger.Printf("Player %s cast spell %s", request.PlayerID, spellID)
-	return result, nil
-}
+	// The action is only spent on an attempt the hero may make
+	if _, err := has.trapSystem.CheckDisarmTrap(player, trapID, hasToolKit); err != nil {
+		result.Success = false
+		result.Message = err.Error()
+		return result, err
+	}
 
-// Disarm trap action
-func (has *HeroActionSystem) processDisarmTrap(request ActionRequest, result *ActionResult) (*ActionResult, error) {
 	// Consume action
 	if err := has.turnManager.ConsumeAction(); err != nil {
 		result.Success = false
@@ -661,31 +850,25 @@ func (has *HeroActionSystem) processDisarmTrap(request ActionRequest, result *Ac
 		return result, err
 	}
 
-	trapID, ok := request.Parameters["trapId"].(string)
-	if !ok {
+	disarmed, disarmRolls, sprung, err := has.trapSystem.DisarmTrap(player, trapID, hasToolKit)
+	if err != nil {
 		result.Success = false
-		result.Message = "No trap specified"
-		return result, fmt.Errorf("missing trapId parameter")
+		result.Message = err.Error()
+		return result, err
 	}
 
-	// TODO: Check if player has tool kit (except for Dwarf)
-	// TODO: Check if trap exists and is known
-	// TODO: Implement actual trap disarming mechanics
-
-	// For now, simple success/failure
-	disarmRolls := has.diceSystem.RollDice(SearchDie, 1, "disarm_trap")
-	disarmResult := disarmRolls[0].Result
-
 	result.SearchRolls = disarmRolls
-	result.Success = disarmResult >= 4 // Success on 4-6
+	result.Success = disarmed
 
-	if result.Success {
+	if disarmed {
 		result.Message = fmt.Sprintf("Successfully disarmed trap %s", trapID)
-		has.logger.Printf("Player %s disarmed trap %s with roll %d", request.PlayerID, trapID, disarmResult)
 	} else {
 		result.Message = "Failed to disarm trap"
-		has.logger.Printf("Player %s failed to disarm trap %s with roll %d", request.PlayerID, trapID, disarmResult)
-		// TODO: Trigger trap effect
+		if sprung != nil {
+			result.TrapsSprung = []*TrapResult{sprung}
+			result.Damage = sprung.Damage
+			result.Message = fmt.Sprintf("Failed to disarm trap and sprang it for %d damage", sprung.Damage)
+		}
 	}
 
 	return result, nil
@@ -693,25 +876,36 @@ func (has *HeroActionSystem) processDisarmTrap(request ActionRequest, result *Ac
 
 // ProcessInstantAction processes instant actions that don't consume the main action
 func (has *HeroActionSystem) ProcessInstantAction(request InstantActionRequest) (*ActionResult, error) {
-	// Validate it's the player's turn (instant actions don't require actions/movement to be available)
-	// Use dynamic turn order if available
-	canAct := false
-	if has.dynamicTurnOrder != nil {
-		canAct = has.dynamicTurnOrder.CanPlayerAct(request.PlayerID)
-	} else {
-		canAct = has.turnManager.IsPlayersTurn(request.PlayerID)
-	}
-
-	if !canAct {
+	// Validate it's the player's turn (instant actions don't require actions/movement to be available).
+	// Potions and items may also be used out of turn; their timing is checked when used.
+	usableOutOfTurn := request.Action == UsePotionInstant || request.Action == UseItemInstant
+	if !usableOutOfTurn && !has.canPlayerAct(request.PlayerID) {
 		return nil, fmt.Errorf("player %s cannot act right now", request.PlayerID)
 	}
 
 	// Validate entity belongs to player
 	player := has.turnManager.GetCurrentPlayer()
+	if usableOutOfTurn {
+		player = has.turnManager.GetPlayer(request.PlayerID)
+	}
 	if player == nil || player.EntityID != request.EntityID {
 		return nil, fmt.Errorf("entity %s does not belong to player %s", request.EntityID, request.PlayerID)
 	}
 
+	// A sleeping hero can only pass
+	if request.Action != PassTurnInstant {
+		if err := checkHeroAwake(player); err != nil {
+			return nil, err
+		}
+	}
+
+	// A dying hero can only pass or reach for items that may save them
+	if request.Action != PassTurnInstant && !usableOutOfTurn {
+		if err := checkHeroConscious(player); err != nil {
+			return nil, err
+		}
+	}
+
 	result := &ActionResult{
 		Action:    HeroAction(request.Action), // Cast to HeroAction for compatibility
 		PlayerID:  request.PlayerID,
@@ -733,6 +927,8 @@ func (has *HeroActionSystem) ProcessInstantAction(request InstantActionRequest)
 		return has.processPassTurn(request, result)
 	case RollMovementInstant:
 		return has.processRollMovement(request, result)
+	case PickUpItemInstant:
+		return has.processPickUpItem(request, result)
 	default:
 		return nil, fmt.Errorf("unknown instant action: %s", request.Action)
 	}
@@ -751,6 +947,13 @@ func (has *HeroActionSystem) ProcessMovement(request MovementRequest) (*ActionRe
 		return nil, fmt.Errorf("entity %s does not belong to player %s", request.EntityID, request.PlayerID)
 	}
 
+	if err := checkHeroAwake(player); err != nil {
+		return nil, err
+	}
+	if err := checkHeroConscious(player); err != nil {
+		return nil, err
+	}
+
 	result := &ActionResult{
 		Action:    HeroAction("movement"), // Special action type
 		PlayerID:  request.PlayerID,
@@ -761,6 +964,37 @@ func (has *HeroActionSystem) ProcessMovement(request MovementRequest) (*ActionRe
 	return has.processMovement(request, result)
 }
 
+// canPlayerAct reports whether it is the player's turn, using dynamic turn order if available
+func (has *HeroActionSystem) canPlayerAct(playerID string) bool {
+	if has.dynamicTurnOrder != nil {
+		return has.dynamicTurnOrder.CanPlayerAct(playerID)
+	}
+	return has.turnManager.IsPlayersTurn(playerID)
+}
+
+// checkHeroAwake rejects actions from a hero put to sleep by a dread spell
+func checkHeroAwake(player *Player) error {
+	if player.Character != nil && player.Character.IsAsleep {
+		return &GameError{Code: "hero_asleep", Message: fmt.Sprintf("%s is asleep and loses this turn", player.EntityID)}
+	}
+	return nil
+}
+
+// checkHeroConscious rejects actions from a hero at 0 body points
+func checkHeroConscious(player *Player) error {
+	if player.Character != nil && player.Character.IsUnconscious() {
+		return &GameError{Code: "hero_unconscious", Message: fmt.Sprintf("%s is unconscious and cannot act", player.EntityID)}
+	}
+	return nil
+}
+
+// checkHeroLife tells the hero lifecycle system that a hero's body points changed
+func (has *HeroActionSystem) checkHeroLife(heroID string) {
+	if has.heroLifecycle != nil {
+		has.heroLifecycle.CheckHero(heroID)
+	}
+}
+
 // Instant action processors
 
 func (has *HeroActionSystem) processMovement(request MovementRequest, result *ActionResult) (*ActionResult, error) {
@@ -799,6 +1033,13 @@ func (has *HeroActionSystem) processMovement(request MovementRequest, result *Ac
 		return result, err
 	}
 
+	// Heroes will not walk onto a trap they know about
+	if has.trapSystem != nil && has.trapSystem.IsKnownTrapAt(request.EntityID, newTile.X, newTile.Y) {
+		result.Success = false
+		result.Message = "Movement blocked: a known trap is in the way"
+		return result, &GameError{Code: "known_trap", Message: "a known trap is in the way"}
+	}
+
 	// Only consume movement points if the move is valid
 	if err := has.turnManager.ConsumeMovement(distance, requestAction); err != nil {
 		result.Success = false
@@ -808,6 +1049,7 @@ func (has *HeroActionSystem) processMovement(request MovementRequest, result *Ac
 
 	// Update entity position
 	has.gameState.Lock.Lock()
+	fromTile := has.gameState.Entities[request.EntityID]
 	has.gameState.Entities[request.EntityID] = *newTile
 	has.gameState.Lock.Unlock()
 
@@ -817,6 +1059,24 @@ func (has *HeroActionSystem) processMovement(request MovementRequest, result *Ac
 		Tile: *newTile,
 	})
 
+	// Stepping onto an unknown trap springs it mid-move
+	if has.trapSystem != nil {
+		sprung, err := has.trapSystem.SpringTrapOnEntry(request.EntityID, fromTile, *newTile)
+		if err != nil {
+			has.logger.Printf("Warning: Failed to spring trap at (%d,%d): %v", newTile.X, newTile.Y, err)
+		}
+		if sprung != nil {
+			result.TrapsSprung = []*TrapResult{sprung}
+			result.Damage = sprung.Damage
+			if sprung.PushedBackTo != nil {
+				newTile = sprung.PushedBackTo
+			}
+			if sprung.EndsMovement {
+				has.turnManager.ForfeitMovement(fmt.Sprintf("%s trap", sprung.Type))
+			}
+		}
+	}
+
 	// Check for newly visible doors after movement (only if game state is fully initialized)
 	hero := *newTile
 	heroIdx := hero.Y*has.gameState.Segment.Width + hero.X
@@ -878,6 +1138,14 @@ func (has *HeroActionSystem) processOpenDoor(request InstantActionRequest, resul
 		return result, nil
 	}
 
+	// Quest events can lock a door shut
+	if door.State == "locked" {
+		has.gameState.Lock.Unlock()
+		result.Success = false
+		result.Message = fmt.Sprintf("Door %s is locked", doorID)
+		return result, &GameError{Code: "door_locked", Message: fmt.Sprintf("door %s is locked", doorID)}
+	}
+
 	// Open the door
 	door.State = "open"
 	has.gameState.Lock.Unlock()
@@ -903,11 +1171,7 @@ func (has *HeroActionSystem) processUsePotion(request InstantActionRequest, resu
 		return result, fmt.Errorf("missing potionId parameter")
 	}
 
-	// TODO: Implement potion usage logic
-	result.Success = true
-	result.Message = fmt.Sprintf("Used potion %s", potionID)
-	has.logger.Printf("Player %s used potion %s", request.PlayerID, potionID)
-	return result, nil
+	return has.useItem(request, potionID, true, result)
 }
 
 func (has *HeroActionSystem) processUseItem(request InstantActionRequest, result *ActionResult) (*ActionResult, error) {
@@ -918,56 +1182,88 @@ func (has *HeroActionSystem) processUseItem(request InstantActionRequest, result
 		return result, fmt.Errorf("missing itemId parameter")
 	}
 
-	// TODO: Implement item usage logic
-	result.Success = true
-	result.Message = fmt.Sprintf("Used item %s", itemID)
-	has.logger.Printf("Player %s used item %s", request.PlayerID, itemID)
-	return result, nil
+	return has.useItem(request, itemID, false, result)
 }
 
 func (has *HeroActionSystem) processTradeItem(request InstantActionRequest, result *ActionResult) (*ActionResult, error) {
-	targetEntityID, ok1 := request.Parameters["targetEntityId"].(string)
-	itemID, ok2 := request.Parameters["itemId"].(string)
-
-	if !ok1 || !ok2 {
+	targetEntityID, ok := request.Parameters["targetEntityId"].(string)
+	if !ok {
 		result.Success = false
 		result.Message = "Missing trade parameters"
-		return result, fmt.Errorf("missing targetEntityId or itemId parameter")
+		return result, fmt.Errorf("missing targetEntityId parameter")
 	}
 
-	// Check if players are adjacent
-	has.gameState.Lock.Lock()
-	sourcePos, sourceExists := has.gameState.Entities[request.EntityID]
-	targetPos, targetExists := has.gameState.Entities[targetEntityID]
-	has.gameState.Lock.Unlock()
+	if has.tradeSystem == nil {
+		result.Success = false
+		result.Message = "Trading not available"
+		return result, fmt.Errorf("trade system not initialized")
+	}
+
+	// "itemId" hands over a single item; "itemIds"/"gold" and "requestItemIds"/"requestGold" describe a full trade
+	give := TradeGoods{Items: stringList(request.Parameters["itemIds"]), Gold: intParam(request.Parameters["gold"])}
+	if itemID, ok := request.Parameters["itemId"].(string); ok {
+		give.Items = append(give.Items, itemID)
+	}
+	want := TradeGoods{Items: stringList(request.Parameters["requestItemIds"]), Gold: intParam(request.Parameters["requestGold"])}
 
-	if !sourceExists || !targetExists {
+	offer, err := has.tradeSystem.ProposeTrade(request.EntityID, targetEntityID, give, want)
+	if err != nil {
 		result.Success = false
-		result.Message = "Player entity not found"
-		return result, fmt.Errorf("player entity not found")
+		result.Message = err.Error()
+		return result, err
 	}
 
-	// Check adjacency (within 1 tile, including diagonals)
-	dx := sourcePos.X - targetPos.X
-	dy := sourcePos.Y - targetPos.Y
-	if dx < 0 {
-		dx = -dx
+	result.Success = true
+	result.TradeOffer = offer
+	result.Message = fmt.Sprintf("Offered a trade to %s", targetEntityID)
+	has.logger.Printf("Player %s proposed %s to %s", request.PlayerID, offer.ID, targetEntityID)
+	return result, nil
+}
+
+func (has *HeroActionSystem) processPickUpItem(request InstantActionRequest, result *ActionResult) (*ActionResult, error) {
+	x, ok1 := request.Parameters["x"].(float64)
+	y, ok2 := request.Parameters["y"].(float64)
+	if !ok1 || !ok2 {
+		result.Success = false
+		result.Message = "No tile specified"
+		return result, fmt.Errorf("missing x/y parameters")
 	}
-	if dy < 0 {
-		dy = -dy
+	tile := protocol.TileAddress{X: int(x), Y: int(y)}
+
+	if has.inventoryManager == nil {
+		result.Success = false
+		result.Message = "Inventory not available"
+		return result, fmt.Errorf("inventory manager not initialized")
 	}
-	isAdjacent := dx <= 1 && dy <= 1 && (dx > 0 || dy > 0) // Not same tile
 
-	if !isAdjacent {
+	// Items can be picked up from the hero's own square or one next to it, but not through a
+	// wall or closed door
+	has.gameState.Lock.Lock()
+	heroPos, onBoard := has.gameState.Entities[request.EntityID]
+	reachable := onBoard && (groundTile(heroPos) == tile || (areAdjacent(heroPos, tile) && adjacentOpen(has.gameState, heroPos, tile)))
+	has.gameState.Lock.Unlock()
+	if !reachable {
+		err := &GameError{Code: "not_adjacent", Message: "hero must stand on or next to the items"}
 		result.Success = false
-		result.Message = "Players must be adjacent to trade"
-		return result, fmt.Errorf("players not adjacent")
+		result.Message = err.Error()
+		return result, err
+	}
+
+	items, err := has.inventoryManager.PickUpItems(request.EntityID, tile)
+	if err != nil {
+		result.Success = false
+		result.Message = err.Error()
+		return result, err
+	}
+
+	has.broadcastGroundItems(tile)
+	if inventory, err := has.inventoryManager.GetInventory(request.EntityID); err == nil {
+		has.broadcaster.BroadcastEvent("InventoryChanged", inventoryToLite(inventory))
 	}
 
-	// TODO: Implement actual item trading logic
 	result.Success = true
-	result.Message = fmt.Sprintf("Traded item %s to %s", itemID, targetEntityID)
-	has.logger.Printf("Player %s traded item %s to %s", request.PlayerID, itemID, targetEntityID)
+	result.Message = fmt.Sprintf("Picked up %d items", len(items))
+	has.logger.Printf("Player %s picked up %d items at (%d,%d)", request.PlayerID, len(items), tile.X, tile.Y)
 	return result, nil
 }
 
@@ -982,8 +1278,18 @@ func (has *HeroActionSystem) processPassTurn(request InstantActionRequest, resul
 }
 
 func (has *HeroActionSystem) processRollMovement(request InstantActionRequest, result *ActionResult) (*ActionResult, error) {
+	// Pending effects such as double movement add dice to this roll
+	extraDice := 0
+	if has.turnStateManager != nil && !has.turnManager.GetTurnState().MovementDiceRolled {
+		for _, effect := range has.turnStateManager.TriggerEffects(request.EntityID, "next_movement") {
+			if effect.EffectType == "double_movement" || effect.EffectType == bonusMovementDice {
+				extraDice += effect.Value
+			}
+		}
+	}
+
 	// Roll movement dice for the current player
-	diceRolls, err := has.turnManager.RollMovementDice()
+	diceRolls, err := has.turnManager.RollMovementDiceWithBonus(extraDice)
 	if err != nil {
 		result.Success = false
 		result.Message = err.Error()
@@ -1029,6 +1335,12 @@ func (has *HeroActionSystem) processRollMovement(request InstantActionRequest, r
 		}
 	}
 
+	// Doubles affect the hero's next attack under the DoubleDiceEffects house rule
+	if effect := has.diceSystem.DoublesEffect(diceRolls); effect != nil && has.turnStateManager != nil {
+		has.turnStateManager.QueueActiveEffect(request.EntityID, *effect)
+		has.logger.Printf("%s rolled double %ds: %s %d on the next attack", request.EntityID, diceRolls[0].Result, effect.EffectType, effect.Value)
+	}
+
 	return result, nil
 }
 
@@ -1040,9 +1352,38 @@ func abs(x float64) float64 {
 	return x
 }
 
+// stringList reads a JSON array of strings from action parameters
+func stringList(value any) []string {
+	var list []string
+	switch v := value.(type) {
+	case []string:
+		list = append(list, v...)
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+	}
+	return list
+}
+
+// intParam reads a JSON number from action parameters
+func intParam(value any) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
+
 // DiceSystem handles dice rolling with debug overrides
 type DiceSystem struct {
 	debugSystem *DebugSystem
+	rules       *RuleSet
 	random      *rand.Rand
 }
 
@@ -1170,27 +1511,10 @@ func CalculateCombatDamage(attackRolls, defenseRolls []DiceRoll) int {
 }
 
 // CalculateHeroCombatDamage calculates damage when a hero is defending
-// Heroes can defend with both white and black shields (3/6 chance total)
+// Heroes defend with white shields only (2/6 chance); black shields belong to monsters
 func CalculateHeroCombatDamage(attackRolls, defenseRolls []DiceRoll) int {
-	skulls := 0
-	shields := 0
-
-	// Count skulls from attack rolls
-	for _, roll := range attackRolls {
-		if roll.CombatResult == Skull {
-			skulls++
-		}
-	}
-
-	// Count both white and black shields from hero defense rolls
-	for _, roll := range defenseRolls {
-		if roll.CombatResult == WhiteShield || roll.CombatResult == BlackShield {
-			shields++
-		}
-	}
-
-	// Net damage = skulls - shields (minimum 0)
-	damage := skulls - shields
+	// Net damage = skulls - white shields (minimum 0)
+	damage := countSkulls(attackRolls) - countHeroShields(defenseRolls)
 	if damage < 0 {
 		damage = 0
 	}
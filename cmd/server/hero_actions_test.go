@@ -3,6 +3,7 @@ package main
 import (
 	"testing"
 
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
 	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
 )
 
@@ -43,6 +44,7 @@ func createTestHeroActionSystem() *HeroActionSystem {
 	// Add mock monster system for attack tests
 	monsterSystem := createTestMonsterSystem(logger)
 	has.SetMonsterSystem(monsterSystem)
+	has.SetTrapSystem(NewTrapSystem(gameState, turnManager, has.diceSystem, broadcaster, logger))
 
 	return has
 }
@@ -60,7 +62,7 @@ func createTestMonsterSystem(logger Logger) *MonsterSystem {
 	testMonster := &Monster{
 		ID:               "monster-1",
 		Type:             Goblin,
-		Position:         protocol.TileAddress{X: 6, Y: 6},
+		Position:         protocol.TileAddress{X: 5, Y: 6},
 		Body:             3,
 		MaxBody:          3,
 		AttackDice:       2,
@@ -119,6 +121,18 @@ func TestHeroActions_SearchTreasure_FindsItems(t *testing.T) {
 func TestHeroActions_SearchTraps_RequiresHighRoll(t *testing.T) {
 	has := createTestHeroActionSystem()
 
+	// Hero and a pit trap share room 1
+	has.gameState.Segment = geometry.Segment{Width: 10, Height: 10}
+	has.gameState.RegionMap = geometry.RegionMap{TileRegionIDs: make([]int, 100), RegionsCount: 2}
+	for i := range has.gameState.RegionMap.TileRegionIDs {
+		has.gameState.RegionMap.TileRegionIDs[i] = 1
+	}
+	has.trapSystem.AddTrap(&Trap{ID: "trap-1", Type: PitTrap, Position: protocol.TileAddress{X: 5, Y: 7}, Room: 1})
+
+	// Searching needs the room clear of visible monsters
+	goblin, _ := has.monsterSystem.GetMonsterByID("monster-1")
+	goblin.IsVisible = false
+
 	// Enable actions for testing
 	has.turnManager.RestoreActions()
 
@@ -162,10 +176,14 @@ func TestHeroActions_SearchTraps_RequiresHighRoll(t *testing.T) {
 	if result.Message != "Found a trap!" {
 		t.Errorf("Expected 'Found a trap!' with roll 6, got: %s", result.Message)
 	}
+	if !has.trapSystem.IsKnownTrapAt("hero-1", 5, 7) {
+		t.Error("Expected the trap to be revealed to the searching hero")
+	}
 }
 
 func TestHeroActions_SearchSecret_RequiresSix(t *testing.T) {
 	has := createTestHeroActionSystem()
+	setupSecretDoorRooms(has)
 
 	// Enable actions for testing
 	has.turnManager.RestoreActions()
@@ -208,7 +226,10 @@ func TestHeroActions_SearchSecret_RequiresSix(t *testing.T) {
 	}
 
 	if result.SecretRevealed == nil {
-		t.Error("Expected secret door to be revealed")
+		t.Fatal("Expected secret door to be revealed")
+	}
+	if result.SecretRevealed.LeadsTo != "room-1" {
+		t.Errorf("Expected secret door to lead to room-1, got %s", result.SecretRevealed.LeadsTo)
 	}
 }
 
@@ -239,7 +260,9 @@ func TestHeroActions_DisarmTrap_RequiresTrapID(t *testing.T) {
 	// Restore actions for second test
 	has.turnManager.RestoreActions()
 
-	// Test with trap ID
+	// Test with a known trap next to a dwarf, who needs no tool kit
+	has.turnManager.GetPlayer("player-1").Class = Dwarf
+	has.trapSystem.AddTrap(&Trap{ID: "trap-1", Type: PitTrap, Position: protocol.TileAddress{X: 5, Y: 6}, Revealed: true})
 	request.Parameters["trapId"] = "trap-1"
 
 	result, err = has.ProcessAction(request)
@@ -254,7 +277,7 @@ func TestHeroActions_DisarmTrap_RequiresTrapID(t *testing.T) {
 }
 
 func TestHeroActions_CastSpell_RequiresSpellID(t *testing.T) {
-	has := createTestHeroActionSystem()
+	has := createTestSpellCaster(t, &SpellCard{ID: "fireball", Name: "Ball of Flame", Target: SpellTargetMonster, Effect: SpellEffectDamage, EffectValue: 2})
 
 	// Test without spell ID (should fail)
 	request := ActionRequest{
@@ -276,6 +299,7 @@ func TestHeroActions_CastSpell_RequiresSpellID(t *testing.T) {
 
 	// Test with spell ID
 	request.Parameters["spellId"] = "fireball"
+	request.Parameters["targetId"] = "monster-1"
 
 	result, err = has.ProcessAction(request)
 
@@ -0,0 +1,381 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// Hero conditions (FallenHero.Condition)
+const (
+	HeroConditionDying = "dying" // At 0 body; an ally can still save them
+	HeroConditionDead  = "dead"
+)
+
+// Where a dead hero's items go (HeroLifecycleSystem.SetDeadHeroItems)
+const (
+	DeadHeroItemsCorpse = "corpse" // Left where the hero fell for the party to recover
+	DeadHeroItemsGM     = "gm"     // Handed to the GM
+)
+
+// FallenHero is a hero brought down to 0 body points, dying or dead
+type FallenHero struct {
+	HeroID         string               `json:"heroId"`
+	PlayerID       string               `json:"playerId"`
+	Condition      string               `json:"condition"`
+	Position       protocol.TileAddress `json:"position"`
+	DiesAfterCycle int                  `json:"diesAfterCycle"`  // The GM phase of this cycle is the last chance of rescue
+	Items          []*ItemCard          `json:"items,omitempty"` // Left on the corpse
+	Gold           int                  `json:"gold,omitempty"`
+}
+
+// HeroLifecycleSystem tracks heroes from falling to 0 body points until they are rescued or die.
+// A dying hero is skipped in the hero phase; unless healed before the end of the next GM phase
+// they die, and the quest is lost once every hero is dead.
+type HeroLifecycleSystem struct {
+	fallen           map[string]*FallenHero // heroID -> fallen hero
+	gmItems          []*ItemCard            // Items dead heroes handed to the GM
+	gmGold           int
+	deadHeroItems    string
+	gameState        *GameState
+	turnManager      *TurnManager
+	dynamicTurnOrder *DynamicTurnOrderManager
+	inventoryManager *InventoryManager
+	questSummary     func() *protocol.QuestSummary // Recap sent when the party is wiped out
+	broadcaster      Broadcaster
+	logger           Logger
+	mutex            sync.Mutex
+}
+
+// NewHeroLifecycleSystem creates a new hero lifecycle system
+func NewHeroLifecycleSystem(gameState *GameState, turnManager *TurnManager, dynamicTurnOrder *DynamicTurnOrderManager, inventoryManager *InventoryManager, broadcaster Broadcaster, logger Logger) *HeroLifecycleSystem {
+	return &HeroLifecycleSystem{
+		fallen:           make(map[string]*FallenHero),
+		deadHeroItems:    DeadHeroItemsCorpse,
+		gameState:        gameState,
+		turnManager:      turnManager,
+		dynamicTurnOrder: dynamicTurnOrder,
+		inventoryManager: inventoryManager,
+		broadcaster:      broadcaster,
+		logger:           logger,
+	}
+}
+
+// SetQuestSummary sets the recap sent with QuestEnded when every hero has died
+func (hls *HeroLifecycleSystem) SetQuestSummary(summary func() *protocol.QuestSummary) {
+	hls.questSummary = summary
+}
+
+// SetDeadHeroItems sets where a dead hero's items go: DeadHeroItemsCorpse or DeadHeroItemsGM
+func (hls *HeroLifecycleSystem) SetDeadHeroItems(destination string) error {
+	if destination != DeadHeroItemsCorpse && destination != DeadHeroItemsGM {
+		return fmt.Errorf("unknown dead hero item destination %q", destination)
+	}
+
+	hls.mutex.Lock()
+	defer hls.mutex.Unlock()
+	hls.deadHeroItems = destination
+	return nil
+}
+
+// CheckHero updates a hero's condition after their body points changed: a hero at 0 body
+// starts dying, and a dying hero healed above 0 is rescued
+func (hls *HeroLifecycleSystem) CheckHero(heroID string) {
+	player := hls.findHero(heroID)
+	if player == nil || player.Character == nil {
+		return
+	}
+
+	hls.mutex.Lock()
+	defer hls.mutex.Unlock()
+
+	fallen := hls.fallen[heroID]
+	switch {
+	case fallen == nil && player.Character.IsUnconscious():
+		hls.startDyingLocked(player)
+	case fallen != nil && fallen.Condition == HeroConditionDying && !player.Character.IsUnconscious():
+		hls.rescueLocked(player)
+	}
+}
+
+// ResolveGMPhaseEnd lets dying heroes whose rescue window closes with this GM phase die.
+// It returns the quest outcome if no hero is left alive.
+func (hls *HeroLifecycleSystem) ResolveGMPhaseEnd(cycle int) *QuestOutcome {
+	hls.mutex.Lock()
+	defer hls.mutex.Unlock()
+
+	for _, heroID := range hls.sortedFallenIDs() {
+		fallen := hls.fallen[heroID]
+		if fallen.Condition == HeroConditionDying && fallen.DiesAfterCycle <= cycle {
+			hls.dieLocked(fallen)
+		}
+	}
+
+	return hls.checkPartyLocked()
+}
+
+// LootCorpse hands everything a dead hero left behind to a hero standing on or next to the
+// corpse, on that hero's turn
+func (hls *HeroLifecycleSystem) LootCorpse(playerID, deadHeroID string) error {
+	looter := hls.turnManager.GetPlayer(playerID)
+	if looter == nil || looter.Character == nil {
+		return fmt.Errorf("player %s not found", playerID)
+	}
+	if !hls.dynamicTurnOrder.CanPlayerAct(playerID) {
+		return &GameError{Code: "not_your_turn", Message: fmt.Sprintf("player %s cannot act right now", playerID)}
+	}
+	if looter.Character.IsUnconscious() {
+		return &GameError{Code: "hero_unconscious", Message: fmt.Sprintf("%s is unconscious", looter.EntityID)}
+	}
+
+	hls.mutex.Lock()
+	corpse := hls.fallen[deadHeroID]
+	if corpse == nil || corpse.Condition != HeroConditionDead {
+		hls.mutex.Unlock()
+		return &GameError{Code: "no_corpse", Message: fmt.Sprintf("hero %s has not died", deadHeroID)}
+	}
+	corpsePos := corpse.Position
+	hls.mutex.Unlock()
+
+	// Checked outside the mutex: the quest triggers take it while holding the game state lock
+	hls.gameState.Lock.Lock()
+	position, onBoard := hls.gameState.Entities[looter.EntityID]
+	reachable := onBoard && (position == corpsePos || (areAdjacent(position, corpsePos) && adjacentOpen(hls.gameState, position, corpsePos)))
+	hls.gameState.Lock.Unlock()
+	if !reachable {
+		return &GameError{Code: "not_adjacent", Message: "hero must stand on or next to the corpse"}
+	}
+
+	hls.mutex.Lock()
+	if len(corpse.Items) == 0 && corpse.Gold == 0 {
+		hls.mutex.Unlock()
+		return &GameError{Code: "nothing_to_loot", Message: fmt.Sprintf("hero %s left nothing behind", deadHeroID)}
+	}
+	items, gold := corpse.Items, corpse.Gold
+	corpse.Items, corpse.Gold = nil, 0
+	hls.mutex.Unlock()
+
+	if err := hls.inventoryManager.GiveItems(looter.EntityID, items, gold); err != nil {
+		hls.mutex.Lock()
+		corpse.Items, corpse.Gold = items, gold
+		hls.mutex.Unlock()
+		return err
+	}
+
+	hls.broadcaster.BroadcastEvent("CorpseLooted", protocol.CorpseLooted{
+		HeroID:   deadHeroID,
+		LooterID: looter.EntityID,
+		Items:    itemsToLite(items),
+		Gold:     gold,
+	})
+	if inventory, err := hls.inventoryManager.GetInventory(looter.EntityID); err == nil {
+		hls.broadcaster.BroadcastEvent("InventoryChanged", inventoryToLite(inventory))
+	}
+
+	hls.logger.Printf("Hero %s recovered %d items and %d gold from %s", looter.EntityID, len(items), gold, deadHeroID)
+	return nil
+}
+
+// GetCondition returns a hero's condition, or "" for a hero still standing
+func (hls *HeroLifecycleSystem) GetCondition(heroID string) string {
+	hls.mutex.Lock()
+	defer hls.mutex.Unlock()
+
+	if fallen := hls.fallen[heroID]; fallen != nil {
+		return fallen.Condition
+	}
+	return ""
+}
+
+// IsDead reports whether a hero has died
+func (hls *HeroLifecycleSystem) IsDead(heroID string) bool {
+	return hls.GetCondition(heroID) == HeroConditionDead
+}
+
+// GetCorpses returns a marker for every dead hero
+func (hls *HeroLifecycleSystem) GetCorpses() []protocol.CorpseLite {
+	hls.mutex.Lock()
+	defer hls.mutex.Unlock()
+
+	corpses := make([]protocol.CorpseLite, 0)
+	for _, heroID := range hls.sortedFallenIDs() {
+		fallen := hls.fallen[heroID]
+		if fallen.Condition == HeroConditionDead {
+			corpses = append(corpses, protocol.CorpseLite{
+				HeroID: fallen.HeroID,
+				Tile:   fallen.Position,
+				Items:  len(fallen.Items),
+				Gold:   fallen.Gold,
+			})
+		}
+	}
+	return corpses
+}
+
+// startDyingLocked takes a hero at 0 body out of the hero phase. The rescue window closes at
+// the end of the current cycle's GM phase, or the following one if the hero fell during a GM phase.
+func (hls *HeroLifecycleSystem) startDyingLocked(player *Player) {
+	hls.gameState.Lock.Lock()
+	position := hls.gameState.Entities[player.EntityID]
+	hls.gameState.Lock.Unlock()
+
+	diesAfter := hls.dynamicTurnOrder.GetCycleNumber()
+	if hls.dynamicTurnOrder.GetCurrentPhase() == GMPhase {
+		diesAfter++
+	}
+
+	hls.fallen[player.EntityID] = &FallenHero{
+		HeroID:         player.EntityID,
+		PlayerID:       player.ID,
+		Condition:      HeroConditionDying,
+		Position:       position,
+		DiesAfterCycle: diesAfter,
+	}
+	hls.dynamicTurnOrder.SetHeroOutOfPlay(player.ID, true)
+
+	hls.broadcaster.BroadcastEvent("HeroDying", protocol.HeroDying{
+		HeroID:         player.EntityID,
+		Tile:           position,
+		DiesAfterCycle: diesAfter,
+	})
+	hls.logger.Printf("Hero %s is dying at (%d,%d); dies after the GM phase of cycle %d unless rescued",
+		player.EntityID, position.X, position.Y, diesAfter)
+}
+
+// rescueLocked brings a healed dying hero back into play
+func (hls *HeroLifecycleSystem) rescueLocked(player *Player) {
+	delete(hls.fallen, player.EntityID)
+	hls.dynamicTurnOrder.SetHeroOutOfPlay(player.ID, false)
+
+	hls.broadcaster.BroadcastEvent("HeroRescued", protocol.HeroRescued{
+		HeroID:      player.EntityID,
+		CurrentBody: player.Character.CurrentBody,
+	})
+	hls.logger.Printf("Hero %s was rescued with %d body points", player.EntityID, player.Character.CurrentBody)
+}
+
+// dieLocked kills a dying hero: their items drop on the corpse or go to the GM,
+// and the hero leaves the board
+func (hls *HeroLifecycleSystem) dieLocked(fallen *FallenHero) {
+	fallen.Condition = HeroConditionDead
+
+	items, gold, err := hls.inventoryManager.TakeAllItems(fallen.HeroID)
+	if err != nil {
+		hls.logger.Printf("Hero %s died without an inventory: %v", fallen.HeroID, err)
+	}
+	if hls.deadHeroItems == DeadHeroItemsGM {
+		hls.gmItems = append(hls.gmItems, items...)
+		hls.gmGold += gold
+	} else {
+		fallen.Items = items
+		fallen.Gold = gold
+	}
+
+	hls.gameState.Lock.Lock()
+	delete(hls.gameState.Entities, fallen.HeroID)
+	hls.gameState.Lock.Unlock()
+
+	hls.broadcaster.BroadcastEvent("HeroDied", protocol.HeroDied{
+		HeroID:  fallen.HeroID,
+		Tile:    fallen.Position,
+		ItemsTo: hls.deadHeroItems,
+		Items:   itemsToLite(items),
+		Gold:    gold,
+	})
+	hls.logger.Printf("Hero %s died; %d items and %d gold go to the %s", fallen.HeroID, len(items), gold, hls.deadHeroItems)
+}
+
+// checkPartyLocked ends the quest in defeat once every hero is dead
+func (hls *HeroLifecycleSystem) checkPartyLocked() *QuestOutcome {
+	heroes := hls.turnManager.GetHeroPlayers()
+	if len(heroes) == 0 {
+		return nil
+	}
+	for _, player := range heroes {
+		if fallen := hls.fallen[player.EntityID]; fallen == nil || fallen.Condition != HeroConditionDead {
+			return nil
+		}
+	}
+
+	outcome := hls.dynamicTurnOrder.EndQuest(QuestDefeat, "every hero has died")
+	ended := protocol.QuestEnded{Result: outcome.Result, Reason: outcome.Reason, Cycle: outcome.Cycle}
+	if hls.questSummary != nil {
+		ended.Summary = hls.questSummary()
+	}
+	hls.broadcaster.BroadcastEvent("QuestEnded", ended)
+	return outcome
+}
+
+// sortedFallenIDs returns fallen hero IDs in a stable order. The caller must hold the mutex.
+func (hls *HeroLifecycleSystem) sortedFallenIDs() []string {
+	ids := make([]string, 0, len(hls.fallen))
+	for heroID := range hls.fallen {
+		ids = append(ids, heroID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// findHero returns the player controlling a hero entity
+func (hls *HeroLifecycleSystem) findHero(heroID string) *Player {
+	for _, player := range hls.turnManager.GetHeroPlayers() {
+		if player.EntityID == heroID {
+			return player
+		}
+	}
+	return nil
+}
+
+// heroLifecyclePersistence is the serialized form of HeroLifecycleSystem
+type heroLifecyclePersistence struct {
+	Fallen        map[string]*FallenHero `json:"fallen"`
+	GMItems       []*ItemCard            `json:"gmItems,omitempty"`
+	GMGold        int                    `json:"gmGold,omitempty"`
+	DeadHeroItems string                 `json:"deadHeroItems"`
+}
+
+// SerializeForPersistence serializes fallen heroes and the items handed to the GM
+func (hls *HeroLifecycleSystem) SerializeForPersistence() ([]byte, error) {
+	hls.mutex.Lock()
+	defer hls.mutex.Unlock()
+
+	return json.Marshal(heroLifecyclePersistence{
+		Fallen:        hls.fallen,
+		GMItems:       hls.gmItems,
+		GMGold:        hls.gmGold,
+		DeadHeroItems: hls.deadHeroItems,
+	})
+}
+
+// RestoreFromPersistence restores fallen heroes from JSON produced by SerializeForPersistence
+func (hls *HeroLifecycleSystem) RestoreFromPersistence(data []byte) error {
+	var restored heroLifecyclePersistence
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return err
+	}
+
+	hls.mutex.Lock()
+	defer hls.mutex.Unlock()
+
+	hls.fallen = orEmpty(restored.Fallen)
+	hls.gmItems = restored.GMItems
+	hls.gmGold = restored.GMGold
+	if restored.DeadHeroItems != "" {
+		hls.deadHeroItems = restored.DeadHeroItems
+	}
+
+	hls.logger.Printf("Restored %d fallen heroes from persistence", len(hls.fallen))
+	return nil
+}
+
+// itemsToLite converts items for patches
+func itemsToLite(items []*ItemCard) []protocol.InventoryItemLite {
+	lite := make([]protocol.InventoryItemLite, 0, len(items))
+	for _, item := range items {
+		lite = append(lite, protocol.InventoryItemLite{ID: item.ID, Name: item.Name})
+	}
+	return lite
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// createTestLifecycle puts hero-1 (on turn) next to hero-2, both carrying the given cards,
+// with a hero lifecycle system tracking them in the given phase of cycle 1
+func createTestLifecycle(t *testing.T, phase TurnPhaseType, treasures ...*TreasureCard) (*HeroActionSystem, *HeroLifecycleSystem) {
+	has := createTestItemUser(t, nil, treasures)
+	has.gameState.Entities["hero-2"] = protocol.TileAddress{X: 6, Y: 5}
+
+	dtom := NewDynamicTurnOrderManager(&MockLogger{})
+	dtom.RegisterPlayer("player-1")
+	dtom.RegisterPlayer("player-2")
+	dtom.RestorePhase(phase, 1, "player-1", "", nil)
+	has.SetDynamicTurnOrderManager(dtom)
+
+	lifecycle := NewHeroLifecycleSystem(has.gameState, has.turnManager, dtom, has.inventoryManager, &MockBroadcaster{}, &MockLogger{})
+	has.SetHeroLifecycle(lifecycle)
+	return has, lifecycle
+}
+
+// knockOut drops a hero to 0 body points
+func knockOut(has *HeroActionSystem, lifecycle *HeroLifecycleSystem, playerID string) {
+	player := has.turnManager.GetPlayer(playerID)
+	player.Character.TakeDamage(player.Character.CurrentBody)
+	lifecycle.CheckHero(player.EntityID)
+}
+
+func TestHeroLifecycle_AdjacentAllyRescuesDyingHero(t *testing.T) {
+	has, lifecycle := createTestLifecycle(t, HeroPhaseActive, healingPotion())
+	knockOut(has, lifecycle, "player-2")
+
+	if lifecycle.GetCondition("hero-2") != HeroConditionDying {
+		t.Fatalf("Expected hero-2 to be dying, got %q", lifecycle.GetCondition("hero-2"))
+	}
+	if eligible := has.dynamicTurnOrder.GetEligibleHeroes([]string{"player-1", "player-2"}); len(eligible) != 1 {
+		t.Errorf("Expected the dying hero to be skipped, got %v", eligible)
+	}
+
+	request := useItemRequest("player-1", "hero-1", UsePotionInstant, "potion_of_healing")
+	request.Parameters["targetEntityId"] = "hero-2"
+	result, err := has.ProcessInstantAction(request)
+	if err != nil {
+		t.Fatalf("Expected hero-1 to give hero-2 the potion, got: %v", err)
+	}
+	if result.ItemUsed.TargetID != "hero-2" {
+		t.Errorf("Expected the potion used on hero-2, got %s", result.ItemUsed.TargetID)
+	}
+
+	if lifecycle.GetCondition("hero-2") != "" || has.turnManager.GetPlayer("player-2").Character.CurrentBody != 4 {
+		t.Errorf("Expected hero-2 rescued with 4 body, got %q", lifecycle.GetCondition("hero-2"))
+	}
+	if has.dynamicTurnOrder.IsHeroOutOfPlay("player-2") {
+		t.Error("Expected the rescued hero back in the turn order")
+	}
+}
+
+func TestHeroLifecycle_UnrescuedHeroDiesAndLeavesItems(t *testing.T) {
+	has, lifecycle := createTestLifecycle(t, HeroPhaseActive, healingPotion())
+	has.inventoryManager.AddGold("hero-2", 25)
+	knockOut(has, lifecycle, "player-2")
+
+	if outcome := lifecycle.ResolveGMPhaseEnd(1); outcome != nil {
+		t.Fatalf("Expected the quest to go on with hero-1 alive, got %+v", outcome)
+	}
+	if !lifecycle.IsDead("hero-2") {
+		t.Fatal("Expected hero-2 to die at the end of the GM phase")
+	}
+	if _, onBoard := has.gameState.Entities["hero-2"]; onBoard {
+		t.Error("Expected the dead hero to leave the board")
+	}
+
+	corpses := lifecycle.GetCorpses()
+	if len(corpses) != 1 || corpses[0].Tile != (protocol.TileAddress{X: 6, Y: 5}) || corpses[0].Items != 1 || corpses[0].Gold != 25 {
+		t.Fatalf("Expected a corpse holding the potion and 25 gold at (6,5), got %+v", corpses)
+	}
+
+	// Not through the wall between the squares
+	has.gameState.BlockedWalls = map[geometry.EdgeAddress]bool{
+		{X: 6, Y: 5, Orientation: geometry.Vertical}: true,
+	}
+	expectGameErrorCode(t, lifecycle.LootCorpse("player-1", "hero-2"), "not_adjacent")
+	has.gameState.BlockedWalls = nil
+
+	if err := lifecycle.LootCorpse("player-1", "hero-2"); err != nil {
+		t.Fatalf("Expected hero-1 to recover the items, got: %v", err)
+	}
+	inventory, _ := has.inventoryManager.GetInventory("hero-1")
+	if len(inventory.Carried) != 2 || inventory.Gold != 25 {
+		t.Errorf("Expected hero-1 to carry both potions and 25 gold, got %d items and %d gold", len(inventory.Carried), inventory.Gold)
+	}
+}
+
+func TestHeroLifecycle_TotalPartyKillEndsQuest(t *testing.T) {
+	has, lifecycle := createTestLifecycle(t, GMPhase)
+	knockOut(has, lifecycle, "player-1")
+	knockOut(has, lifecycle, "player-2")
+
+	// Heroes falling during a GM phase survive until the end of the next one
+	if outcome := lifecycle.ResolveGMPhaseEnd(1); outcome != nil || lifecycle.IsDead("hero-1") {
+		t.Fatalf("Expected the heroes to still be dying, got %+v", outcome)
+	}
+
+	outcome := lifecycle.ResolveGMPhaseEnd(2)
+	if outcome == nil || outcome.Result != QuestDefeat {
+		t.Fatalf("Expected a defeat once every hero died, got %+v", outcome)
+	}
+	if has.dynamicTurnOrder.GetCurrentPhase() != QuestEndedPhase {
+		t.Errorf("Expected the quest to be over, got phase %s", has.dynamicTurnOrder.GetCurrentPhase())
+	}
+}
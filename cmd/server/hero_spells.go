@@ -0,0 +1,337 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// Spell targets (SpellCard.Target)
+const (
+	SpellTargetSelf    = "self"
+	SpellTargetHero    = "hero"
+	SpellTargetMonster = "monster"
+	SpellTargetAny     = "any"
+)
+
+// Spell effects (SpellCard.Effect)
+const (
+	SpellEffectDamage           = "damage"             // EffectValue body points; "resistible" lets the target roll to cancel
+	SpellEffectAttack           = "attack"             // EffectValue attack dice against the target's defense
+	SpellEffectHealBody         = "heal_body"          // EffectValue body points restored
+	SpellEffectHealMind         = "heal_mind"          // EffectValue mind points restored
+	SpellEffectBonusAttackDice  = "bonus_attack_dice"  // EffectValue extra dice on the target's next attack
+	SpellEffectBonusDefenseDice = "bonus_defense_dice" // EffectValue extra dice the next time the target defends
+	SpellEffectDoubleMovement   = "double_movement"    // Target rolls twice its movement dice next time
+	SpellEffectSleep            = "sleep"              // Monster sleeps unless a black shield comes up on its mind dice
+)
+
+// spellTarget is a resolved spell target: exactly one of Hero or Monster is set
+type spellTarget struct {
+	ID       string
+	Position protocol.TileAddress
+	Hero     *Player
+	Monster  *Monster
+}
+
+// processCastSpell casts a spell card from the caster's hand and discards it for the rest of the quest
+func (has *HeroActionSystem) processCastSpell(request ActionRequest, result *ActionResult) (*ActionResult, error) {
+	spellID, ok := request.Parameters["spellId"].(string)
+	if !ok {
+		result.Success = false
+		result.Message = "No spell specified"
+		return result, fmt.Errorf("missing spellId parameter")
+	}
+
+	if has.inventoryManager == nil {
+		result.Success = false
+		result.Message = "Inventory not available"
+		return result, fmt.Errorf("inventory manager not initialized")
+	}
+
+	spell, err := has.inventoryManager.GetSpell(request.EntityID, spellID)
+	if err != nil {
+		result.Success = false
+		result.Message = err.Error()
+		return result, err
+	}
+
+	targetID, _ := request.Parameters["targetId"].(string)
+	target, err := has.resolveSpellTarget(request.EntityID, spell, targetID)
+	if err != nil {
+		result.Success = false
+		result.Message = err.Error()
+		return result, err
+	}
+
+	if err := has.checkSpellReach(request.EntityID, spell, target); err != nil {
+		result.Success = false
+		result.Message = err.Error()
+		return result, err
+	}
+
+	// Consume action only once the spell is known to be castable: target, range and line of sight
+	if err := has.turnManager.ConsumeAction(); err != nil {
+		result.Success = false
+		result.Message = err.Error()
+		return result, err
+	}
+
+	effects, err := has.applySpellEffect(spell, target, result)
+	if err != nil {
+		result.Success = false
+		result.Message = err.Error()
+		return result, err
+	}
+
+	if err := has.inventoryManager.DiscardSpell(request.EntityID, spell.ID); err != nil {
+		has.logger.Printf("Warning: failed to discard spell %s for %s: %v", spell.ID, request.EntityID, err)
+	}
+
+	// Healing may save a dying hero
+	if target.Hero != nil {
+		has.checkHeroLife(target.ID)
+	}
+
+	result.Success = true
+	result.SpellEffect = &SpellEffect{
+		SpellID:    spell.ID,
+		Name:       spell.Name,
+		Effects:    effects,
+		TargetType: spell.Target,
+		TargetID:   target.ID,
+	}
+	result.Message = fmt.Sprintf("Cast %s on %s", spell.Name, target.ID)
+
+	has.logger.Printf("Player %s cast spell %s on %s (%d effects)", request.PlayerID, spell.ID, target.ID, len(effects))
+	return result, nil
+}
+
+// resolveSpellTarget finds the hero or monster a spell is aimed at and checks the spell's
+// effect can work on it. An empty targetID means the caster for spells that may target heroes.
+func (has *HeroActionSystem) resolveSpellTarget(casterID string, spell *SpellCard, targetID string) (*spellTarget, error) {
+	target, err := has.findSpellTarget(casterID, spell, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch spell.Effect {
+	case SpellEffectDamage, SpellEffectAttack, SpellEffectSleep:
+		if target.Monster == nil {
+			return nil, &GameError{Code: "invalid_target", Message: fmt.Sprintf("%s must target a monster", spell.Name)}
+		}
+	case SpellEffectHealBody, SpellEffectHealMind, SpellEffectBonusAttackDice, SpellEffectBonusDefenseDice, SpellEffectDoubleMovement:
+		if target.Hero == nil || target.Hero.Character == nil {
+			return nil, &GameError{Code: "invalid_target", Message: fmt.Sprintf("%s must target a hero", spell.Name)}
+		}
+	default:
+		return nil, &GameError{Code: "unknown_spell_effect", Message: fmt.Sprintf("spell effect %q is not supported", spell.Effect)}
+	}
+	return target, nil
+}
+
+// findSpellTarget finds the hero or monster a spell's target kind allows it to be aimed at
+func (has *HeroActionSystem) findSpellTarget(casterID string, spell *SpellCard, targetID string) (*spellTarget, error) {
+	if spell.Target == SpellTargetSelf || (targetID == "" && spell.Target != SpellTargetMonster) {
+		targetID = casterID
+	}
+	if targetID == "" {
+		return nil, &GameError{Code: "missing_target", Message: fmt.Sprintf("%s needs a target", spell.Name)}
+	}
+
+	has.gameState.Lock.Lock()
+	position, onBoard := has.gameState.Entities[targetID]
+	has.gameState.Lock.Unlock()
+
+	for _, player := range has.turnManager.GetHeroPlayers() {
+		if player.EntityID != targetID {
+			continue
+		}
+		if spell.Target == SpellTargetMonster {
+			return nil, &GameError{Code: "invalid_target", Message: fmt.Sprintf("%s can only target monsters", spell.Name)}
+		}
+		if !onBoard {
+			return nil, &GameError{Code: "invalid_target", Message: fmt.Sprintf("hero %s is not on the board", targetID)}
+		}
+		return &spellTarget{ID: targetID, Position: position, Hero: player}, nil
+	}
+
+	if has.monsterSystem != nil {
+		if monster, err := has.monsterSystem.GetMonsterByID(targetID); err == nil {
+			if spell.Target == SpellTargetHero || spell.Target == SpellTargetSelf {
+				return nil, &GameError{Code: "invalid_target", Message: fmt.Sprintf("%s can only target heroes", spell.Name)}
+			}
+			if !monster.IsAlive {
+				return nil, &GameError{Code: "invalid_target", Message: fmt.Sprintf("monster %s is already dead", targetID)}
+			}
+			return &spellTarget{ID: targetID, Position: monster.Position, Monster: monster}, nil
+		}
+	}
+
+	return nil, &GameError{Code: "invalid_target", Message: fmt.Sprintf("target %s not found", targetID)}
+}
+
+// checkSpellReach enforces the spell's range and requires line of sight to any target but the caster
+func (has *HeroActionSystem) checkSpellReach(casterID string, spell *SpellCard, target *spellTarget) error {
+	if target.ID == casterID {
+		return nil
+	}
+
+	has.gameState.Lock.Lock()
+	defer has.gameState.Lock.Unlock()
+
+	casterPos, exists := has.gameState.Entities[casterID]
+	if !exists {
+		return fmt.Errorf("caster %s not found on board", casterID)
+	}
+
+	if spell.Range > 0 {
+		distance := absInt(target.Position.X-casterPos.X) + absInt(target.Position.Y-casterPos.Y)
+		if distance > spell.Range {
+			return &GameError{Code: "out_of_range", Message: fmt.Sprintf("%s is %d squares away; %s reaches %d", target.ID, distance, spell.Name, spell.Range)}
+		}
+	}
+
+	if has.rules.checksLineOfSight() && !has.visibility.IsTileCenterVisible(has.gameState, casterPos.X, casterPos.Y, target.Position.X, target.Position.Y) {
+		return &GameError{Code: "no_line_of_sight", Message: fmt.Sprintf("%s cannot see %s", casterID, target.ID)}
+	}
+
+	return nil
+}
+
+// applySpellEffect resolves a spell card's effect against its target, which resolveSpellTarget
+// has already matched to the effect
+func (has *HeroActionSystem) applySpellEffect(spell *SpellCard, target *spellTarget, result *ActionResult) ([]Effect, error) {
+	value := spellEffectValue(spell)
+
+	switch spell.Effect {
+	case SpellEffectDamage, SpellEffectAttack, SpellEffectSleep:
+		if spell.Effect == SpellEffectSleep {
+			return has.castSleep(spell, target.Monster, result)
+		}
+		return has.castDamage(spell, target.Monster, value, result)
+
+	case SpellEffectHealBody, SpellEffectHealMind:
+		character := target.Hero.Character
+		if spell.Effect == SpellEffectHealBody {
+			character.Heal(value)
+			has.logger.Printf("%s restored %s to %d/%d body", spell.Name, target.ID, character.CurrentBody, character.BaseStats.BodyPoints)
+		} else {
+			character.RestoreMind(value)
+			has.logger.Printf("%s restored %s to %d/%d mind", spell.Name, target.ID, character.CurrentMind, character.BaseStats.MindPoints)
+		}
+		return []Effect{{Type: spell.Effect, Value: value, Description: fmt.Sprintf("Restored up to %d points", value)}}, nil
+
+	case SpellEffectBonusAttackDice, SpellEffectBonusDefenseDice, SpellEffectDoubleMovement:
+		if has.turnStateManager == nil {
+			return nil, fmt.Errorf("turn state manager not initialized")
+		}
+
+		trigger := "next_attack"
+		switch spell.Effect {
+		case SpellEffectBonusDefenseDice:
+			trigger = "next_defend"
+		case SpellEffectDoubleMovement:
+			trigger = "next_movement"
+			value = target.Hero.Character.BaseStats.MovementDice
+		}
+
+		has.turnStateManager.QueueActiveEffect(target.ID, ActiveEffect{
+			Source:     spell.ID,
+			EffectType: spell.Effect,
+			Value:      value,
+			Trigger:    trigger,
+			ExpiresOn:  spellExpiry(spell),
+		})
+		return []Effect{{Type: spell.Effect, Value: value, Description: fmt.Sprintf("Applies on %s", trigger)}}, nil
+
+	default:
+		return nil, &GameError{Code: "unknown_spell_effect", Message: fmt.Sprintf("spell effect %q is not supported", spell.Effect)}
+	}
+}
+
+// castDamage deals spell damage to a monster. "attack" spells roll attack dice against the monster's
+// defense; "resistible" damage lets the monster roll one combat die per point, each black shield cancelling one.
+// A sleeping monster cannot defend and wakes when hurt.
+func (has *HeroActionSystem) castDamage(spell *SpellCard, monster *Monster, value int, result *ActionResult) ([]Effect, error) {
+	damage := value
+
+	if spell.Effect == SpellEffectAttack {
+		result.AttackRolls = has.diceSystem.RollAttackDice(value)
+		defenseDice := monster.DefenseDice
+		if monster.IsAsleep {
+			defenseDice = 0
+		}
+		result.DefenseRolls = has.diceSystem.RollDefenseDice(defenseDice)
+		damage = CalculateCombatDamage(result.AttackRolls, result.DefenseRolls)
+	} else if spell.AttackType == "resistible" && !monster.IsAsleep {
+		result.DefenseRolls = has.diceSystem.RollDice(CombatDie, value, "spell_resist")
+		damage = CalculateCombatDamage(skullsFor(value), result.DefenseRolls)
+	}
+
+	if damage > 0 {
+		if err := has.monsterSystem.SetMonsterAsleep(monster.ID, false); err != nil {
+			return nil, err
+		}
+		if _, _, err := has.monsterSystem.ApplyDamageToMonster(monster.ID, damage); err != nil {
+			return nil, err
+		}
+	}
+
+	result.Damage = damage
+	has.logger.Printf("%s dealt %d damage to %s (%d/%d body)", spell.Name, damage, monster.ID, monster.Body, monster.MaxBody)
+	return []Effect{{Type: SpellEffectDamage, Value: damage, Description: fmt.Sprintf("%d body points of damage", damage)}}, nil
+}
+
+// castSleep puts a monster to sleep. The monster rolls one combat die per mind point and
+// resists on any black shield; undead never sleep.
+func (has *HeroActionSystem) castSleep(spell *SpellCard, monster *Monster, result *ActionResult) ([]Effect, error) {
+	if monster.SubType == "undead" {
+		has.logger.Printf("%s has no effect on undead %s", spell.Name, monster.ID)
+		return []Effect{{Type: SpellEffectSleep, Value: 0, Description: "Undead are immune"}}, nil
+	}
+
+	result.DefenseRolls = has.diceSystem.RollDice(CombatDie, monster.Mind, "spell_resist")
+	for _, roll := range result.DefenseRolls {
+		if roll.CombatResult == BlackShield {
+			has.logger.Printf("%s resisted %s", monster.ID, spell.Name)
+			return []Effect{{Type: SpellEffectSleep, Value: 0, Description: "Resisted"}}, nil
+		}
+	}
+
+	if err := has.monsterSystem.SetMonsterAsleep(monster.ID, true); err != nil {
+		return nil, err
+	}
+	return []Effect{{Type: SpellEffectSleep, Value: 1, Description: "Asleep until woken"}}, nil
+}
+
+// skullsFor builds n automatic hits, so fixed spell damage can be resolved like an attack roll
+func skullsFor(n int) []DiceRoll {
+	rolls := make([]DiceRoll, n)
+	for i := range rolls {
+		rolls[i] = DiceRoll{Die: CombatDie, Result: 6, Type: "spell", CombatResult: Skull}
+	}
+	return rolls
+}
+
+// spellEffectValue reads a spell card's numeric effect value; JSON content decodes it as float64
+func spellEffectValue(spell *SpellCard) int {
+	switch v := spell.EffectValue.(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
+
+// spellExpiry maps a spell card's duration onto ActiveEffect expiry
+func spellExpiry(spell *SpellCard) string {
+	switch spell.Duration {
+	case "end_of_turn", "end_of_quest":
+		return spell.Duration
+	default:
+		return "after_trigger"
+	}
+}
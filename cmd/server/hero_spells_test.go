@@ -0,0 +1,186 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// createTestSpellCaster gives hero-1 the given spell cards and a turn state
+func createTestSpellCaster(t *testing.T, spells ...*SpellCard) *HeroActionSystem {
+	has := createTestHeroActionSystem()
+
+	contentManager := NewContentManager(&MockLogger{})
+	for _, spell := range spells {
+		contentManager.spellCards[spell.ID] = spell
+	}
+
+	inventoryManager := NewInventoryManager(contentManager, &MockLogger{})
+	inventoryManager.InitializeHeroInventory("hero-1")
+	for _, spell := range spells {
+		if err := inventoryManager.AddSpell("hero-1", spell.ID); err != nil {
+			t.Fatalf("Failed to add spell %s: %v", spell.ID, err)
+		}
+	}
+	has.SetInventoryManager(inventoryManager)
+
+	turnStateManager := NewTurnStateManager(&MockLogger{})
+	turnStateManager.StartHeroTurn("hero-1", "player-1", protocol.TileAddress{X: 5, Y: 5})
+	has.SetTurnStateManager(turnStateManager)
+
+	return has
+}
+
+func castSpellRequest(spellID, targetID string) ActionRequest {
+	return ActionRequest{
+		PlayerID:   "player-1",
+		EntityID:   "hero-1",
+		Action:     CastSpellAction,
+		Parameters: map[string]any{"spellId": spellID, "targetId": targetID},
+	}
+}
+
+func TestCastSpell_DamageDiscardsCard(t *testing.T) {
+	has := createTestSpellCaster(t, &SpellCard{ID: "fire_of_wrath", Name: "Fire of Wrath", Target: SpellTargetMonster, Effect: SpellEffectDamage, EffectValue: float64(2)})
+
+	result, err := has.ProcessAction(castSpellRequest("fire_of_wrath", "monster-1"))
+	if err != nil {
+		t.Fatalf("Expected spell to be cast, got: %v", err)
+	}
+	if result.Damage != 2 {
+		t.Errorf("Expected 2 damage, got %d", result.Damage)
+	}
+
+	monster, _ := has.monsterSystem.GetMonsterByID("monster-1")
+	if monster.Body != 1 {
+		t.Errorf("Expected monster at 1 body, got %d", monster.Body)
+	}
+
+	inventory, _ := has.inventoryManager.GetInventory("hero-1")
+	if len(inventory.Spells) != 0 || len(inventory.DiscardedSpells) != 1 {
+		t.Errorf("Expected spell moved to discards, got %d held / %d discarded", len(inventory.Spells), len(inventory.DiscardedSpells))
+	}
+	if _, err := has.inventoryManager.GetSpell("hero-1", "fire_of_wrath"); err == nil {
+		t.Error("Expected a discarded spell to be unavailable for the rest of the quest")
+	}
+}
+
+func TestCastSpell_RejectsSpellNotHeld(t *testing.T) {
+	has := createTestSpellCaster(t)
+
+	if _, err := has.ProcessAction(castSpellRequest("fireball", "monster-1")); err == nil {
+		t.Fatal("Expected casting an unheld spell to fail")
+	}
+	if has.turnManager.GetTurnState().ActionTaken {
+		t.Error("Expected the action not to be consumed")
+	}
+}
+
+func TestCastSpell_RequiresLineOfSight(t *testing.T) {
+	has := createTestSpellCaster(t, &SpellCard{ID: "fireball", Name: "Ball of Flame", Target: SpellTargetMonster, Effect: SpellEffectDamage, EffectValue: 2})
+
+	// Wall between the hero and the goblin at (5,6)
+	has.gameState.Entities["hero-1"] = protocol.TileAddress{X: 7, Y: 6}
+	has.gameState.BlockedWalls = map[geometry.EdgeAddress]bool{
+		{X: 7, Y: 6, Orientation: geometry.Vertical}: true,
+	}
+
+	if _, err := has.ProcessAction(castSpellRequest("fireball", "monster-1")); err == nil {
+		t.Fatal("Expected casting without line of sight to fail")
+	}
+	if _, err := has.inventoryManager.GetSpell("hero-1", "fireball"); err != nil {
+		t.Errorf("Expected the spell to stay in hand, got: %v", err)
+	}
+}
+
+func TestCastSpell_WrongTargetForEffectKeepsAction(t *testing.T) {
+	has := createTestSpellCaster(t, &SpellCard{ID: "soothing_mist", Name: "Soothing Mist", Target: SpellTargetAny, Effect: SpellEffectHealBody, EffectValue: 2})
+
+	_, err := has.ProcessAction(castSpellRequest("soothing_mist", "monster-1"))
+	expectGameErrorCode(t, err, "invalid_target")
+	if has.turnManager.GetTurnState().ActionTaken {
+		t.Error("Expected the action not to be consumed")
+	}
+	if _, err := has.inventoryManager.GetSpell("hero-1", "soothing_mist"); err != nil {
+		t.Errorf("Expected the spell to stay in hand, got: %v", err)
+	}
+}
+
+func TestCastSpell_HealSelf(t *testing.T) {
+	has := createTestSpellCaster(t, &SpellCard{ID: "water_of_healing", Name: "Water of Healing", Target: SpellTargetHero, Effect: SpellEffectHealBody, EffectValue: 4})
+
+	player := has.turnManager.GetPlayer("player-1")
+	player.Character.CurrentBody = 2
+
+	if _, err := has.ProcessAction(castSpellRequest("water_of_healing", "")); err != nil {
+		t.Fatalf("Expected heal to succeed, got: %v", err)
+	}
+	if player.Character.CurrentBody != 6 {
+		t.Errorf("Expected 6 body after healing, got %d", player.Character.CurrentBody)
+	}
+}
+
+func TestCastSpell_SleepStopsMonsterUntilWoken(t *testing.T) {
+	has := createTestSpellCaster(t, &SpellCard{ID: "sleep", Name: "Sleep", Target: SpellTargetMonster, Effect: SpellEffectSleep})
+
+	if _, err := has.ProcessAction(castSpellRequest("sleep", "monster-1")); err != nil {
+		t.Fatalf("Expected sleep to succeed, got: %v", err)
+	}
+
+	monster, _ := has.monsterSystem.GetMonsterByID("monster-1")
+	if !monster.IsAsleep {
+		t.Fatal("Expected goblin with no mind points to fall asleep")
+	}
+	if err := has.monsterSystem.MoveMonster("monster-1", protocol.TileAddress{X: 7, Y: 6}); err == nil {
+		t.Error("Expected a sleeping monster to be unable to move")
+	}
+
+	if err := has.monsterSystem.SetMonsterAsleep("monster-1", false); err != nil {
+		t.Fatalf("Failed to wake monster: %v", err)
+	}
+	if err := has.monsterSystem.MoveMonster("monster-1", protocol.TileAddress{X: 7, Y: 6}); err != nil {
+		t.Errorf("Expected an awake monster to move, got: %v", err)
+	}
+}
+
+func TestCastSpell_BonusAttackDiceAppliesToNextAttack(t *testing.T) {
+	has := createTestSpellCaster(t, &SpellCard{ID: "courage", Name: "Courage", Target: SpellTargetHero, Effect: SpellEffectBonusAttackDice, EffectValue: 2})
+
+	if _, err := has.ProcessAction(castSpellRequest("courage", "hero-1")); err != nil {
+		t.Fatalf("Expected courage to succeed, got: %v", err)
+	}
+
+	// Next turn: attack with the bonus
+	has.turnManager.resetHeroTurn()
+	result, err := has.ProcessAction(ActionRequest{
+		PlayerID:   "player-1",
+		EntityID:   "hero-1",
+		Action:     AttackAction,
+		Parameters: map[string]any{"targetId": "monster-1"},
+	})
+	if err != nil {
+		t.Fatalf("Expected attack to succeed, got: %v", err)
+	}
+
+	player := has.turnManager.GetPlayer("player-1")
+	expected := player.Character.GetEffectiveAttackDice() + 2
+	if len(result.AttackRolls) != expected {
+		t.Errorf("Expected %d attack dice with courage, got %d", expected, len(result.AttackRolls))
+	}
+}
+
+func TestTurnStateManager_QueuedEffectsApplyWhenTurnStarts(t *testing.T) {
+	tsm := NewTurnStateManager(&MockLogger{})
+
+	tsm.QueueActiveEffect("hero-2", ActiveEffect{Source: "veil_of_mist", EffectType: SpellEffectDoubleMovement, Value: 2, Trigger: "next_movement", ExpiresOn: "after_trigger"})
+	if tsm.GetHeroTurnState("hero-2") != nil {
+		t.Fatal("Expected no turn state before the hero's turn")
+	}
+
+	tsm.StartHeroTurn("hero-2", "player-2", protocol.TileAddress{X: 1, Y: 1})
+	triggered := tsm.TriggerEffects("hero-2", "next_movement")
+	if len(triggered) != 1 || triggered[0].Value != 2 {
+		t.Errorf("Expected the queued effect to trigger once the turn started, got %+v", triggered)
+	}
+}
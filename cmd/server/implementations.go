@@ -13,6 +13,7 @@ import (
 type BroadcasterImpl struct {
 	hub      *ws.Hub
 	sequence SequenceGenerator
+	recorder *EventRecorder
 }
 
 func NewBroadcaster(hub *ws.Hub, sequence SequenceGenerator) *BroadcasterImpl {
@@ -22,11 +23,16 @@ func NewBroadcaster(hub *ws.Hub, sequence SequenceGenerator) *BroadcasterImpl {
 	}
 }
 
+// SetEventRecorder persists every event this broadcaster sends to the recorder's event store
+func (b *BroadcasterImpl) SetEventRecorder(recorder *EventRecorder) {
+	b.recorder = recorder
+}
+
 func (b *BroadcasterImpl) BroadcastEvent(eventType string, payload any) {
 	seq := b.sequence.Next()
 	envelope := protocol.PatchEnvelope{
 		Sequence: seq,
-		EventID:  0,
+		EventID:  recordEvent(b.recorder, eventType, payload),
 		Type:     eventType,
 		Payload:  payload,
 	}
@@ -39,6 +45,19 @@ func (b *BroadcasterImpl) BroadcastEvent(eventType string, payload any) {
 	b.hub.Broadcast(data)
 }
 
+// recordEvent persists an event if a recorder is configured and returns its event ID (0 if not stored)
+func recordEvent(recorder *EventRecorder, eventType string, payload any) int64 {
+	if recorder == nil {
+		return 0
+	}
+	eventID, err := recorder.Record(eventType, payload)
+	if err != nil {
+		log.Printf("failed to persist %s: %v", eventType, err)
+		return 0
+	}
+	return eventID
+}
+
 // LoggerImpl implements Logger using standard log package
 type LoggerImpl struct{}
 
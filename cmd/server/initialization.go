@@ -10,6 +10,11 @@ import (
 )
 
 func loadGameContent() (*geometry.BoardDefinition, *geometry.QuestDefinition, error) {
+	return loadQuestContent("")
+}
+
+// loadQuestContent loads the board and the quest in questPath, or the first quest when empty
+func loadQuestContent(questPath string) (*geometry.BoardDefinition, *geometry.QuestDefinition, error) {
 	// Detect correct path based on working directory
 	// If running from root: content/board.json
 	// If running from cmd/server (tests): ../../content/board.json
@@ -27,7 +32,9 @@ func loadGameContent() (*geometry.BoardDefinition, *geometry.QuestDefinition, er
 	}
 
 	// Load the quest
-	questPath := contentPath + "/base/quests/quest-01.json"
+	if questPath == "" {
+		questPath = contentPath + "/base/quests/quest-01.json"
+	}
 	quest, err := geometry.LoadQuestFromFile(questPath)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load quest: %v", err)
@@ -171,43 +178,25 @@ func initializeVisibleFurniture(state *GameState, furnitureSystem *FurnitureSyst
 // createMonstersFromQuest creates monster instances from quest monster placements
 func createMonstersFromQuest(quest *geometry.QuestDefinition, monsterSystem *MonsterSystem) error {
 	for _, questMonster := range quest.Monsters {
-		// Convert string type to MonsterType
-		var monsterType MonsterType
-		switch questMonster.Type {
-		case "goblin":
-			monsterType = Goblin
-		case "orc":
-			monsterType = Orc
-		case "skeleton":
-			monsterType = Skeleton
-		case "zombie":
-			monsterType = Zombie
-		case "gargoyle":
-			monsterType = Gargoyle
-		case "mummy":
-			monsterType = Mummy
-		case "dread_warrior":
-			monsterType = DreadWarrior
-		case "abomination":
-			monsterType = Abomination
-		default:
+		if !monsterSystem.HasMonsterType(MonsterType(questMonster.Type)) {
 			log.Printf("Warning: Unknown monster type '%s' in quest, skipping", questMonster.Type)
 			continue
 		}
 
-		// Create monster position
-		position := protocol.TileAddress{
-			X: questMonster.X,
-			Y: questMonster.Y,
-		}
-
-		// Spawn the monster
-		_, err := monsterSystem.SpawnMonster(monsterType, position)
+		// Spawn the monster with the quest's name and stat overrides
+		monster, err := monsterSystem.SpawnQuestMonster(questMonster)
 		if err != nil {
 			log.Printf("Warning: Failed to spawn monster %s at (%d,%d): %v", questMonster.Type, questMonster.X, questMonster.Y, err)
 			continue
 		}
 
+		// Spellcasting monsters get their quest's dread spells
+		for _, spell := range questMonster.DreadSpells {
+			if err := monsterSystem.AssignDreadSpell(monster.ID, spell.ID, spell.Uses); err != nil {
+				log.Printf("Warning: Failed to assign dread spell %s to %s: %v", spell.ID, monster.ID, err)
+			}
+		}
+
 		// log.Printf("Created monster %s (%s) at (%d,%d) in room %d",
 		// 	monster.ID, questMonster.Type, questMonster.X, questMonster.Y, questMonster.Room)
 	}
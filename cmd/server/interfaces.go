@@ -20,6 +20,12 @@ type SequenceGenerator interface {
 	Next() uint64
 }
 
+// GameSnapshotter captures and replaces the whole game state (implemented by GameManager)
+type GameSnapshotter interface {
+	CaptureSnapshot() (*GameSnapshotData, error)
+	ImportSnapshot(data *GameSnapshotData) error
+}
+
 // GameEngine interface for core game logic
 type GameEngine interface {
 	ProcessMove(req protocol.RequestMove) (*MoveResult, error)
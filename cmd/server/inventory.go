@@ -1,8 +1,12 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
 )
 
 // HeroInventory represents a hero's inventory
@@ -12,11 +16,27 @@ type HeroInventory struct {
 	Equipment map[string]*ItemCard `json:"equipment"` // slot -> equipped item
 	Carried   []*ItemCard          `json:"carried"`   // Items not equipped
 	Spells    []*SpellCard         `json:"spells"`    // Spell cards (for Wizard/Elf)
+
+	DiscardedSpells []*SpellCard   `json:"discarded_spells,omitempty"` // Cast this quest; unavailable until the next quest
+	ItemUses        map[string]int `json:"item_uses,omitempty"`        // Item ID -> uses spent this quest (charged and per-quest items)
+}
+
+// TradeGoods lists what one hero hands over in a trade
+type TradeGoods struct {
+	Items []string `json:"items,omitempty"` // Item IDs, carried or equipped; repeat an ID to hand over several copies
+	Gold  int      `json:"gold,omitempty"`
+}
+
+// IsEmpty reports whether nothing is handed over
+func (g TradeGoods) IsEmpty() bool {
+	return len(g.Items) == 0 && g.Gold == 0
 }
 
 // InventoryManager manages hero inventories
 type InventoryManager struct {
-	inventories    map[string]*HeroInventory // heroID -> inventory
+	inventories    map[string]*HeroInventory            // heroID -> inventory
+	heroes         map[string]*Player                   // heroID -> registered hero, whose equipment rules and stats are kept in step
+	ground         map[protocol.TileAddress][]*ItemCard // Items dropped or thrown onto the board, waiting to be picked up
 	contentManager *ContentManager
 	logger         Logger
 	mutex          sync.RWMutex
@@ -26,6 +46,8 @@ type InventoryManager struct {
 func NewInventoryManager(contentManager *ContentManager, logger Logger) *InventoryManager {
 	return &InventoryManager{
 		inventories:    make(map[string]*HeroInventory),
+		heroes:         make(map[string]*Player),
+		ground:         make(map[protocol.TileAddress][]*ItemCard),
 		contentManager: contentManager,
 		logger:         logger,
 	}
@@ -52,6 +74,33 @@ func (im *InventoryManager) InitializeHeroInventory(heroID string) error {
 	return nil
 }
 
+// RegisterHero links a hero to their inventory. Equipping then follows the hero's class rules,
+// and their equipment stat modifications are recomputed whenever the equipped items change.
+func (im *InventoryManager) RegisterHero(player *Player) {
+	im.mutex.Lock()
+	defer im.mutex.Unlock()
+
+	im.heroes[player.EntityID] = player
+	im.refreshEquipmentMods(player.EntityID)
+}
+
+// refreshEquipmentMods recomputes a registered hero's stat modifications from their equipment.
+// The caller must hold the mutex.
+func (im *InventoryManager) refreshEquipmentMods(heroID string) {
+	player, registered := im.heroes[heroID]
+	inventory, exists := im.inventories[heroID]
+	if !registered || !exists || player.Character == nil {
+		return
+	}
+
+	mods := equipmentMods(inventory)
+	if mods != player.Character.EquipmentMods {
+		player.Character.EquipmentMods = mods
+		im.logger.Printf("Hero %s equipment mods now %+v (attack %d, defense %d)", heroID, mods,
+			player.Character.GetEffectiveAttackDice(), player.Character.GetEffectiveDefenseDice())
+	}
+}
+
 // GetInventory retrieves a hero's inventory
 func (im *InventoryManager) GetInventory(heroID string) (*HeroInventory, error) {
 	im.mutex.RLock()
@@ -75,21 +124,147 @@ func (im *InventoryManager) AddItem(heroID string, itemID string) error {
 		return fmt.Errorf("inventory for hero %s not found", heroID)
 	}
 
-	// Check if it's equipment or artifact
+	// Check if it's equipment, an artifact or a treasure card kept by the hero (potions)
 	var item *ItemCard
 	if equip, ok := im.contentManager.GetEquipmentCard(itemID); ok {
 		item = equip
 	} else if artifact, ok := im.contentManager.GetArtifactCard(itemID); ok {
 		item = artifact
+	} else if treasure, ok := im.contentManager.GetTreasureCard(itemID); ok && treasure.Type == "potion" {
+		item = treasureCardToItem(treasure)
 	} else {
 		return fmt.Errorf("item %s not found in content", itemID)
 	}
 
 	inventory.Carried = append(inventory.Carried, item)
+	im.refreshEquipmentMods(heroID)
 	im.logger.Printf("Added item %s to hero %s inventory", itemID, heroID)
 	return nil
 }
 
+// HasItem reports whether a hero carries or has equipped an item
+func (im *InventoryManager) HasItem(heroID string, itemID string) bool {
+	im.mutex.RLock()
+	defer im.mutex.RUnlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return false
+	}
+
+	for _, item := range inventory.Equipment {
+		if item != nil && item.ID == itemID {
+			return true
+		}
+	}
+	for _, item := range inventory.Carried {
+		if item.ID == itemID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetUsableItem returns a carried or equipped item the hero can still use this quest
+func (im *InventoryManager) GetUsableItem(heroID string, itemID string) (*ItemCard, error) {
+	im.mutex.RLock()
+	defer im.mutex.RUnlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return nil, fmt.Errorf("inventory for hero %s not found", heroID)
+	}
+
+	item := findInventoryItem(inventory, itemID)
+	if item == nil {
+		return nil, &GameError{Code: "item_not_held", Message: fmt.Sprintf("hero does not carry item %s", itemID)}
+	}
+
+	if item.UsesPerQuest > 0 && inventory.ItemUses[item.ID] >= item.UsesPerQuest {
+		return nil, &GameError{Code: "item_spent", Message: fmt.Sprintf("%s has no uses left this quest", item.Name)}
+	}
+
+	return item, nil
+}
+
+// SpendItemUse records one use of an item. Items limited per quest stay in the inventory;
+// other items are discarded from Carried once their charges (Uses, default 1) run out.
+// Returns whether the item was discarded and how many uses remain.
+func (im *InventoryManager) SpendItemUse(heroID string, itemID string) (bool, int, error) {
+	im.mutex.Lock()
+	defer im.mutex.Unlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return false, 0, fmt.Errorf("inventory for hero %s not found", heroID)
+	}
+
+	item := findInventoryItem(inventory, itemID)
+	if item == nil {
+		return false, 0, &GameError{Code: "item_not_held", Message: fmt.Sprintf("hero does not carry item %s", itemID)}
+	}
+
+	if inventory.ItemUses == nil {
+		inventory.ItemUses = make(map[string]int)
+	}
+	inventory.ItemUses[item.ID]++
+	used := inventory.ItemUses[item.ID]
+
+	if item.UsesPerQuest > 0 {
+		im.logger.Printf("Hero %s used %s (%d/%d this quest)", heroID, item.ID, used, item.UsesPerQuest)
+		return false, item.UsesPerQuest - used, nil
+	}
+
+	charges := max(item.Uses, 1)
+	if used < charges {
+		im.logger.Printf("Hero %s used %s (%d charges left)", heroID, item.ID, charges-used)
+		return false, charges - used, nil
+	}
+
+	for i, carried := range inventory.Carried {
+		if carried.ID == item.ID {
+			inventory.Carried = append(inventory.Carried[:i], inventory.Carried[i+1:]...)
+			break
+		}
+	}
+	delete(inventory.ItemUses, item.ID)
+	im.refreshEquipmentMods(heroID)
+
+	im.logger.Printf("Hero %s used up %s", heroID, item.ID)
+	return true, 0, nil
+}
+
+// findInventoryItem finds an item among the carried items, then the equipped ones
+func findInventoryItem(inventory *HeroInventory, itemID string) *ItemCard {
+	for _, item := range inventory.Carried {
+		if item.ID == itemID {
+			return item
+		}
+	}
+	for _, item := range inventory.Equipment {
+		if item != nil && item.ID == itemID {
+			return item
+		}
+	}
+	return nil
+}
+
+// treasureCardToItem turns a treasure card the hero keeps, such as a potion, into an inventory item
+func treasureCardToItem(card *TreasureCard) *ItemCard {
+	return &ItemCard{
+		ID:                card.ID,
+		Name:              card.Name,
+		Category:          card.Category,
+		Type:              card.Type,
+		Subtype:           card.Subtype,
+		Uses:              card.Uses,
+		Effect:            card.Effect,
+		UsageRestrictions: card.UsageRestrictions,
+		Description:       card.Description,
+		CardImage:         card.CardImage,
+	}
+}
+
 // AddGold adds gold to a hero's inventory
 func (im *InventoryManager) AddGold(heroID string, amount int) error {
 	im.mutex.Lock()
@@ -105,6 +280,25 @@ func (im *InventoryManager) AddGold(heroID string, amount int) error {
 	return nil
 }
 
+// RemoveGold takes up to the given amount of gold from a hero (all of it when amount is 0
+// or more than they carry) and returns how much was taken
+func (im *InventoryManager) RemoveGold(heroID string, amount int) (int, error) {
+	im.mutex.Lock()
+	defer im.mutex.Unlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return 0, fmt.Errorf("inventory for hero %s not found", heroID)
+	}
+
+	if amount <= 0 || amount > inventory.Gold {
+		amount = inventory.Gold
+	}
+	inventory.Gold -= amount
+	im.logger.Printf("Removed %d gold from hero %s (total: %d)", amount, heroID, inventory.Gold)
+	return amount, nil
+}
+
 // EquipItem equips an item from carried items
 func (im *InventoryManager) EquipItem(heroID string, itemID string) error {
 	im.mutex.Lock()
@@ -136,7 +330,13 @@ func (im *InventoryManager) EquipItem(heroID string, itemID string) error {
 		return fmt.Errorf("cannot determine equipment slot for item %s", itemID)
 	}
 
-	// Check if slot already occupied
+	if player, registered := im.heroes[heroID]; registered {
+		if err := checkEquipRules(inventory, itemToEquip, player.Class); err != nil {
+			return err
+		}
+	}
+
+	// Check if slot already occupied; body armour, helmets and shields each take a single slot
 	if existing, occupied := inventory.Equipment[slot]; occupied {
 		// Unequip existing item and move to carried
 		inventory.Carried = append(inventory.Carried, existing)
@@ -149,6 +349,8 @@ func (im *InventoryManager) EquipItem(heroID string, itemID string) error {
 	// Remove from carried items
 	inventory.Carried = append(inventory.Carried[:itemIndex], inventory.Carried[itemIndex+1:]...)
 
+	im.refreshEquipmentMods(heroID)
+
 	im.logger.Printf("Equipped item %s to slot %s for hero %s", itemID, slot, heroID)
 	return nil
 }
@@ -173,11 +375,167 @@ func (im *InventoryManager) UnequipItem(heroID string, slot string) error {
 
 	// Remove from equipment
 	delete(inventory.Equipment, slot)
+	im.refreshEquipmentMods(heroID)
 
 	im.logger.Printf("Unequipped item %s from slot %s for hero %s", item.ID, slot, heroID)
 	return nil
 }
 
+// Trade atomically exchanges goods between two heroes. Nothing changes hands unless both
+// heroes hold everything they offer. Received items are carried, not equipped.
+func (im *InventoryManager) Trade(fromHeroID string, give TradeGoods, toHeroID string, receive TradeGoods) error {
+	im.mutex.Lock()
+	defer im.mutex.Unlock()
+
+	from, exists := im.inventories[fromHeroID]
+	if !exists {
+		return fmt.Errorf("inventory for hero %s not found", fromHeroID)
+	}
+	to, exists := im.inventories[toHeroID]
+	if !exists {
+		return fmt.Errorf("inventory for hero %s not found", toHeroID)
+	}
+
+	if err := checkTradeGoods(from, give); err != nil {
+		return err
+	}
+	if err := checkTradeGoods(to, receive); err != nil {
+		return err
+	}
+
+	handOver(from, to, give)
+	handOver(to, from, receive)
+
+	// Equipped items may have changed hands
+	im.refreshEquipmentMods(fromHeroID)
+	im.refreshEquipmentMods(toHeroID)
+
+	im.logger.Printf("Hero %s traded %v (%d gold) to hero %s for %v (%d gold)",
+		fromHeroID, give.Items, give.Gold, toHeroID, receive.Items, receive.Gold)
+	return nil
+}
+
+// TakeAllItems empties a hero's inventory, equipped and carried items and gold alike,
+// and returns what was taken
+func (im *InventoryManager) TakeAllItems(heroID string) ([]*ItemCard, int, error) {
+	im.mutex.Lock()
+	defer im.mutex.Unlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return nil, 0, fmt.Errorf("inventory for hero %s not found", heroID)
+	}
+
+	items := make([]*ItemCard, 0, len(inventory.Equipment)+len(inventory.Carried))
+	for _, item := range inventory.Equipment {
+		if item != nil {
+			items = append(items, item)
+		}
+	}
+	items = append(items, inventory.Carried...)
+	gold := inventory.Gold
+
+	inventory.Equipment = make(map[string]*ItemCard)
+	inventory.Carried = make([]*ItemCard, 0)
+	inventory.Gold = 0
+	im.refreshEquipmentMods(heroID)
+
+	im.logger.Printf("Took %d items and %d gold from hero %s", len(items), gold, heroID)
+	return items, gold, nil
+}
+
+// GiveItems adds items to a hero's carried items along with any gold
+func (im *InventoryManager) GiveItems(heroID string, items []*ItemCard, gold int) error {
+	im.mutex.Lock()
+	defer im.mutex.Unlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return fmt.Errorf("inventory for hero %s not found", heroID)
+	}
+
+	inventory.Carried = append(inventory.Carried, items...)
+	inventory.Gold += gold
+	im.refreshEquipmentMods(heroID)
+
+	im.logger.Printf("Gave %d items and %d gold to hero %s", len(items), gold, heroID)
+	return nil
+}
+
+// checkTradeGoods verifies a hero holds every item (counting copies) and the gold they offer
+func checkTradeGoods(inventory *HeroInventory, goods TradeGoods) error {
+	if goods.Gold < 0 || goods.Gold > inventory.Gold {
+		return &GameError{Code: "not_enough_gold", Message: fmt.Sprintf("hero %s cannot hand over %d gold", inventory.HeroID, goods.Gold)}
+	}
+
+	held := make(map[string]int)
+	for _, item := range inventory.Carried {
+		held[item.ID]++
+	}
+	for _, item := range inventory.Equipment {
+		if item != nil {
+			held[item.ID]++
+		}
+	}
+	for _, itemID := range goods.Items {
+		if held[itemID] == 0 {
+			return &GameError{Code: "item_not_held", Message: fmt.Sprintf("hero %s does not hold item %s", inventory.HeroID, itemID)}
+		}
+		held[itemID]--
+	}
+	return nil
+}
+
+// handOver moves checked goods between inventories, taking carried copies before equipped ones.
+// Uses already spent on an item travel with its last copy.
+func handOver(from, to *HeroInventory, goods TradeGoods) {
+	from.Gold -= goods.Gold
+	to.Gold += goods.Gold
+
+	for _, itemID := range goods.Items {
+		item := removeInventoryItem(from, itemID)
+		to.Carried = append(to.Carried, item)
+
+		if spent := from.ItemUses[itemID]; spent > 0 && findInventoryItem(from, itemID) == nil {
+			delete(from.ItemUses, itemID)
+			if to.ItemUses == nil {
+				to.ItemUses = make(map[string]int)
+			}
+			to.ItemUses[itemID] += spent
+		}
+	}
+}
+
+// removeInventoryItem takes one copy of an item out of the carried items, or else unequips it
+func removeInventoryItem(inventory *HeroInventory, itemID string) *ItemCard {
+	for i, item := range inventory.Carried {
+		if item.ID == itemID {
+			inventory.Carried = append(inventory.Carried[:i], inventory.Carried[i+1:]...)
+			return item
+		}
+	}
+	for slot, item := range inventory.Equipment {
+		if item != nil && item.ID == itemID {
+			delete(inventory.Equipment, slot)
+			return item
+		}
+	}
+	return nil
+}
+
+// isUsableBy reports whether a hero class may use an item; an empty UsableBy allows everyone
+func isUsableBy(item *ItemCard, class HeroClass) bool {
+	if len(item.UsableBy) == 0 {
+		return true
+	}
+	for _, allowed := range item.UsableBy {
+		if strings.EqualFold(allowed, string(class)) || strings.EqualFold(allowed, "all") {
+			return true
+		}
+	}
+	return false
+}
+
 // AddSpell adds a spell to a hero's spell list
 func (im *InventoryManager) AddSpell(heroID string, spellID string) error {
 	im.mutex.Lock()
@@ -198,6 +556,100 @@ func (im *InventoryManager) AddSpell(heroID string, spellID string) error {
 	return nil
 }
 
+// GetSpell returns a spell card the hero still holds
+func (im *InventoryManager) GetSpell(heroID string, spellID string) (*SpellCard, error) {
+	im.mutex.RLock()
+	defer im.mutex.RUnlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return nil, fmt.Errorf("inventory for hero %s not found", heroID)
+	}
+
+	for _, spell := range inventory.Spells {
+		if spell.ID == spellID {
+			return spell, nil
+		}
+	}
+
+	for _, spell := range inventory.DiscardedSpells {
+		if spell.ID == spellID {
+			return nil, &GameError{Code: "spell_discarded", Message: fmt.Sprintf("%s has already been cast this quest", spell.Name)}
+		}
+	}
+
+	return nil, &GameError{Code: "spell_not_held", Message: fmt.Sprintf("hero does not hold spell %s", spellID)}
+}
+
+// DiscardSpell moves a cast spell out of the hero's hand for the rest of the quest
+func (im *InventoryManager) DiscardSpell(heroID string, spellID string) error {
+	im.mutex.Lock()
+	defer im.mutex.Unlock()
+
+	inventory, exists := im.inventories[heroID]
+	if !exists {
+		return fmt.Errorf("inventory for hero %s not found", heroID)
+	}
+
+	for i, spell := range inventory.Spells {
+		if spell.ID == spellID {
+			inventory.Spells = append(inventory.Spells[:i], inventory.Spells[i+1:]...)
+			inventory.DiscardedSpells = append(inventory.DiscardedSpells, spell)
+			im.logger.Printf("Hero %s discarded spell %s for the rest of the quest", heroID, spellID)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("hero %s does not hold spell %s", heroID, spellID)
+}
+
+// SerializeForPersistence serializes all hero inventories to JSON
+func (im *InventoryManager) SerializeForPersistence() ([]byte, error) {
+	im.mutex.RLock()
+	defer im.mutex.RUnlock()
+
+	return json.Marshal(im.inventories)
+}
+
+// RestoreFromPersistence replaces all hero inventories with previously serialized data
+func (im *InventoryManager) RestoreFromPersistence(data []byte) error {
+	var restored map[string]*HeroInventory
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return err
+	}
+
+	im.mutex.Lock()
+	defer im.mutex.Unlock()
+
+	im.inventories = orEmpty(restored)
+	for _, inventory := range im.inventories {
+		if inventory.Equipment == nil {
+			inventory.Equipment = make(map[string]*ItemCard)
+		}
+	}
+	for heroID := range im.heroes {
+		im.refreshEquipmentMods(heroID)
+	}
+
+	im.logger.Printf("Restored %d hero inventories from persistence", len(im.inventories))
+	return nil
+}
+
+// ReplaceInventory gives a hero an inventory carried over from an earlier quest
+func (im *InventoryManager) ReplaceInventory(heroID string, inventory *HeroInventory) {
+	im.mutex.Lock()
+	defer im.mutex.Unlock()
+
+	inventory.HeroID = heroID
+	if inventory.Equipment == nil {
+		inventory.Equipment = make(map[string]*ItemCard)
+	}
+	im.inventories[heroID] = inventory
+	im.refreshEquipmentMods(heroID)
+
+	im.logger.Printf("Hero %s carries %d items and %d gold into the quest", heroID, len(inventory.Equipment)+len(inventory.Carried), inventory.Gold)
+}
+
 // determineSlot determines the equipment slot based on item type and subtype
 func determineSlot(item *ItemCard) string {
 	switch item.Type {
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// Item effects (EffectDefinition.Type)
+const (
+	ItemEffectRestorePoints = "restore_points" // "body" and/or "mind" points restored, or "full": true for both to maximum
+	ItemEffectBonusDice     = "bonus_dice"     // "dice" extra dice on the hero's next "roll": "attack" (default), "defend" or "movement"
+)
+
+// Item usage timings (UsageRestriction.Timing)
+const (
+	ItemTimingAnyTime        = "any_time"        // On the hero's turn or out of turn (default)
+	ItemTimingBeforeMovement = "before_movement" // On the hero's turn before moving
+	ItemTimingDuringCombat   = "during_combat"   // On the hero's turn or while a monster attacks the hero
+)
+
+// bonusMovementDice is the ActiveEffect type for extra movement dice from items
+const bonusMovementDice = "bonus_movement_dice"
+
+// ItemUseResult describes an item used by a hero
+type ItemUseResult struct {
+	ItemID   string   `json:"itemId"`
+	Name     string   `json:"name"`
+	TargetID string   `json:"targetId"` // The hero the item was used on
+	Effects  []Effect `json:"effects"`
+	Consumed bool     `json:"consumed"` // Discarded from the hero's inventory
+	UsesLeft int      `json:"usesLeft"`
+	Context  string   `json:"context"` // "on_turn", "during_monster_attack", "out_of_turn"
+}
+
+// useItem uses an item from the hero's inventory and applies its effect. Items may be used out
+// of turn when their timing allows it, and an unconscious hero may still use items that restore body points.
+// Items that restore points may instead be given to an adjacent hero named by "targetEntityId".
+func (has *HeroActionSystem) useItem(request InstantActionRequest, itemID string, potionOnly bool, result *ActionResult) (*ActionResult, error) {
+	fail := func(err error) (*ActionResult, error) {
+		result.Success = false
+		result.Message = err.Error()
+		return result, err
+	}
+
+	if has.inventoryManager == nil {
+		return fail(fmt.Errorf("inventory manager not initialized"))
+	}
+
+	player := has.turnManager.GetPlayer(request.PlayerID)
+	if player == nil || player.Character == nil {
+		return fail(fmt.Errorf("player %s not found", request.PlayerID))
+	}
+
+	item, err := has.inventoryManager.GetUsableItem(request.EntityID, itemID)
+	if err != nil {
+		return fail(err)
+	}
+	if potionOnly && item.Type != string(Potion) {
+		return fail(&GameError{Code: "not_a_potion", Message: fmt.Sprintf("%s is not a potion", item.Name)})
+	}
+	if item.Effect == nil {
+		return fail(&GameError{Code: "item_not_usable", Message: fmt.Sprintf("%s has no effect to use", item.Name)})
+	}
+
+	character := player.Character
+	if character.IsUnconscious() && !restoresBody(item.Effect) {
+		return fail(&GameError{Code: "hero_unconscious", Message: "an unconscious hero can only use items that restore body points"})
+	}
+
+	target := player
+	if targetID, _ := request.Parameters["targetEntityId"].(string); targetID != "" && targetID != request.EntityID {
+		if target, err = has.resolveItemTarget(player, targetID, item); err != nil {
+			return fail(err)
+		}
+	}
+
+	context, err := has.checkItemTiming(request, item, character.IsUnconscious())
+	if err != nil {
+		return fail(err)
+	}
+
+	restrictions := item.UsageRestrictions
+	if restrictions == nil {
+		restrictions = &UsageRestriction{}
+	}
+	if has.turnStateManager != nil && restrictions.MaxPerTurn > 0 {
+		itemDef := &ItemDefinition{ID: item.ID, Name: item.Name, MaxUsesPerTurn: restrictions.MaxPerTurn}
+		if ok, reason := has.turnStateManager.CanUseItem(request.EntityID, item.ID, itemDef); !ok {
+			return fail(&GameError{Code: "item_limit", Message: fmt.Sprintf("%s: %s", item.Name, reason)})
+		}
+	}
+
+	if err := validateItemEffect(item); err != nil {
+		return fail(err)
+	}
+
+	if restrictions.RequiresAction {
+		if context != "on_turn" {
+			return fail(&GameError{Code: "requires_action", Message: fmt.Sprintf("%s can only be used as the hero's action", item.Name)})
+		}
+		if err := has.turnManager.ConsumeAction(); err != nil {
+			return fail(err)
+		}
+	}
+
+	effects, err := has.applyItemEffect(item, target)
+	if err != nil {
+		return fail(err)
+	}
+
+	consumed, usesLeft, err := has.inventoryManager.SpendItemUse(request.EntityID, item.ID)
+	if err != nil {
+		return fail(err)
+	}
+
+	if has.turnStateManager != nil {
+		has.turnStateManager.RecordItemUse(request.EntityID, Activity{
+			Type:     "use_item",
+			ItemID:   item.ID,
+			ItemName: item.Name,
+			Context:  context,
+		})
+	}
+
+	result.Success = true
+	result.Message = fmt.Sprintf("Used %s", item.Name)
+	result.ItemUsed = &ItemUseResult{
+		ItemID:   item.ID,
+		Name:     item.Name,
+		TargetID: target.EntityID,
+		Effects:  effects,
+		Consumed: consumed,
+		UsesLeft: usesLeft,
+		Context:  context,
+	}
+
+	has.broadcaster.BroadcastEvent("ItemUsed", protocol.ItemUsed{
+		EntityID: request.EntityID,
+		TargetID: target.EntityID,
+		ItemID:   item.ID,
+		ItemName: item.Name,
+		Effect:   item.Effect.Type,
+		Consumed: consumed,
+		UsesLeft: usesLeft,
+		Body:     target.Character.CurrentBody,
+		Mind:     target.Character.CurrentMind,
+	})
+
+	has.logger.Printf("Player %s used %s on %s (%s, consumed: %t, uses left: %d)", request.PlayerID, item.ID, target.EntityID, context, consumed, usesLeft)
+
+	has.checkHeroLife(target.EntityID)
+	return result, nil
+}
+
+// resolveItemTarget finds the adjacent hero a healing item is given to
+func (has *HeroActionSystem) resolveItemTarget(user *Player, targetID string, item *ItemCard) (*Player, error) {
+	if item.Effect.Type != ItemEffectRestorePoints {
+		return nil, &GameError{Code: "invalid_target", Message: fmt.Sprintf("%s can only be used by its holder", item.Name)}
+	}
+	if user.Character.IsUnconscious() {
+		return nil, &GameError{Code: "hero_unconscious", Message: "an unconscious hero cannot tend to others"}
+	}
+
+	var target *Player
+	for _, player := range has.turnManager.GetHeroPlayers() {
+		if player.EntityID == targetID && player.Character != nil {
+			target = player
+		}
+	}
+	if target == nil {
+		return nil, &GameError{Code: "invalid_target", Message: fmt.Sprintf("hero %s not found", targetID)}
+	}
+
+	has.gameState.Lock.Lock()
+	userPos, userOnBoard := has.gameState.Entities[user.EntityID]
+	targetPos, targetOnBoard := has.gameState.Entities[targetID]
+	reachable := userOnBoard && targetOnBoard && areAdjacent(userPos, targetPos) && adjacentOpen(has.gameState, userPos, targetPos)
+	has.gameState.Lock.Unlock()
+
+	if !reachable {
+		return nil, &GameError{Code: "not_adjacent", Message: fmt.Sprintf("%s can only be given to an adjacent hero", item.Name)}
+	}
+	return target, nil
+}
+
+// checkItemTiming checks an item's timing window and returns the context it is used in
+func (has *HeroActionSystem) checkItemTiming(request InstantActionRequest, item *ItemCard, unconscious bool) (string, error) {
+	context := "out_of_turn"
+	onTurn := has.canPlayerAct(request.PlayerID)
+	if onTurn {
+		context = "on_turn"
+	} else if has.turnStateManager != nil {
+		if reaction := has.turnStateManager.GetCurrentReactionContext(); reaction != nil &&
+			reaction.TriggerEvent == "monster_attack" && reaction.TargetHeroID == request.EntityID {
+			context = "during_monster_attack"
+		}
+	}
+
+	// A dying hero may always reach for a healing item
+	if unconscious {
+		return context, nil
+	}
+
+	timing := ItemTimingAnyTime
+	if item.UsageRestrictions != nil && item.UsageRestrictions.Timing != "" {
+		timing = item.UsageRestrictions.Timing
+	}
+
+	switch timing {
+	case ItemTimingAnyTime:
+		return context, nil
+	case ItemTimingBeforeMovement:
+		state := has.turnManager.GetTurnState()
+		if !onTurn || state.HasMoved || state.MovementStarted {
+			return "", &GameError{Code: "wrong_timing", Message: fmt.Sprintf("%s must be used on the hero's turn before moving", item.Name)}
+		}
+	case ItemTimingDuringCombat:
+		if context == "out_of_turn" {
+			return "", &GameError{Code: "wrong_timing", Message: fmt.Sprintf("%s can only be used in combat", item.Name)}
+		}
+	default:
+		return "", &GameError{Code: "wrong_timing", Message: fmt.Sprintf("unknown item timing %q", timing)}
+	}
+	return context, nil
+}
+
+// validateItemEffect rejects effects the executor cannot apply before anything is spent
+func validateItemEffect(item *ItemCard) error {
+	switch item.Effect.Type {
+	case ItemEffectRestorePoints:
+		return nil
+	case ItemEffectBonusDice:
+		switch effectString(item.Effect, "roll") {
+		case "", "attack", "defend", "movement":
+			return nil
+		}
+		return &GameError{Code: "unknown_item_effect", Message: fmt.Sprintf("%s: unknown bonus dice roll %q", item.Name, effectString(item.Effect, "roll"))}
+	default:
+		// Artifacts are only used for their abilities limited per quest
+		if artifactEffects[item.Effect.Type].Use != nil && item.UsesPerQuest > 0 {
+			return nil
+		}
+		return &GameError{Code: "unknown_item_effect", Message: fmt.Sprintf("item effect %q is not supported", item.Effect.Type)}
+	}
+}
+
+// applyItemEffect applies an item's effect to the hero using it
+func (has *HeroActionSystem) applyItemEffect(item *ItemCard, player *Player) ([]Effect, error) {
+	character := player.Character
+
+	switch item.Effect.Type {
+	case ItemEffectRestorePoints:
+		body := effectInt(item.Effect, "body")
+		mind := effectInt(item.Effect, "mind")
+		if full, _ := item.Effect.Data["full"].(bool); full {
+			body = character.BaseStats.BodyPoints
+			mind = character.BaseStats.MindPoints
+		}
+
+		effects := make([]Effect, 0, 2)
+		if body > 0 {
+			character.Heal(body)
+			effects = append(effects, Effect{Type: "restore_body", Value: body, Description: fmt.Sprintf("Restored up to %d body points", body)})
+		}
+		if mind > 0 {
+			character.RestoreMind(mind)
+			effects = append(effects, Effect{Type: "restore_mind", Value: mind, Description: fmt.Sprintf("Restored up to %d mind points", mind)})
+		}
+		has.logger.Printf("%s restored %s to %d/%d body, %d/%d mind", item.Name, player.EntityID,
+			character.CurrentBody, character.BaseStats.BodyPoints, character.CurrentMind, character.BaseStats.MindPoints)
+		return effects, nil
+
+	case ItemEffectBonusDice:
+		if has.turnStateManager == nil {
+			return nil, fmt.Errorf("turn state manager not initialized")
+		}
+
+		effectType, trigger := SpellEffectBonusAttackDice, "next_attack"
+		switch effectString(item.Effect, "roll") {
+		case "defend":
+			effectType, trigger = SpellEffectBonusDefenseDice, "next_defend"
+		case "movement":
+			effectType, trigger = bonusMovementDice, "next_movement"
+		}
+
+		dice := max(effectInt(item.Effect, "dice"), 1)
+		has.turnStateManager.QueueActiveEffect(player.EntityID, ActiveEffect{
+			Source:     item.ID,
+			EffectType: effectType,
+			Value:      dice,
+			Trigger:    trigger,
+			ExpiresOn:  "after_trigger",
+		})
+		return []Effect{{Type: effectType, Value: dice, Description: fmt.Sprintf("Applies on %s", trigger)}}, nil
+
+	default:
+		if use := artifactEffects[item.Effect.Type].Use; use != nil {
+			return use(has, item, player)
+		}
+		return nil, &GameError{Code: "unknown_item_effect", Message: fmt.Sprintf("item effect %q is not supported", item.Effect.Type)}
+	}
+}
+
+// restoresBody reports whether an effect can bring an unconscious hero back
+func restoresBody(effect *EffectDefinition) bool {
+	if effect.Type != ItemEffectRestorePoints {
+		return false
+	}
+	full, _ := effect.Data["full"].(bool)
+	return full || effectInt(effect, "body") > 0
+}
+
+// effectInt reads a numeric effect field, which JSON decodes as float64
+func effectInt(effect *EffectDefinition, key string) int {
+	return intParam(effect.Data[key])
+}
+
+// effectString reads a string effect field
+func effectString(effect *EffectDefinition, key string) string {
+	s, _ := effect.Data[key].(string)
+	return s
+}
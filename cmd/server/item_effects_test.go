@@ -0,0 +1,194 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// createTestItemUser gives hero-1 (on turn) and hero-2 (out of turn) inventories stocked from the given cards
+func createTestItemUser(t *testing.T, items []*ItemCard, treasures []*TreasureCard) *HeroActionSystem {
+	has := createTestHeroActionSystem()
+	has.turnManager.AddPlayer(NewPlayer("player-2", "Other Hero", "hero-2", Elf))
+	has.gameState.Entities["hero-2"] = protocol.TileAddress{X: 2, Y: 2}
+
+	contentManager := NewContentManager(&MockLogger{})
+	for _, item := range items {
+		contentManager.equipmentCards[item.ID] = item
+	}
+	for _, treasure := range treasures {
+		contentManager.treasureCards[treasure.ID] = treasure
+	}
+
+	inventoryManager := NewInventoryManager(contentManager, &MockLogger{})
+	for _, heroID := range []string{"hero-1", "hero-2"} {
+		inventoryManager.InitializeHeroInventory(heroID)
+		for _, item := range items {
+			if err := inventoryManager.AddItem(heroID, item.ID); err != nil {
+				t.Fatalf("Failed to add %s: %v", item.ID, err)
+			}
+		}
+		for _, treasure := range treasures {
+			if err := inventoryManager.AddItem(heroID, treasure.ID); err != nil {
+				t.Fatalf("Failed to add %s: %v", treasure.ID, err)
+			}
+		}
+	}
+	for _, player := range has.turnManager.GetHeroPlayers() {
+		inventoryManager.RegisterHero(player)
+	}
+	has.SetInventoryManager(inventoryManager)
+
+	turnStateManager := NewTurnStateManager(&MockLogger{})
+	turnStateManager.StartHeroTurn("hero-1", "player-1", protocol.TileAddress{X: 5, Y: 5})
+	has.SetTurnStateManager(turnStateManager)
+
+	return has
+}
+
+func useItemRequest(playerID, heroID string, action InstantAction, itemID string) InstantActionRequest {
+	key := "itemId"
+	if action == UsePotionInstant {
+		key = "potionId"
+	}
+	return InstantActionRequest{
+		PlayerID:   playerID,
+		EntityID:   heroID,
+		Action:     action,
+		Parameters: map[string]any{key: itemID},
+	}
+}
+
+func healingPotion() *TreasureCard {
+	return &TreasureCard{
+		ID:     "potion_of_healing",
+		Name:   "Potion of Healing",
+		Type:   "potion",
+		Effect: &EffectDefinition{Type: ItemEffectRestorePoints, Data: map[string]any{"type": ItemEffectRestorePoints, "body": float64(4)}},
+	}
+}
+
+func TestUseItem_HealingPotionIsDrunkAndDiscarded(t *testing.T) {
+	has := createTestItemUser(t, nil, []*TreasureCard{healingPotion()})
+	player := has.turnManager.GetPlayer("player-1")
+	player.Character.TakeDamage(5)
+
+	result, err := has.ProcessInstantAction(useItemRequest("player-1", "hero-1", UsePotionInstant, "potion_of_healing"))
+	if err != nil {
+		t.Fatalf("Expected potion to be drunk, got: %v", err)
+	}
+	if !result.ItemUsed.Consumed {
+		t.Error("Expected the potion to be used up")
+	}
+	if player.Character.CurrentBody != player.Character.BaseStats.BodyPoints-1 {
+		t.Errorf("Expected 4 body restored, got %d/%d", player.Character.CurrentBody, player.Character.BaseStats.BodyPoints)
+	}
+	if has.inventoryManager.HasItem("hero-1", "potion_of_healing") {
+		t.Error("Expected the potion to leave the inventory")
+	}
+}
+
+func TestUseItem_ChargesRespectMaxPerTurn(t *testing.T) {
+	has := createTestItemUser(t, []*ItemCard{{
+		ID:                "heroic_brew",
+		Name:              "Heroic Brew",
+		Type:              "potion",
+		Uses:              2,
+		Effect:            &EffectDefinition{Type: ItemEffectBonusDice, Data: map[string]any{"dice": float64(2), "roll": "attack"}},
+		UsageRestrictions: &UsageRestriction{MaxPerTurn: 1},
+	}}, nil)
+	request := useItemRequest("player-1", "hero-1", UseItemInstant, "heroic_brew")
+
+	result, err := has.ProcessInstantAction(request)
+	if err != nil {
+		t.Fatalf("Expected first use to succeed, got: %v", err)
+	}
+	if result.ItemUsed.Consumed || result.ItemUsed.UsesLeft != 1 {
+		t.Errorf("Expected one charge left, got %+v", result.ItemUsed)
+	}
+	if effects := has.turnStateManager.TriggerEffects("hero-1", "next_attack"); len(effects) != 1 || effects[0].Value != 2 {
+		t.Errorf("Expected +2 attack dice on the next attack, got %+v", effects)
+	}
+
+	if _, err := has.ProcessInstantAction(request); err == nil {
+		t.Fatal("Expected a second use this turn to fail")
+	}
+	if !has.inventoryManager.HasItem("hero-1", "heroic_brew") {
+		t.Error("Expected the brew to keep its last charge")
+	}
+}
+
+func TestUseItem_UsesPerQuestAreCounted(t *testing.T) {
+	has := createTestItemUser(t, []*ItemCard{{
+		ID:           "elixir_flask",
+		Name:         "Elixir Flask",
+		Type:         "equipment",
+		UsesPerQuest: 1,
+		Effect:       &EffectDefinition{Type: ItemEffectRestorePoints, Data: map[string]any{"mind": float64(2)}},
+	}}, nil)
+	request := useItemRequest("player-1", "hero-1", UseItemInstant, "elixir_flask")
+
+	if _, err := has.ProcessInstantAction(request); err != nil {
+		t.Fatalf("Expected first use to succeed, got: %v", err)
+	}
+
+	// A new turn lifts per-turn limits but not per-quest ones
+	has.turnStateManager.AdvanceTurn()
+	_, err := has.ProcessInstantAction(request)
+	if gameErr, ok := err.(*GameError); !ok || gameErr.Code != "item_spent" {
+		t.Errorf("Expected item_spent, got: %v", err)
+	}
+	if !has.inventoryManager.HasItem("hero-1", "elixir_flask") {
+		t.Error("Expected a per-quest item to stay in the inventory")
+	}
+}
+
+func TestUseItem_UnconsciousHeroCanOnlyHeal(t *testing.T) {
+	has := createTestItemUser(t, []*ItemCard{{
+		ID:     "strength_potion",
+		Name:   "Potion of Strength",
+		Type:   "potion",
+		Effect: &EffectDefinition{Type: ItemEffectBonusDice, Data: map[string]any{"dice": float64(2)}},
+	}}, []*TreasureCard{healingPotion()})
+	other := has.turnManager.GetPlayer("player-2")
+	other.Character.TakeDamage(other.Character.CurrentBody)
+
+	if _, err := has.ProcessInstantAction(useItemRequest("player-2", "hero-2", UsePotionInstant, "strength_potion")); err == nil {
+		t.Fatal("Expected an unconscious hero not to drink a strength potion")
+	}
+
+	result, err := has.ProcessInstantAction(useItemRequest("player-2", "hero-2", UsePotionInstant, "potion_of_healing"))
+	if err != nil {
+		t.Fatalf("Expected an unconscious hero to drink a healing potion out of turn, got: %v", err)
+	}
+	if result.ItemUsed.Context != "out_of_turn" || other.Character.IsUnconscious() {
+		t.Errorf("Expected hero-2 healed out of turn, got context %s and %d body", result.ItemUsed.Context, other.Character.CurrentBody)
+	}
+}
+
+func TestUseItem_CombatItemsDuringMonsterAttack(t *testing.T) {
+	has := createTestItemUser(t, []*ItemCard{{
+		ID:                "shield_tonic",
+		Name:              "Shield Tonic",
+		Type:              "potion",
+		Effect:            &EffectDefinition{Type: ItemEffectBonusDice, Data: map[string]any{"dice": float64(1), "roll": "defend"}},
+		UsageRestrictions: &UsageRestriction{Timing: ItemTimingDuringCombat},
+	}}, nil)
+	request := useItemRequest("player-2", "hero-2", UseItemInstant, "shield_tonic")
+
+	if _, err := has.ProcessInstantAction(request); err == nil {
+		t.Fatal("Expected a combat item to be unusable out of turn outside an attack")
+	}
+
+	has.turnStateManager.PushReactionContext(ReactionContext{TriggerEvent: "monster_attack", TargetHeroID: "hero-2"})
+	result, err := has.ProcessInstantAction(request)
+	if err != nil {
+		t.Fatalf("Expected the tonic to be usable while attacked, got: %v", err)
+	}
+	if result.ItemUsed.Context != "during_monster_attack" {
+		t.Errorf("Expected during_monster_attack context, got %s", result.ItemUsed.Context)
+	}
+	if effects := has.turnStateManager.TriggerEffects("hero-2", "next_defend"); len(effects) != 1 {
+		t.Errorf("Expected a pending defense bonus, got %+v", effects)
+	}
+}
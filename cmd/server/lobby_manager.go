@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"sync"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
 )
 
 // PlayerRole represents the role a player has chosen in the lobby
@@ -29,6 +31,7 @@ type LobbyState struct {
 	CanStartGame    bool                        `json:"canStartGame"`
 	GameStarted     bool                        `json:"gameStarted"`
 	AvailableHeroes []string                    `json:"availableHeroes"`
+	HouseRules      protocol.HouseRules         `json:"houseRules"`
 }
 
 // LobbyManager manages the pre-game lobby where players join and select roles
@@ -37,6 +40,7 @@ type LobbyManager struct {
 	contentManager *ContentManager
 	mutex          sync.RWMutex
 	gameStarted    bool
+	houseRules     protocol.HouseRules // Chosen by the GM before the game starts
 }
 
 // NewLobbyManager creates a new lobby manager
@@ -151,6 +155,32 @@ func (lm *LobbyManager) SetPlayerReady(playerID string, isReady bool) error {
 	return nil
 }
 
+// SetHouseRules sets the house rules the game will be played with; only the GM may choose them
+func (lm *LobbyManager) SetHouseRules(playerID string, rules protocol.HouseRules) error {
+	lm.mutex.Lock()
+	defer lm.mutex.Unlock()
+
+	if lm.gameStarted {
+		return fmt.Errorf("game has already started")
+	}
+
+	player, exists := lm.players[playerID]
+	if !exists || player.Role != RoleGameMaster {
+		return fmt.Errorf("only the game master can choose house rules")
+	}
+
+	lm.houseRules = rules
+	return nil
+}
+
+// GetHouseRules returns the house rules chosen in the lobby
+func (lm *LobbyManager) GetHouseRules() protocol.HouseRules {
+	lm.mutex.RLock()
+	defer lm.mutex.RUnlock()
+
+	return lm.houseRules
+}
+
 // CanStartGame checks if the game can be started
 func (lm *LobbyManager) CanStartGame() bool {
 	lm.mutex.RLock()
@@ -209,6 +239,7 @@ func (lm *LobbyManager) GetLobbyState() *LobbyState {
 		CanStartGame:    lm.CanStartGame(),
 		GameStarted:     lm.gameStarted,
 		AvailableHeroes: availableHeroes,
+		HouseRules:      lm.houseRules,
 	}
 }
 
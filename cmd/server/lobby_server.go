@@ -82,6 +82,9 @@ func (ls *LobbyServer) HandleMessage(conn *websocket.Conn, data []byte) error {
 	case "RequestToggleReady":
 		return ls.handleToggleReady(playerID, env.Payload)
 
+	case "RequestSetHouseRules":
+		return ls.handleSetHouseRules(playerID, env.Payload)
+
 	case "RequestStartGame":
 		return ls.handleStartGame(playerID)
 
@@ -146,6 +149,28 @@ func (ls *LobbyServer) handleToggleReady(playerID string, payload json.RawMessag
 	return nil
 }
 
+// handleSetHouseRules processes the GM's choice of house rules
+func (ls *LobbyServer) handleSetHouseRules(playerID string, payload json.RawMessage) error {
+	var req protocol.RequestSetHouseRules
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return err
+	}
+
+	log.Printf("Player %s setting house rules: %+v", playerID, req.Rules)
+
+	if err := ls.lobby.SetHouseRules(playerID, req.Rules); err != nil {
+		return err
+	}
+
+	ls.broadcastLobbyState()
+	return nil
+}
+
+// GetHouseRules returns the house rules chosen in the lobby
+func (ls *LobbyServer) GetHouseRules() protocol.HouseRules {
+	return ls.lobby.GetHouseRules()
+}
+
 // handleStartGame processes a game start request
 func (ls *LobbyServer) handleStartGame(playerID string) error {
 	log.Printf("Player %s requesting game start", playerID)
@@ -200,6 +225,7 @@ func (ls *LobbyServer) broadcastLobbyState() {
 		CanStartGame:    lobbyState.CanStartGame,
 		GameStarted:     lobbyState.GameStarted,
 		AvailableHeroes: lobbyState.AvailableHeroes,
+		HouseRules:      lobbyState.HouseRules,
 	}
 
 	log.Printf("Broadcasting lobby state: %d players, canStart=%v", len(players), lobbyState.CanStartGame)
@@ -7,7 +7,6 @@ import (
 	"log"
 	"net/http"
 	"os"
-	"sync/atomic"
 
 	"github.com/coder/websocket"
 
@@ -82,6 +81,30 @@ func mainWithGameManager() {
 		log.Fatalf("Failed to create monsters from quest: %v", err)
 	}
 
+	// Persist events, replaying any that were logged before a restart
+	eventStoreConfig := GetEventStoreConfigFromEnv()
+	eventStore, err := OpenEventStore(eventStoreConfig)
+	if err != nil {
+		log.Fatalf("Failed to open event store: %v", err)
+	}
+	if eventStore != nil {
+		gameID := eventStoreConfig.GameID
+		if gameID == "" {
+			gameID = NewGameID()
+		}
+		game := GameRecord{ID: gameID, MapID: board.ID, QuestID: quest.ID, DMUserID: "gm"}
+		if _, err := gameManager.EnableEventLog(eventStore, game); err != nil {
+			log.Fatalf("Failed to enable event log: %v", err)
+		}
+	}
+
+	// Named save slots for stopping and resuming a session
+	saveSlots, err := NewSaveSlotStore(GetSaveDirectoryFromEnv())
+	if err != nil {
+		log.Fatalf("Failed to open save slots: %v", err)
+	}
+	gameManager.SetSaveSlotStore(saveSlots)
+
 	state, _, err := initializeGameState(board, quest, furnitureSystem)
 	if err != nil {
 		log.Fatalf("Failed to initialize game state: %v", err)
@@ -161,7 +184,7 @@ func mainWithGameManager() {
 				}
 				// Use hardcoded player ID for direct game mode (legacy)
 				playerID := "player-1"
-				handleEnhancedWebSocketMessage(data, gameManager, state, hub, sequenceGen, quest, furnitureSystem, playerID)
+				handleEnhancedWebSocketMessage(data, gameManager, state, quest, furnitureSystem, playerID)
 			}
 		}(conn)
 	})
@@ -281,24 +304,13 @@ func mainWithGameManager() {
 		monsters := make([]protocol.MonsterLite, 0)
 		allMonsters := gameManager.GetMonsters()
 		for _, monster := range allMonsters {
-			monsterItem := protocol.MonsterLite{
-				ID:          monster.ID,
-				Type:        string(monster.Type),
-				Tile:        monster.Position,
-				Body:        monster.Body,
-				MaxBody:     monster.MaxBody,
-				Mind:        monster.Mind,
-				MaxMind:     monster.MaxMind,
-				AttackDice:  monster.AttackDice,
-				DefenseDice: monster.DefenseDice,
-				IsVisible:   monster.IsVisible,
-				IsAlive:     monster.IsAlive,
-			}
+			monsterItem := toMonsterLite(monster)
 			monsters = append(monsters, monsterItem)
 		}
 
 		// Get hero turn states
 		heroTurnStates := gameManager.GetHeroTurnStatesForSnapshot()
+		heroStats := gameManager.GetHeroStatsForSnapshot()
 
 		// Get dynamic turn order state
 		heroesActed := dynamicTurnOrder.GetHeroesActedThisCycle()
@@ -311,7 +323,7 @@ func mainWithGameManager() {
 			MapID:             "dev-map",
 			PackID:            "dev-pack@v1",
 			Turn:              turnState.TurnNumber,
-			LastEventID:       0,
+			LastEventID:       gameManager.GetLastEventID(),
 			MapWidth:          state.Segment.Width,
 			MapHeight:         state.Segment.Height,
 			RegionsCount:      state.RegionMap.RegionsCount,
@@ -341,6 +353,16 @@ func mainWithGameManager() {
 			Furniture:        furniture,
 			Monsters:         monsters,
 			HeroTurnStates:   heroTurnStates,
+			HeroStats:        heroStats,
+			Corpses:          gameManager.GetHeroLifecycle().GetCorpses(),
+			GroundItems:      gameManager.GetInventoryManager().GetGroundItems(),
+			SearchedRooms:    gameManager.GetTurnStateManager().GetSearchLedger(),
+			HouseRules:       gameManager.GetRuleSet().ToLite(),
+			SpellSelection:   gameManager.GetSpellSelectionForSnapshot(),
+			QuestEnded:       gameManager.GetQuestEndedForSnapshot(),
+			QuestProgress:    gameManager.GetQuestProgressForSnapshot(),
+			Campaign:         gameManager.GetCampaignForSnapshot(),
+			Armoury:          gameManager.GetArmouryForSnapshot(),
 			VisibleRegionIDs: visibleNow,
 			CorridorRegionID: state.CorridorRegion,
 			KnownRegionIDs:   known,
@@ -442,12 +464,13 @@ func mainWithGameManager() {
 		// Get hero turn states for snapshot
 		heroTurnStates := gameManager.GetHeroTurnStatesForSnapshot()
 		log.Printf("DEBUG: Snapshot generation - got %d hero turn states", len(heroTurnStates))
+		heroStats := gameManager.GetHeroStatsForSnapshot()
 
 		s := protocol.Snapshot{
 			MapID:             "dev-map",
 			PackID:            "dev-pack@v1",
 			Turn:              turnState.TurnNumber,
-			LastEventID:       0,
+			LastEventID:       gameManager.GetLastEventID(),
 			MapWidth:          state.Segment.Width,
 			MapHeight:         state.Segment.Height,
 			RegionsCount:      state.RegionMap.RegionsCount,
@@ -477,6 +500,16 @@ func mainWithGameManager() {
 			Furniture:        furniture,
 			Monsters:         monsters,
 			HeroTurnStates:   heroTurnStates,
+			HeroStats:        heroStats,
+			Corpses:          gameManager.GetHeroLifecycle().GetCorpses(),
+			GroundItems:      gameManager.GetInventoryManager().GetGroundItems(),
+			SearchedRooms:    gameManager.GetTurnStateManager().GetSearchLedger(),
+			HouseRules:       gameManager.GetRuleSet().ToLite(),
+			SpellSelection:   gameManager.GetSpellSelectionForSnapshot(),
+			QuestEnded:       gameManager.GetQuestEndedForSnapshot(),
+			QuestProgress:    gameManager.GetQuestProgressForSnapshot(),
+			Campaign:         gameManager.GetCampaignForSnapshot(),
+			Armoury:          gameManager.GetArmouryForSnapshot(),
 			VisibleRegionIDs: visibleNow,
 			CorridorRegionID: state.CorridorRegion,
 			KnownRegionIDs:   known,
@@ -496,7 +529,7 @@ func mainWithGameManager() {
 }
 
 // Enhanced WebSocket message handler supporting both legacy and new actions
-func handleEnhancedWebSocketMessage(data []byte, gameManager *GameManager, state *GameState, hub *ws.Hub, sequenceGen *SequenceGeneratorImpl, quest *geometry.QuestDefinition, furnitureSystem *FurnitureSystem, playerID string) {
+func handleEnhancedWebSocketMessage(data []byte, gameManager *GameManager, state *GameState, quest *geometry.QuestDefinition, furnitureSystem *FurnitureSystem, playerID string) {
 	log.Printf("DEBUG: Received WebSocket message from player %s: %s", playerID, string(data))
 	var env protocol.IntentEnvelope
 	if err := json.Unmarshal(data, &env); err != nil {
@@ -505,6 +538,15 @@ func handleEnhancedWebSocketMessage(data []byte, gameManager *GameManager, state
 	}
 	log.Printf("DEBUG: Message type: %s from player %s", env.Type, playerID)
 
+	broadcaster := gameManager.GetBroadcaster()
+
+	// Snapshot whatever the message changed that the event log cannot replay, once the
+	// objective check below has run
+	defer gameManager.SaveSnapshotIfDue()
+
+	// Any message may kill a monster, pick up an item, move a hero or end a turn cycle
+	defer gameManager.CheckQuestObjectives()
+
 	switch env.Type {
 	case "RequestMove":
 		var req protocol.RequestMove
@@ -513,11 +555,10 @@ func handleEnhancedWebSocketMessage(data []byte, gameManager *GameManager, state
 		}
 
 		// Use legacy movement system for now (unlimited movement compatibility)
-		seqPtr := &sequenceGen.counter
 		monsterSystem := gameManager.GetMonsterSystem()
 		// Use GameManager's state to ensure consistency with door toggles
 		gameManagerState := gameManager.GetGameState()
-		handleRequestMove(req, gameManagerState, hub, seqPtr, quest, furnitureSystem, monsterSystem)
+		handleRequestMove(req, gameManagerState, broadcaster, quest, furnitureSystem, monsterSystem, gameManager.GetTrapSystem())
 
 	case "MovementRequest":
 		// New turn-based movement system
@@ -537,27 +578,13 @@ func handleEnhancedWebSocketMessage(data []byte, gameManager *GameManager, state
 				"action":  "movement",
 				"message": err.Error(),
 			}
-			patch := protocol.PatchEnvelope{
-				Sequence: sequenceGen.Next(),
-				EventID:  int64(sequenceGen.Next()),
-				Type:     "HeroActionResult",
-				Payload:  errorResult,
-			}
-			data, _ := json.Marshal(patch)
-			hub.Broadcast(data)
+			broadcaster.BroadcastEvent("HeroActionResult", errorResult)
 			return
 		}
 		log.Printf("DEBUG: ProcessMovement returned result: %+v", result)
 
 		// Broadcast the movement result
-		patch := protocol.PatchEnvelope{
-			Sequence: sequenceGen.Next(),
-			EventID:  int64(sequenceGen.Next()),
-			Type:     "HeroActionResult",
-			Payload:  result,
-		}
-		data, _ := json.Marshal(patch)
-		hub.Broadcast(data)
+		broadcaster.BroadcastEvent("HeroActionResult", result)
 
 	case "RequestToggleDoor":
 		var req protocol.RequestToggleDoor
@@ -590,30 +617,13 @@ func handleEnhancedWebSocketMessage(data []byte, gameManager *GameManager, state
 				"action":  req.Action,
 				"message": err.Error(),
 			}
-			patch := protocol.PatchEnvelope{
-				Sequence: sequenceGen.Next(),
-				EventID:  int64(sequenceGen.Next()),
-				Type:     "HeroActionResult",
-				Payload:  errorResult,
-			}
-			data, _ := json.Marshal(patch)
-			log.Printf("DEBUG: Broadcasting HeroActionError: %s", string(data))
-			hub.Broadcast(data)
+			broadcaster.BroadcastEvent("HeroActionResult", errorResult)
 			return
 		}
 		log.Printf("DEBUG: ProcessHeroAction returned result: %+v", result)
 
 		// Broadcast the action result
-		patch := protocol.PatchEnvelope{
-			Sequence: sequenceGen.Next(),
-			EventID:  int64(sequenceGen.Next()),
-			Type:     "HeroActionResult",
-			Payload:  result,
-		}
-
-		data, _ := json.Marshal(patch)
-		log.Printf("DEBUG: Broadcasting HeroActionResult: %s", string(data))
-		hub.Broadcast(data)
+		broadcaster.BroadcastEvent("HeroActionResult", result)
 
 	case "MonsterAction":
 		// New monster action system (GameMaster only)
@@ -630,15 +640,7 @@ func handleEnhancedWebSocketMessage(data []byte, gameManager *GameManager, state
 		}
 
 		// Broadcast the action result
-		patch := protocol.PatchEnvelope{
-			Sequence: sequenceGen.Next(),
-			EventID:  int64(sequenceGen.Next()),
-			Type:     "MonsterActionResult",
-			Payload:  result,
-		}
-
-		data, _ := json.Marshal(patch)
-		hub.Broadcast(data)
+		broadcaster.BroadcastEvent("MonsterActionResult", result)
 
 	case "PassGMTurn":
 		// Debug function to pass GM turn and return to hero turn
@@ -651,16 +653,7 @@ func handleEnhancedWebSocketMessage(data []byte, gameManager *GameManager, state
 
 		// Broadcast new turn state
 		turnState := gameManager.GetTurnState()
-		patch := protocol.PatchEnvelope{
-			Sequence: sequenceGen.Next(),
-			EventID:  int64(sequenceGen.Next()),
-			Type:     "TurnStateChanged",
-			Payload:  turnState,
-		}
-
-		data, _ := json.Marshal(patch)
-		log.Printf("DEBUG: Broadcasting TurnStateChanged after PassGMTurn: %s", string(data))
-		hub.Broadcast(data)
+		broadcaster.BroadcastEvent("TurnStateChanged", turnState)
 
 	case "EndTurn":
 		// End turn request
@@ -671,15 +664,7 @@ func handleEnhancedWebSocketMessage(data []byte, gameManager *GameManager, state
 
 		// Broadcast new turn state
 		turnState := gameManager.GetTurnState()
-		patch := protocol.PatchEnvelope{
-			Sequence: sequenceGen.Next(),
-			EventID:  int64(sequenceGen.Next()),
-			Type:     "TurnStateChanged",
-			Payload:  turnState,
-		}
-
-		data, _ := json.Marshal(patch)
-		hub.Broadcast(data)
+		broadcaster.BroadcastEvent("TurnStateChanged", turnState)
 
 	case "InstantActionRequest":
 		// Instant action system
@@ -699,30 +684,13 @@ func handleEnhancedWebSocketMessage(data []byte, gameManager *GameManager, state
 				"action":  req.Action,
 				"message": err.Error(),
 			}
-			patch := protocol.PatchEnvelope{
-				Sequence: sequenceGen.Next(),
-				EventID:  int64(sequenceGen.Next()),
-				Type:     "HeroActionResult",
-				Payload:  errorResult,
-			}
-			data, _ := json.Marshal(patch)
-			log.Printf("DEBUG: Broadcasting InstantActionError: %s", string(data))
-			hub.Broadcast(data)
+			broadcaster.BroadcastEvent("HeroActionResult", errorResult)
 			return
 		}
 		log.Printf("DEBUG: ProcessInstantAction returned result: %+v", result)
 
 		// Broadcast the action result
-		patch := protocol.PatchEnvelope{
-			Sequence: sequenceGen.Next(),
-			EventID:  int64(sequenceGen.Next()),
-			Type:     "HeroActionResult",
-			Payload:  result,
-		}
-
-		data, _ := json.Marshal(patch)
-		log.Printf("DEBUG: Broadcasting InstantActionResult: %s", string(data))
-		hub.Broadcast(data)
+		broadcaster.BroadcastEvent("HeroActionResult", result)
 
 	case "RequestJoinLobby":
 		// Lobby: Player joins
@@ -742,12 +710,11 @@ func handleEnhancedWebSocketMessage(data []byte, gameManager *GameManager, state
 
 	default:
 		// Unknown message type - fall back to legacy handler
-		seqPtr := &sequenceGen.counter
 		monsterSystem := gameManager.GetMonsterSystem()
 		// Use GameManager's state to ensure consistency
 		gameManagerState := gameManager.GetGameState()
 		// Use the playerID parameter passed to this function
-		handleWebSocketMessage(data, gameManagerState, hub, seqPtr, quest, furnitureSystem, monsterSystem, gameManager, playerID)
+		handleWebSocketMessage(data, gameManagerState, broadcaster, quest, furnitureSystem, monsterSystem, gameManager, playerID)
 	}
 }
 
@@ -822,23 +789,6 @@ func buildBlockedTiles(quest *geometry.QuestDefinition) map[protocol.TileAddress
 	return blockedTiles
 }
 
-func broadcastEvent(hub *ws.Hub, sequence *uint64, eventType string, payload any) {
-	seq := atomic.AddUint64(sequence, 1)
-	envelope := protocol.PatchEnvelope{
-		Sequence: seq,
-		EventID:  0,
-		Type:     eventType,
-		Payload:  payload,
-	}
-	data, err := json.Marshal(envelope)
-	if err != nil {
-		log.Printf("failed to marshal %s: %v", eventType, err)
-		return
-	}
-	log.Printf("broadcasting %s", eventType)
-	hub.Broadcast(data)
-}
-
 func getVisibleBlockingWalls(state *GameState, hero protocol.TileAddress, quest *geometry.QuestDefinition) ([]protocol.BlockingWallLite, []protocol.BlockingWallLite) {
 	log.Printf("=== Checking blocking wall visibility from hero at (%d,%d) ===", hero.X, hero.Y)
 
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"sync"
+	"sync/atomic"
 
 	"github.com/coder/websocket"
 
@@ -87,6 +88,15 @@ func getStartingPositionsFromQuest(quest *geometry.QuestDefinition, board *geome
 	return []protocol.TileAddress{}
 }
 
+// lobbyGame is one quest's game in lobby mode, swapped as a whole when the campaign moves on
+type lobbyGame struct {
+	gameManager     *GameManager
+	state           *GameState
+	quest           *geometry.QuestDefinition
+	board           *geometry.BoardDefinition
+	furnitureSystem *FurnitureSystem
+}
+
 // mainWithLobby starts the server in lobby mode, where players join before the game starts
 func mainWithLobby() {
 	log.Printf("=== Starting HeroQuest Server in Lobby Mode ===")
@@ -105,35 +115,51 @@ func mainWithLobby() {
 		log.Fatalf("Failed to load campaign content: %v", err)
 	}
 
+	// Open the event store (disabled unless EVENT_STORE is set)
+	eventStoreConfig := GetEventStoreConfigFromEnv()
+	eventStore, err := OpenEventStore(eventStoreConfig)
+	if err != nil {
+		log.Fatalf("Failed to open event store: %v", err)
+	}
+	gameID := eventStoreConfig.GameID
+	if gameID == "" {
+		gameID = NewGameID()
+	}
+	saveSlots, err := NewSaveSlotStore(GetSaveDirectoryFromEnv())
+	if err != nil {
+		log.Fatalf("Failed to open save slots: %v", err)
+	}
+
 	// Create lobby server
 	lobbyServer := NewLobbyServer(contentManager, sequenceGen)
 
-	// Game state (will be initialized after lobby phase)
-	var gameManager *GameManager
-	var state *GameState
-	var quest *geometry.QuestDefinition
-	var board *geometry.BoardDefinition
-	var furnitureSystem *FurnitureSystem
+	// The quest being played (nil until the lobby phase ends). startQuest builds each quest
+	// aside and swaps it in whole, so connections always see one complete game.
+	var current atomic.Pointer[lobbyGame]
 
 	// Flag to track if game has started
 	gameStarted := false
 	var gameMasterPlayerID string // Track GM player ID for reliable role checking
 
-	// Set game start handler
-	lobbyServer.SetGameStartHandler(func(gameMasterID string, heroPlayers map[string]string) error {
-		log.Printf("Initializing game with GM=%s and heroes=%v", gameMasterID, heroPlayers)
-		gameMasterPlayerID = gameMasterID // Store GM ID for persistent role checking
+	// The party's progress through the campaign, set up when the game starts
+	var campaign *CampaignSession
 
-		// Load game content
-		loadedBoard, loadedQuest, err := loadGameContent()
+	// startQuest replaces the running game with one of the campaign's quests, bringing in the party.
+	// The running game is left untouched unless the new one is built in full.
+	var startQuest func(start CampaignQuestStart) error
+	startQuest = func(start CampaignQuestStart) error {
+		// Load game content; a campaign without quests plays the first quest
+		questPath := ""
+		if start.Quest.Path != "" {
+			questPath = contentManager.GetQuestPath(start.Quest)
+		}
+		board, quest, err := loadQuestContent(questPath)
 		if err != nil {
 			return fmt.Errorf("failed to load game content: %w", err)
 		}
-		board = loadedBoard
-		quest = loadedQuest
 
 		// Initialize furniture system
-		furnitureSystem = NewFurnitureSystem(log.New(os.Stdout, "", log.LstdFlags))
+		furnitureSystem := NewFurnitureSystem(log.New(os.Stdout, "", log.LstdFlags))
 		if err := furnitureSystem.LoadFurnitureDefinitions("content"); err != nil {
 			log.Printf("Warning: Failed to load furniture definitions: %v", err)
 		}
@@ -145,13 +171,14 @@ func mainWithLobby() {
 		broadcaster := NewBroadcaster(hub, sequenceGen)
 
 		// Initialize game manager
-		gameManager, err = NewGameManagerWithFurniture(broadcaster, logger, sequenceGen, debugConfig, furnitureSystem, quest)
+		gameManager, err := NewGameManagerWithFurniture(broadcaster, logger, sequenceGen, debugConfig, furnitureSystem, quest)
 		if err != nil {
 			return fmt.Errorf("failed to initialize game manager: %w", err)
 		}
+		gameManager.SetRuleSet(lobbyServer.GetHouseRules())
 
 		// Initialize game state
-		state, _, err = initializeGameState(board, quest, furnitureSystem)
+		state, _, err := initializeGameState(board, quest, furnitureSystem)
 		if err != nil {
 			return fmt.Errorf("failed to initialize game state: %w", err)
 		}
@@ -162,33 +189,29 @@ func mainWithLobby() {
 
 		// Note: GM is not registered in turn order because they don't participate in quest setup
 		// or hero election phases. They only participate during GM phase which is managed separately.
-		log.Printf("GM player %s will control monsters during GM phase", gameMasterID)
+		log.Printf("GM player %s will control monsters during GM phase", gameMasterPlayerID)
 
-		// Create players from lobby selections
-		inventoryManager := NewInventoryManager(contentManager, logger)
-		entityIDCounter := 1
+		// Create players from the party, with what they carried out of the last quest
+		inventoryManager := gameManager.GetInventoryManager()
 
-		for playerID, heroClassID := range heroPlayers {
+		for _, hero := range start.Party {
 			// Load hero card
-			heroCard, ok := contentManager.GetHeroCard(heroClassID)
+			heroCard, ok := contentManager.GetHeroCard(hero.ClassID)
 			if !ok {
-				return fmt.Errorf("hero class not found: %s", heroClassID)
+				return fmt.Errorf("hero class not found: %s", hero.ClassID)
 			}
 
-			// Create entity ID
-			entityID := fmt.Sprintf("hero-%d", entityIDCounter)
-			entityIDCounter++
-
 			// Initialize inventory
-			if err := inventoryManager.InitializeHeroInventory(entityID); err != nil {
-				return fmt.Errorf("failed to initialize inventory for %s: %w", entityID, err)
+			if err := inventoryManager.InitializeHeroInventory(hero.HeroID); err != nil {
+				return fmt.Errorf("failed to initialize inventory for %s: %w", hero.HeroID, err)
 			}
 
 			// Create player from content
-			player, err := NewPlayerFromContent(playerID, entityID, heroCard, contentManager, inventoryManager)
+			player, err := NewPlayerFromContent(hero.PlayerID, hero.HeroID, heroCard, contentManager, inventoryManager)
 			if err != nil {
-				return fmt.Errorf("failed to create player %s: %w", playerID, err)
+				return fmt.Errorf("failed to create player %s: %w", hero.PlayerID, err)
 			}
+			gameManager.ApplyCampaignHero(hero)
 
 			// Add to turn manager
 			if err := gameManager.turnManager.AddPlayer(player); err != nil {
@@ -196,13 +219,18 @@ func mainWithLobby() {
 			}
 
 			// Register player in dynamic turn order
-			dynamicTurnOrder.RegisterPlayer(playerID)
-			log.Printf("Registered hero player in turn order: %s", playerID)
+			dynamicTurnOrder.RegisterPlayer(hero.PlayerID)
+			log.Printf("Registered hero player in turn order: %s", hero.PlayerID)
 
 			// Note: Hero entities will be spawned at positions chosen during quest setup phase
 			// Do NOT add to game state yet - position selection happens first
 
-			log.Printf("Created player %s as %s (%s)", playerID, heroCard.Name, entityID)
+			log.Printf("Created player %s as %s (%s)", hero.PlayerID, heroCard.Name, hero.HeroID)
+		}
+
+		// Wizard and Elf draft their spell schools during quest setup
+		if err := gameManager.BeginSpellSelection(); err != nil {
+			return fmt.Errorf("failed to start spell selection: %w", err)
 		}
 
 		// Spawn monsters from quest definition
@@ -210,6 +238,56 @@ func mainWithLobby() {
 			return fmt.Errorf("failed to create monsters: %w", err)
 		}
 
+		// Persist events from here on, replaying any that were logged before a restart.
+		// Each quest played after the first keeps its own log.
+		if eventStore != nil {
+			questGameID := gameID
+			if start.Played > 0 {
+				questGameID = fmt.Sprintf("%s-%d", gameID, start.Played+1)
+			}
+			game := GameRecord{ID: questGameID, MapID: board.ID, QuestID: quest.ID, DMUserID: gameMasterPlayerID}
+			replayed, err := gameManager.EnableEventLog(eventStore, game)
+			if err != nil {
+				return fmt.Errorf("failed to enable event log: %w", err)
+			}
+			log.Printf("Game %s: replayed %d stored events", questGameID, replayed)
+		}
+		gameManager.SetSaveSlotStore(saveSlots)
+
+		// The new quest is ready: record how the last one ended and swap the new game in
+		if start.Quest.ID == "" {
+			start.Quest.ID = quest.ID
+		}
+		campaign.StartQuest(start)
+		gameManager.SetCampaign(campaign, startQuest)
+		current.Store(&lobbyGame{gameManager: gameManager, state: state, quest: quest, board: board, furnitureSystem: furnitureSystem})
+
+		// Clients already in the game learn who drafts first
+		if gameManager.GetSpellSelectionForSnapshot() != nil {
+			broadcastSpellSelectionState(gameManager.GetDynamicTurnOrder(), gameManager.GetBroadcaster())
+		}
+		return nil
+	}
+
+	// Set game start handler
+	lobbyServer.SetGameStartHandler(func(gameMasterID string, heroPlayers map[string]string) error {
+		log.Printf("Initializing game with GM=%s and heroes=%v", gameMasterID, heroPlayers)
+		gameMasterPlayerID = gameMasterID // Store GM ID for persistent role checking
+
+		// Form the party from lobby selections
+		campaign = NewCampaignSession(contentManager.GetCampaign(), gameMasterID, logger)
+		entityIDCounter := 1
+		for playerID, heroClassID := range heroPlayers {
+			campaign.AddHero(playerID, fmt.Sprintf("hero-%d", entityIDCounter), heroClassID)
+			entityIDCounter++
+		}
+
+		// Start with the campaign's first quest
+		firstQuest, _ := campaign.NextQuest(nil)
+		if err := startQuest(campaign.PrepareQuest(firstQuest, nil, nil)); err != nil {
+			return err
+		}
+
 		// Mark all connections as no longer in lobby
 		for _, conn := range lobbyServer.GetConnectionManager().GetAllConnections() {
 			lobbyServer.GetConnectionManager().SetInLobby(conn, false)
@@ -260,7 +338,8 @@ func mainWithLobby() {
 
 	// Main page handler (game or redirect to lobby)
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		if !gameStarted {
+		game := current.Load()
+		if !gameStarted || game == nil {
 			// Redirect to lobby if game hasn't started
 			http.Redirect(w, r, "/lobby", http.StatusSeeOther)
 			return
@@ -283,6 +362,7 @@ func mainWithLobby() {
 		}
 
 		// Serve hero game page
+		gameManager, state, quest, board := game.gameManager, game.state, game.quest, game.board
 		currentGameState := gameManager.GetGameState()
 		currentGameState.Lock.Lock()
 
@@ -317,7 +397,8 @@ func mainWithLobby() {
 		entities := []protocol.EntityLite{}
 		// Get player list from turnManager (source of truth for game players)
 		for _, player := range gameManager.turnManager.GetHeroPlayers() {
-			if player == nil {
+			// Dead heroes leave the board and show as corpse markers instead
+			if player == nil || gameManager.GetHeroLifecycle().IsDead(player.EntityID) {
 				continue
 			}
 			playerID := player.ID
@@ -379,6 +460,7 @@ func mainWithLobby() {
 		furniture := gameManager.GetFurnitureForSnapshot()
 		monsters := gameManager.GetMonstersForSnapshot()
 		heroTurnStates := gameManager.GetHeroTurnStatesForSnapshot()
+		heroStats := gameManager.GetHeroStatsForSnapshot()
 
 		// Get dynamic turn order state
 		var turnPhase string
@@ -432,7 +514,7 @@ func mainWithLobby() {
 			MapID:             "dev-map",
 			PackID:            "dev-pack@v1",
 			Turn:              turnState.TurnNumber,
-			LastEventID:       0,
+			LastEventID:       gameManager.GetLastEventID(),
 			MapWidth:          state.Segment.Width,
 			MapHeight:         state.Segment.Height,
 			RegionsCount:      state.RegionMap.RegionsCount,
@@ -462,6 +544,16 @@ func mainWithLobby() {
 			Furniture:            furniture,
 			Monsters:             monsters,
 			HeroTurnStates:       heroTurnStates,
+			HeroStats:            heroStats,
+			Corpses:              gameManager.GetHeroLifecycle().GetCorpses(),
+			GroundItems:          gameManager.GetInventoryManager().GetGroundItems(),
+			SearchedRooms:        gameManager.GetTurnStateManager().GetSearchLedger(),
+			HouseRules:           gameManager.GetRuleSet().ToLite(),
+			SpellSelection:       gameManager.GetSpellSelectionForSnapshot(),
+			QuestEnded:           gameManager.GetQuestEndedForSnapshot(),
+			QuestProgress:        gameManager.GetQuestProgressForSnapshot(),
+			Campaign:             gameManager.GetCampaignForSnapshot(),
+			Armoury:              gameManager.GetArmouryForSnapshot(),
 			PlayerNames:          playerNames,
 			VisibleRegionIDs:     visibleNow,
 			CorridorRegionID:     state.CorridorRegion,
@@ -487,7 +579,8 @@ func mainWithLobby() {
 
 	// GM page handler (only for game master, shows full visibility)
 	mux.HandleFunc("/gm", func(w http.ResponseWriter, r *http.Request) {
-		if !gameStarted {
+		game := current.Load()
+		if !gameStarted || game == nil {
 			// Redirect to lobby if game hasn't started
 			http.Redirect(w, r, "/lobby", http.StatusSeeOther)
 			return
@@ -509,6 +602,7 @@ func mainWithLobby() {
 		}
 
 		// Build GM-specific snapshot with full visibility
+		gameManager, state, quest, board := game.gameManager, game.state, game.quest, game.board
 		currentGameState := gameManager.GetGameState()
 		currentGameState.Lock.Lock()
 
@@ -516,7 +610,8 @@ func mainWithLobby() {
 		entities := []protocol.EntityLite{}
 		// Get player list from turnManager (source of truth for game players)
 		for _, player := range gameManager.turnManager.GetHeroPlayers() {
-			if player == nil {
+			// Dead heroes leave the board and show as corpse markers instead
+			if player == nil || gameManager.GetHeroLifecycle().IsDead(player.EntityID) {
 				continue
 			}
 			pID := player.ID
@@ -586,6 +681,7 @@ func mainWithLobby() {
 		furniture := gameManager.GetFurnitureForSnapshot()
 		monsters := gameManager.GetMonstersForSnapshot()
 		heroTurnStates := gameManager.GetHeroTurnStatesForSnapshot()
+		heroStats := gameManager.GetHeroStatsForSnapshot()
 
 		// Extract quest data
 		questName := ""
@@ -655,7 +751,7 @@ func mainWithLobby() {
 			MapID:             "dev-map",
 			PackID:            "dev-pack@v1",
 			Turn:              turnState.TurnNumber,
-			LastEventID:       0,
+			LastEventID:       gameManager.GetLastEventID(),
 			MapWidth:          state.Segment.Width,
 			MapHeight:         state.Segment.Height,
 			RegionsCount:      state.RegionMap.RegionsCount,
@@ -685,6 +781,16 @@ func mainWithLobby() {
 			Furniture:            furniture,
 			Monsters:             monsters,
 			HeroTurnStates:       heroTurnStates,
+			HeroStats:            heroStats,
+			Corpses:              gameManager.GetHeroLifecycle().GetCorpses(),
+			GroundItems:          gameManager.GetInventoryManager().GetGroundItems(),
+			SearchedRooms:        gameManager.GetTurnStateManager().GetSearchLedger(),
+			HouseRules:           gameManager.GetRuleSet().ToLite(),
+			SpellSelection:       gameManager.GetSpellSelectionForSnapshot(),
+			QuestEnded:           gameManager.GetQuestEndedForSnapshot(),
+			QuestProgress:        gameManager.GetQuestProgressForSnapshot(),
+			Campaign:             gameManager.GetCampaignForSnapshot(),
+			Armoury:              gameManager.GetArmouryForSnapshot(),
 			PlayerNames:          playerNames,
 			VisibleRegionIDs:     allRegions, // GM sees everything
 			CorridorRegionID:     state.CorridorRegion,
@@ -748,10 +854,10 @@ func mainWithLobby() {
 		_ = conn.Write(context.Background(), websocket.MessageText, playerIDMessage)
 
 		// If game has started, mark connection as not in lobby and send initial game state
-		if gameStarted && gameManager != nil {
+		if game := current.Load(); gameStarted && game != nil {
 			// Mark this connection as not in lobby so messages route to game handler
 			lobbyServer.GetConnectionManager().SetInLobby(conn, false)
-			turnState := gameManager.GetTurnState()
+			turnState := game.gameManager.GetTurnState()
 			initMessage, _ := json.Marshal(protocol.PatchEnvelope{
 				Sequence: 0,
 				EventID:  0,
@@ -793,12 +899,12 @@ func mainWithLobby() {
 					if err := lobbyServer.HandleMessage(c, data); err != nil {
 						log.Printf("Lobby message error for %s: %v", playerID, err)
 					}
-				} else if gameStarted && gameManager != nil {
+				} else if game := current.Load(); gameStarted && game != nil {
 					// Handle game messages - get player ID from connection map
 					connectionPlayerMap.RLock()
 					playerID := connectionPlayerMap.conns[c]
 					connectionPlayerMap.RUnlock()
-					handleEnhancedWebSocketMessage(data, gameManager, state, hub, sequenceGen, quest, furnitureSystem, playerID)
+					handleEnhancedWebSocketMessage(data, game.gameManager, game.state, game.quest, game.furnitureSystem, playerID)
 				}
 			}
 		}(conn)
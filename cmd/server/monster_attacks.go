@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// MonsterAbilityDiagonalAttack lets a monster attack diagonally adjacent heroes as well
+const MonsterAbilityDiagonalAttack = "attack_diagonal"
+
+// MonsterAttack is a monster's attack on a hero. The attack dice are rolled when it is
+// declared; the hero may then react, for example with a potion, before rolling defense.
+type MonsterAttack struct {
+	ID           string     `json:"id"`
+	MonsterID    string     `json:"monsterId"`
+	HeroID       string     `json:"heroId"`
+	AttackRolls  []DiceRoll `json:"attackRolls"`
+	DefenseRolls []DiceRoll `json:"defenseRolls,omitempty"`
+	Damage       int        `json:"damage"`
+	BodyLeft     int        `json:"bodyLeft"`
+	Resolved     bool       `json:"resolved"`
+}
+
+// SetTurnStateManager sets the turn state manager that holds the reaction window and defense bonuses
+func (ms *MonsterSystem) SetTurnStateManager(turnStateManager *TurnStateManager) {
+	ms.turnStateManager = turnStateManager
+}
+
+// DeclareMonsterAttack rolls a monster's attack on a hero in reach. When skulls are rolled
+// the attack waits for ResolveMonsterAttack, giving the hero a window to react; an attack
+// without skulls is resolved at once.
+func (ms *MonsterSystem) DeclareMonsterAttack(monsterID, heroID string) (*MonsterAttack, error) {
+	if ms.pendingAttack != nil {
+		return nil, &GameError{Code: "attack_pending", Message: fmt.Sprintf("attack %s is waiting for %s to defend", ms.pendingAttack.ID, ms.pendingAttack.HeroID)}
+	}
+
+	monster, exists := ms.monsters[monsterID]
+	if !exists || !monster.IsAlive {
+		return nil, fmt.Errorf("monster %s not found", monsterID)
+	}
+	if monster.IsAsleep {
+		return nil, &GameError{Code: "monster_asleep", Message: fmt.Sprintf("monster %s is asleep", monsterID)}
+	}
+
+	hero := ms.findHeroPlayer(heroID)
+	if hero == nil || hero.Character == nil {
+		return nil, &GameError{Code: "invalid_target", Message: fmt.Sprintf("hero %s not found", heroID)}
+	}
+	if hero.Character.IsUnconscious() {
+		return nil, &GameError{Code: "hero_unconscious", Message: fmt.Sprintf("%s is already down", heroID)}
+	}
+
+	ms.gameState.Lock.Lock()
+	heroPos, onBoard := ms.gameState.Entities[heroID]
+	reaches := onBoard && monsterReaches(ms.gameState, monster, heroPos)
+	ms.gameState.Lock.Unlock()
+	if !reaches {
+		return nil, &GameError{Code: "target_out_of_reach", Message: fmt.Sprintf("%s cannot reach %s", monster.DisplayName(), heroID)}
+	}
+
+	ms.nextAttackID++
+	attack := &MonsterAttack{
+		ID:          fmt.Sprintf("attack-%d", ms.nextAttackID),
+		MonsterID:   monsterID,
+		HeroID:      heroID,
+		AttackRolls: ms.diceSystem.RollAttackDice(monster.AttackDice),
+	}
+
+	skulls := countSkulls(attack.AttackRolls)
+	ms.logger.Printf("%s (%s) attacks %s with %d skulls", monster.DisplayName(), monsterID, heroID, skulls)
+	if skulls == 0 {
+		ms.applyMonsterAttack(attack, hero)
+		return attack, nil
+	}
+
+	ms.pendingAttack = attack
+	if ms.turnStateManager != nil {
+		ms.turnStateManager.PushReactionContext(ReactionContext{TriggerEvent: "monster_attack", TargetHeroID: heroID})
+	}
+	ms.broadcaster.BroadcastEvent("MonsterAttackDeclared", protocol.MonsterAttackDeclared{
+		AttackID:    attack.ID,
+		MonsterID:   monsterID,
+		HeroID:      heroID,
+		AttackFaces: combatFaces(attack.AttackRolls),
+		Skulls:      skulls,
+	})
+	return attack, nil
+}
+
+// ResolveMonsterAttack closes the reaction window of a declared attack: the hero rolls their
+// defense dice, including armour and any defense bonus readied during the window, and takes
+// the damage that gets through
+func (ms *MonsterSystem) ResolveMonsterAttack(attackID string) (*MonsterAttack, error) {
+	attack := ms.pendingAttack
+	if attack == nil || attack.ID != attackID {
+		return nil, &GameError{Code: "no_pending_attack", Message: fmt.Sprintf("attack %s is not waiting for a defense roll", attackID)}
+	}
+
+	// The reaction window closes whatever happens next, so no attack is left blocking the others
+	ms.pendingAttack = nil
+	if ms.turnStateManager != nil {
+		ms.turnStateManager.PopReactionContext()
+	}
+
+	hero := ms.findHeroPlayer(attack.HeroID)
+	if hero == nil || hero.Character == nil {
+		return nil, fmt.Errorf("hero %s not found", attack.HeroID)
+	}
+	ms.applyMonsterAttack(attack, hero)
+	return attack, nil
+}
+
+// ResolvePendingMonsterAttack rolls defense for the hero on the attack still waiting for it,
+// if any. The GM's turn ends this way, so an attack the hero never answers cannot hold up
+// the game. Returns nil when no attack was waiting.
+func (ms *MonsterSystem) ResolvePendingMonsterAttack() (*MonsterAttack, error) {
+	if ms.pendingAttack == nil {
+		return nil, nil
+	}
+	return ms.ResolveMonsterAttack(ms.pendingAttack.ID)
+}
+
+// GetPendingMonsterAttack returns the attack waiting for a defense roll, if any
+func (ms *MonsterSystem) GetPendingMonsterAttack() *MonsterAttack {
+	return ms.pendingAttack
+}
+
+// applyMonsterAttack rolls the hero's defense, applies the damage and announces the result
+func (ms *MonsterSystem) applyMonsterAttack(attack *MonsterAttack, hero *Player) {
+	character := hero.Character
+
+	if countSkulls(attack.AttackRolls) > 0 {
+		defenseDice := character.GetEffectiveDefenseDice()
+		if ms.turnStateManager != nil {
+			for _, effect := range ms.turnStateManager.TriggerEffects(attack.HeroID, "next_defend") {
+				if effect.EffectType == SpellEffectBonusDefenseDice {
+					defenseDice += effect.Value
+				}
+			}
+		}
+		if ms.inventoryManager != nil {
+			defense := &ArtifactDefense{Monster: ms.monsters[attack.MonsterID], DefenseDice: defenseDice}
+			ms.inventoryManager.ApplyArtifactDefense(attack.HeroID, defense)
+			defenseDice = defense.DefenseDice
+		}
+		attack.DefenseRolls = ms.diceSystem.RollDefenseDice(defenseDice)
+	}
+
+	attack.Damage = CalculateHeroCombatDamage(attack.AttackRolls, attack.DefenseRolls)
+	if attack.Damage > 0 {
+		character.TakeDamage(attack.Damage)
+		character.IsAsleep = false
+	}
+	attack.BodyLeft = character.CurrentBody
+	attack.Resolved = true
+
+	ms.logger.Printf("Monster %s attacked %s: %d skulls vs %d shields = %d damage (%d/%d body)",
+		attack.MonsterID, attack.HeroID, countSkulls(attack.AttackRolls), countHeroShields(attack.DefenseRolls),
+		attack.Damage, character.CurrentBody, character.BaseStats.BodyPoints)
+
+	ms.broadcaster.BroadcastEvent("HeroDamaged", protocol.HeroDamaged{
+		AttackID:     attack.ID,
+		MonsterID:    attack.MonsterID,
+		HeroID:       attack.HeroID,
+		AttackFaces:  combatFaces(attack.AttackRolls),
+		DefenseFaces: combatFaces(attack.DefenseRolls),
+		Skulls:       countSkulls(attack.AttackRolls),
+		Shields:      countHeroShields(attack.DefenseRolls),
+		Damage:       attack.Damage,
+		BodyLeft:     character.CurrentBody,
+	})
+
+	if attack.Damage > 0 && ms.heroLifecycle != nil {
+		ms.heroLifecycle.CheckHero(attack.HeroID)
+	}
+}
+
+// monsterReaches reports whether a monster can attack a square: orthogonally adjacent ones,
+// and diagonal ones too for monsters with MonsterAbilityDiagonalAttack, but never through a
+// wall or closed door. Callers must hold the game state lock.
+func monsterReaches(state *GameState, monster *Monster, target protocol.TileAddress) bool {
+	dx, dy := absInt(target.X-monster.Position.X), absInt(target.Y-monster.Position.Y)
+	if dx+dy == 1 {
+		return adjacentOpen(state, monster.Position, target)
+	}
+	if dx == 1 && dy == 1 && slices.Contains(monster.SpecialAbilities, MonsterAbilityDiagonalAttack) {
+		return adjacentOpen(state, monster.Position, target)
+	}
+	return false
+}
+
+// combatFaces lists the face rolled on each combat die for patches
+func combatFaces(rolls []DiceRoll) []string {
+	faces := make([]string, 0, len(rolls))
+	for _, roll := range rolls {
+		faces = append(faces, string(roll.CombatResult))
+	}
+	return faces
+}
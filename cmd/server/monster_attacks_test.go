@@ -0,0 +1,145 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// createTestMonsterAttacker stands an awake goblin with the given abilities next to hero-2, the elf at (2,2)
+func createTestMonsterAttacker(t *testing.T, goblinAt protocol.TileAddress, abilities ...string) (*HeroActionSystem, *MonsterSystem) {
+	has := createTestItemUser(t, []*ItemCard{{
+		ID:                "shield_tonic",
+		Name:              "Shield Tonic",
+		Type:              "potion",
+		Effect:            &EffectDefinition{Type: ItemEffectBonusDice, Data: map[string]any{"dice": float64(2), "roll": "defend"}},
+		UsageRestrictions: &UsageRestriction{Timing: ItemTimingDuringCombat},
+	}}, nil)
+
+	ms := NewMonsterSystem(has.gameState, has.turnManager, has.diceSystem, &MockBroadcaster{}, &MockLogger{})
+	ms.SetTurnStateManager(has.turnStateManager)
+	ms.monsters["goblin-1"] = &Monster{ID: "goblin-1", Type: Goblin, Position: goblinAt, Body: 1, MaxBody: 1, AttackDice: 2, DefenseDice: 1, IsAlive: true, SpecialAbilities: abilities}
+	has.SetMonsterSystem(ms)
+	return has, ms
+}
+
+func TestMonsterAttack_HeroDefendsWithWhiteShieldsOnly(t *testing.T) {
+	has, ms := createTestMonsterAttacker(t, protocol.TileAddress{X: 2, Y: 3})
+	elf := has.turnManager.GetPlayer("player-2").Character
+	has.debugSystem.SetDiceOverride("attack", 5)
+
+	// Black shields only block for monsters
+	has.debugSystem.SetDiceOverride("defense", 6)
+	attack, err := ms.DeclareMonsterAttack("goblin-1", "hero-2")
+	if err != nil {
+		t.Fatalf("Expected the goblin to attack, got: %v", err)
+	}
+	if attack.Resolved || elf.CurrentBody != elf.BaseStats.BodyPoints {
+		t.Fatal("Expected no damage before the hero rolls defense")
+	}
+	if _, err := ms.ResolveMonsterAttack(attack.ID); err != nil {
+		t.Fatalf("Expected the attack to resolve, got: %v", err)
+	}
+	if attack.Damage != 2 || len(attack.DefenseRolls) != elf.GetEffectiveDefenseDice() {
+		t.Errorf("Expected 2 damage through %d black shields, got %d damage from %d dice", elf.GetEffectiveDefenseDice(), attack.Damage, len(attack.DefenseRolls))
+	}
+
+	has.debugSystem.SetDiceOverride("defense", 4)
+	attack, _ = ms.DeclareMonsterAttack("goblin-1", "hero-2")
+	if _, err := ms.ResolveMonsterAttack(attack.ID); err != nil {
+		t.Fatalf("Expected the attack to resolve, got: %v", err)
+	}
+	if attack.Damage != 0 || attack.BodyLeft != elf.BaseStats.BodyPoints-2 {
+		t.Errorf("Expected white shields to block both skulls, got %d damage and %d body left", attack.Damage, attack.BodyLeft)
+	}
+}
+
+func TestMonsterAttack_HeroReactsBeforeDefending(t *testing.T) {
+	has, ms := createTestMonsterAttacker(t, protocol.TileAddress{X: 3, Y: 2})
+	has.debugSystem.SetDiceOverride("attack", 6)
+	has.debugSystem.SetDiceOverride("defense", 4)
+
+	attack, err := ms.DeclareMonsterAttack("goblin-1", "hero-2")
+	if err != nil {
+		t.Fatalf("Expected the goblin to attack, got: %v", err)
+	}
+	if _, err := ms.DeclareMonsterAttack("goblin-1", "hero-2"); err == nil {
+		t.Error("Expected a second attack to wait for the first to resolve")
+	}
+
+	if _, err := has.ProcessInstantAction(useItemRequest("player-2", "hero-2", UseItemInstant, "shield_tonic")); err != nil {
+		t.Fatalf("Expected the tonic to be usable during the attack, got: %v", err)
+	}
+	if _, err := ms.ResolveMonsterAttack(attack.ID); err != nil {
+		t.Fatalf("Expected the attack to resolve, got: %v", err)
+	}
+
+	elf := has.turnManager.GetPlayer("player-2").Character
+	if len(attack.DefenseRolls) != elf.GetEffectiveDefenseDice()+2 || attack.Damage != 0 {
+		t.Errorf("Expected the tonic's 2 extra defense dice to block both skulls, got %d dice and %d damage", len(attack.DefenseRolls), attack.Damage)
+	}
+	if has.turnStateManager.GetCurrentReactionContext() != nil {
+		t.Error("Expected the reaction window to close")
+	}
+}
+
+func TestMonsterAttack_NeedsReach(t *testing.T) {
+	_, ms := createTestMonsterAttacker(t, protocol.TileAddress{X: 3, Y: 3})
+	_, err := ms.DeclareMonsterAttack("goblin-1", "hero-2")
+	expectGameErrorCode(t, err, "target_out_of_reach")
+
+	_, ms = createTestMonsterAttacker(t, protocol.TileAddress{X: 3, Y: 3}, MonsterAbilityDiagonalAttack)
+	if _, err := ms.DeclareMonsterAttack("goblin-1", "hero-2"); err != nil {
+		t.Errorf("Expected a monster with diagonal reach to attack, got: %v", err)
+	}
+
+	// Walls and closed doors stop an attack between adjacent squares
+	has, ms := createTestMonsterAttacker(t, protocol.TileAddress{X: 2, Y: 3})
+	wall := geometry.EdgeAddress{X: 2, Y: 3, Orientation: geometry.Horizontal}
+	has.gameState.BlockedWalls = map[geometry.EdgeAddress]bool{wall: true}
+	_, err = ms.DeclareMonsterAttack("goblin-1", "hero-2")
+	expectGameErrorCode(t, err, "target_out_of_reach")
+
+	has.gameState.BlockedWalls = nil
+	has.gameState.Doors["door-1"] = &DoorInfo{Edge: wall, State: "closed"}
+	has.gameState.DoorByEdge = map[geometry.EdgeAddress]string{wall: "door-1"}
+	_, err = ms.DeclareMonsterAttack("goblin-1", "hero-2")
+	expectGameErrorCode(t, err, "target_out_of_reach")
+
+	has.gameState.Doors["door-1"].State = "open"
+	if _, err := ms.DeclareMonsterAttack("goblin-1", "hero-2"); err != nil {
+		t.Errorf("Expected the goblin to attack through the open door, got: %v", err)
+	}
+}
+
+func TestMonsterAttack_UnansweredAttackSurvivesSaveAndResolvesAtTurnEnd(t *testing.T) {
+	has, ms := createTestMonsterAttacker(t, protocol.TileAddress{X: 2, Y: 3})
+	has.debugSystem.SetDiceOverride("attack", 6)
+	has.debugSystem.SetDiceOverride("defense", 1)
+	attack, _ := ms.DeclareMonsterAttack("goblin-1", "hero-2")
+
+	data, err := ms.SerializeForPersistence()
+	if err != nil {
+		t.Fatalf("Failed to serialize monsters: %v", err)
+	}
+	restored := NewMonsterSystem(has.gameState, has.turnManager, has.diceSystem, &MockBroadcaster{}, &MockLogger{})
+	restored.SetTurnStateManager(has.turnStateManager)
+	if err := restored.RestoreFromPersistence(data); err != nil {
+		t.Fatalf("Failed to restore monsters: %v", err)
+	}
+	if pending := restored.GetPendingMonsterAttack(); pending == nil || pending.ID != attack.ID {
+		t.Fatalf("Expected the pending attack to be restored, got %+v", pending)
+	}
+
+	resolved, err := restored.ResolvePendingMonsterAttack()
+	if err != nil || resolved == nil || resolved.Damage != 2 {
+		t.Fatalf("Expected the unanswered attack to be rolled for the hero, got %+v (%v)", resolved, err)
+	}
+	if has.turnStateManager.GetCurrentReactionContext() != nil || restored.GetPendingMonsterAttack() != nil {
+		t.Error("Expected the reaction window to close")
+	}
+	if next, err := restored.DeclareMonsterAttack("goblin-1", "hero-2"); err != nil || next.ID == attack.ID {
+		t.Errorf("Expected a new attack once the old one resolved, got %+v (%v)", next, err)
+	}
+}
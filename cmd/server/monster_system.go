@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -49,13 +50,20 @@ type Monster struct {
 	MovementRange    int                  `json:"movementRange"`
 	IsVisible        bool                 `json:"isVisible"`
 	IsAlive          bool                 `json:"isAlive"`
+	IsAsleep         bool                 `json:"isAsleep,omitempty"` // Sleep spell: cannot move or attack until woken
 	SpecialAbilities []string             `json:"specialAbilities,omitempty"`
 	SpawnedTurn      int                  `json:"spawnedTurn"`
 	LastMovedTurn    int                  `json:"lastMovedTurn"`
-	SubType          string               `json:"subType,omitempty"` // e.g., "undead" for skeletons
+	SubType          string               `json:"subType,omitempty"`     // e.g., "undead" for skeletons
+	DreadSpells      map[string]int       `json:"dreadSpells,omitempty"` // Dread spell ID -> casts left this quest
+	Name             string               `json:"name,omitempty"`        // Quest display name, e.g. "Verag the Gargoyle"
+	QuestMonsterID   string               `json:"questMonsterId,omitempty"`
+	Overrides        map[string]int       `json:"overrides,omitempty"`        // Stat -> value differing from the monster card
+	AppliedModifiers []string             `json:"appliedModifiers,omitempty"` // Quest notes whose monster modifier was applied
 }
 
-// MonsterType defines different monster types
+// MonsterType is the ID of a monster card in the loaded campaign content.
+// Any type with a card can be spawned; the constants name the base game's monsters.
 type MonsterType string
 
 const (
@@ -71,16 +79,17 @@ const (
 
 // MonsterTemplate defines monster stats and behavior
 type MonsterTemplate struct {
-	Type             MonsterType `json:"type"`
-	Name             string      `json:"name"`
-	MaxBody          int         `json:"maxBody"`
-	MaxMind          int         `json:"maxMind"`
-	AttackDice       int         `json:"attackDice"`
-	DefenseDice      int         `json:"defenseDice"`
-	MovementRange    int         `json:"movementRange"`
-	SpecialAbilities []string    `json:"specialAbilities,omitempty"`
-	Description      string      `json:"description"`
-	SubType          string      `json:"subType,omitempty"` // e.g., "undead" for skeletons
+	Type             MonsterType      `json:"type"`
+	Name             string           `json:"name"`
+	MaxBody          int              `json:"maxBody"`
+	MaxMind          int              `json:"maxMind"`
+	AttackDice       int              `json:"attackDice"`
+	DefenseDice      int              `json:"defenseDice"`
+	MovementRange    int              `json:"movementRange"`
+	SpecialAbilities []string         `json:"specialAbilities,omitempty"`
+	Description      string           `json:"description"`
+	SubType          string           `json:"subType,omitempty"` // e.g., "undead" for skeletons
+	Abilities        []MonsterAbility `json:"-"`
 }
 
 // MonsterAction represents an action a monster can take
@@ -96,36 +105,72 @@ type MonsterAction struct {
 
 // MonsterSystem handles monster management and AI
 type MonsterSystem struct {
-	monsters      map[string]*Monster
-	templates     map[MonsterType]*MonsterTemplate
-	gameState     *GameState
-	turnManager   *TurnManager
-	diceSystem    *DiceSystem
-	broadcaster   Broadcaster
-	logger        Logger
-	nextMonsterID int
+	monsters       map[string]*Monster
+	templates      map[MonsterType]*MonsterTemplate
+	gameState      *GameState
+	turnManager    *TurnManager
+	diceSystem     *DiceSystem
+	broadcaster    Broadcaster
+	logger         Logger
+	contentManager *ContentManager
+	visibility     VisibilityCalculator
+	heroLifecycle  *HeroLifecycleSystem
+	nextMonsterID  int
+
+	turnStateManager *TurnStateManager
+	pendingAttack    *MonsterAttack // Declared attack waiting for the hero's defense roll
+	nextAttackID     int
+	rules            *RuleSet
+	inventoryManager *InventoryManager // Artifacts that change the heroes' defense and ward off dread spells
+	furnitureSystem  *FurnitureSystem  // Pieces no monster can be placed on
 }
 
 // NewMonsterSystem creates a new monster system
 func NewMonsterSystem(gameState *GameState, turnManager *TurnManager, diceSystem *DiceSystem, broadcaster Broadcaster, logger Logger) *MonsterSystem {
 	ms := &MonsterSystem{
 		monsters:      make(map[string]*Monster),
-		templates:     make(map[MonsterType]*MonsterTemplate),
+		templates:     builtInMonsterTemplates(),
 		gameState:     gameState,
 		turnManager:   turnManager,
 		diceSystem:    diceSystem,
 		broadcaster:   broadcaster,
 		logger:        logger,
+		visibility:    NewVisibilityCalculator(logger),
 		nextMonsterID: 1,
 	}
 
-	ms.initializeMonsterTemplates()
 	return ms
 }
 
-// Initialize monster templates with HeroQuest stats
-func (ms *MonsterSystem) initializeMonsterTemplates() {
-	ms.templates[Goblin] = &MonsterTemplate{
+// SetContentManager sets the content manager monster templates and dread spell cards come from
+func (ms *MonsterSystem) SetContentManager(contentManager *ContentManager) {
+	ms.contentManager = contentManager
+	ms.loadMonsterTemplates()
+}
+
+// loadMonsterTemplates replaces the monster templates with the monster cards in content.
+// Content without monster cards keeps the built-in templates.
+func (ms *MonsterSystem) loadMonsterTemplates() {
+	cards := ms.contentManager.GetAllMonsters()
+	if len(cards) == 0 {
+		ms.templates = builtInMonsterTemplates()
+		ms.logger.Printf("Warning: No monster cards in content, using %d built-in monster templates", len(ms.templates))
+		return
+	}
+
+	ms.templates = make(map[MonsterType]*MonsterTemplate, len(cards))
+	for id, card := range cards {
+		ms.templates[MonsterType(id)] = monsterTemplateFromCard(card)
+	}
+	ms.logger.Printf("Loaded %d monster templates from content", len(ms.templates))
+}
+
+// builtInMonsterTemplates returns the base game's monsters with their HeroQuest stats, used
+// when the campaign content has no monster cards
+func builtInMonsterTemplates() map[MonsterType]*MonsterTemplate {
+	templates := make(map[MonsterType]*MonsterTemplate)
+
+	templates[Goblin] = &MonsterTemplate{
 		Type:          Goblin,
 		Name:          "Goblin",
 		MaxBody:       1,
@@ -136,7 +181,7 @@ func (ms *MonsterSystem) initializeMonsterTemplates() {
 		Description:   "Weak but numerous creatures",
 	}
 
-	ms.templates[Orc] = &MonsterTemplate{
+	templates[Orc] = &MonsterTemplate{
 		Type:          Orc,
 		Name:          "Orc",
 		MaxBody:       1,
@@ -147,7 +192,7 @@ func (ms *MonsterSystem) initializeMonsterTemplates() {
 		Description:   "Stronger than goblins, more aggressive",
 	}
 
-	ms.templates[Skeleton] = &MonsterTemplate{
+	templates[Skeleton] = &MonsterTemplate{
 		Type:          Skeleton,
 		Name:          "Skeleton",
 		MaxBody:       1,
@@ -159,7 +204,7 @@ func (ms *MonsterSystem) initializeMonsterTemplates() {
 		SubType:       "undead",
 	}
 
-	ms.templates[Zombie] = &MonsterTemplate{
+	templates[Zombie] = &MonsterTemplate{
 		Type:          Zombie,
 		Name:          "Zombie",
 		MaxBody:       1,
@@ -171,7 +216,7 @@ func (ms *MonsterSystem) initializeMonsterTemplates() {
 		SubType:       "undead",
 	}
 
-	ms.templates[Mummy] = &MonsterTemplate{
+	templates[Mummy] = &MonsterTemplate{
 		Type:          Mummy,
 		Name:          "Mummy",
 		MaxBody:       2,
@@ -183,7 +228,7 @@ func (ms *MonsterSystem) initializeMonsterTemplates() {
 		SubType:       "undead",
 	}
 
-	ms.templates[Gargoyle] = &MonsterTemplate{
+	templates[Gargoyle] = &MonsterTemplate{
 		Type:          Gargoyle,
 		Name:          "Gargoyle",
 		MaxBody:       3,
@@ -194,7 +239,7 @@ func (ms *MonsterSystem) initializeMonsterTemplates() {
 		Description:   "Stone creatures that guard important areas",
 	}
 
-	ms.templates[DreadWarrior] = &MonsterTemplate{
+	templates[DreadWarrior] = &MonsterTemplate{
 		Type:          DreadWarrior,
 		Name:          "Dread Warrior",
 		MaxBody:       3,
@@ -205,7 +250,7 @@ func (ms *MonsterSystem) initializeMonsterTemplates() {
 		Description:   "Heavily armored undead warriors",
 	}
 
-	ms.templates[Abomination] = &MonsterTemplate{
+	templates[Abomination] = &MonsterTemplate{
 		Type:          Abomination,
 		Name:          "Abomination",
 		MaxBody:       2,
@@ -215,6 +260,56 @@ func (ms *MonsterSystem) initializeMonsterTemplates() {
 		MovementRange: 6,
 		Description:   "Twisted creatures of chaos",
 	}
+	return templates
+}
+
+// monsterTemplateFromCard converts a monster card into the template monsters are spawned from
+func monsterTemplateFromCard(card *MonsterCard) *MonsterTemplate {
+	template := &MonsterTemplate{
+		Type:          MonsterType(card.ID),
+		Name:          card.Name,
+		MaxBody:       card.Stats.BodyPoints,
+		MaxMind:       card.Stats.MindPoints,
+		AttackDice:    card.Stats.AttackDice,
+		DefenseDice:   card.Stats.DefendDice,
+		MovementRange: card.Stats.MovementSquares,
+		Description:   card.Description,
+		SubType:       card.GameplayProperties.SubType,
+	}
+
+	for _, ability := range card.GameplayProperties.Abilities {
+		template.SpecialAbilities = append(template.SpecialAbilities, ability.ID)
+		template.Abilities = append(template.Abilities, MonsterAbility{
+			ID:             ability.ID,
+			Name:           ability.Name,
+			Type:           ability.Type,
+			UsesPerTurn:    ability.UsesPerTurn,
+			UsesPerQuest:   ability.UsesPerQuest,
+			RequiresAction: ability.RequiresAction,
+			Range:          ability.Range,
+			Description:    ability.Description,
+			EffectDetails:  ability.Effect,
+		})
+	}
+	return template
+}
+
+// HasMonsterType reports whether content defines a monster type
+func (ms *MonsterSystem) HasMonsterType(monsterType MonsterType) bool {
+	_, exists := ms.templates[monsterType]
+	return exists
+}
+
+// GetMonsterAbilities returns the special abilities a monster's card gives it
+func (ms *MonsterSystem) GetMonsterAbilities(monsterID string) []MonsterAbility {
+	monster, exists := ms.monsters[monsterID]
+	if !exists {
+		return nil
+	}
+	if template, exists := ms.templates[monster.Type]; exists {
+		return template.Abilities
+	}
+	return nil
 }
 
 // SpawnMonster creates a new monster at the specified location
@@ -246,6 +341,7 @@ func (ms *MonsterSystem) SpawnMonster(monsterType MonsterType, position protocol
 		IsVisible:        false, // Monsters start hidden until revealed
 		IsAlive:          true,
 		SpecialAbilities: template.SpecialAbilities,
+		SubType:          template.SubType,
 		SpawnedTurn:      ms.getTurnNumber(),
 		LastMovedTurn:    0,
 	}
@@ -278,6 +374,10 @@ func (ms *MonsterSystem) MoveMonster(monsterID string, destination protocol.Tile
 		return fmt.Errorf("monster %s is dead", monsterID)
 	}
 
+	if monster.IsAsleep {
+		return &GameError{Code: "monster_asleep", Message: fmt.Sprintf("monster %s is asleep", monsterID)}
+	}
+
 	// Validate movement distance
 	distance := ms.calculateDistance(monster.Position, destination)
 	if distance > monster.MovementRange {
@@ -323,6 +423,24 @@ func (ms *MonsterSystem) GetVisibleMonsters() []*Monster {
 	return visible
 }
 
+// MonsterInSightOf reports whether the hero has line of sight to any living monster,
+// whether or not the monster has been revealed
+func (ms *MonsterSystem) MonsterInSightOf(heroID string) bool {
+	ms.gameState.Lock.Lock()
+	defer ms.gameState.Lock.Unlock()
+
+	heroPos, onBoard := ms.gameState.Entities[heroID]
+	if !onBoard {
+		return false
+	}
+	for _, monster := range ms.monsters {
+		if monster.IsAlive && ms.visibility.IsTileCenterVisible(ms.gameState, heroPos.X, heroPos.Y, monster.Position.X, monster.Position.Y) {
+			return true
+		}
+	}
+	return false
+}
+
 // RevealMonster makes a monster visible
 func (ms *MonsterSystem) RevealMonster(monsterID string) error {
 	monster, exists := ms.monsters[monsterID]
@@ -330,6 +448,10 @@ func (ms *MonsterSystem) RevealMonster(monsterID string) error {
 		return fmt.Errorf("monster %s not found", monsterID)
 	}
 
+	ms.gameState.Lock.Lock()
+	ms.gameState.KnownMonsters[monsterID] = true
+	ms.gameState.Lock.Unlock()
+
 	if !monster.IsVisible {
 		monster.IsVisible = true
 		ms.broadcastMonsterUpdate(monster)
@@ -363,6 +485,61 @@ func (ms *MonsterSystem) KillMonster(monsterID string) error {
 	return nil
 }
 
+// RestoreMonster puts a monster back into the system exactly as given (used when rebuilding
+// a game from persisted state); nothing is broadcast
+func (ms *MonsterSystem) RestoreMonster(monster *Monster) {
+	restored := *monster
+	ms.monsters[restored.ID] = &restored
+
+	var n int
+	if _, err := fmt.Sscanf(restored.ID, "monster_%d", &n); err == nil && n >= ms.nextMonsterID {
+		ms.nextMonsterID = n + 1
+	}
+
+	ms.gameState.Lock.Lock()
+	if restored.IsAlive {
+		ms.gameState.Entities[restored.ID] = restored.Position
+	} else {
+		delete(ms.gameState.Entities, restored.ID)
+	}
+	ms.gameState.Lock.Unlock()
+}
+
+// monsterSystemPersistence is the serialized form of MonsterSystem
+type monsterSystemPersistence struct {
+	Monsters      map[string]*Monster `json:"monsters"`
+	NextMonsterID int                 `json:"nextMonsterId"`
+	PendingAttack *MonsterAttack      `json:"pendingAttack,omitempty"`
+	NextAttackID  int                 `json:"nextAttackId,omitempty"`
+}
+
+// SerializeForPersistence serializes all monsters to JSON
+func (ms *MonsterSystem) SerializeForPersistence() ([]byte, error) {
+	return json.Marshal(monsterSystemPersistence{
+		Monsters:      ms.monsters,
+		NextMonsterID: ms.nextMonsterID,
+		PendingAttack: ms.pendingAttack,
+		NextAttackID:  ms.nextAttackID,
+	})
+}
+
+// RestoreFromPersistence replaces all monsters with previously serialized data.
+// Entity positions are restored separately with the GameState.
+func (ms *MonsterSystem) RestoreFromPersistence(data []byte) error {
+	var restored monsterSystemPersistence
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return err
+	}
+
+	ms.monsters = orEmpty(restored.Monsters)
+	ms.nextMonsterID = max(restored.NextMonsterID, 1)
+	ms.pendingAttack = restored.PendingAttack
+	ms.nextAttackID = restored.NextAttackID
+
+	ms.logger.Printf("Restored %d monsters from persistence", len(ms.monsters))
+	return nil
+}
+
 func (ms *MonsterSystem) broadcastMonsterUpdate(monster *Monster) {
 	ms.broadcaster.BroadcastEvent("MonsterUpdate", map[string]any{
 		"monster": monster,
@@ -434,6 +611,28 @@ func (ms *MonsterSystem) ApplyDamageToMonster(monsterID string, damage int) (*Mo
 	return monster, isDead, nil
 }
 
+// SetMonsterAsleep puts a monster to sleep or wakes it up
+func (ms *MonsterSystem) SetMonsterAsleep(monsterID string, asleep bool) error {
+	monster, exists := ms.monsters[monsterID]
+	if !exists {
+		return fmt.Errorf("monster %s not found", monsterID)
+	}
+
+	if monster.IsAsleep == asleep {
+		return nil
+	}
+
+	monster.IsAsleep = asleep
+	if asleep {
+		ms.logger.Printf("Monster %s (%s) fell asleep", monster.ID, monster.Type)
+	} else {
+		ms.logger.Printf("Monster %s (%s) woke up", monster.ID, monster.Type)
+	}
+
+	ms.broadcastMonsterUpdate(monster)
+	return nil
+}
+
 // IsMonsterAt checks if there is an alive monster at the specified position
 func (ms *MonsterSystem) IsMonsterAt(x, y int) bool {
 	for _, monster := range ms.monsters {
@@ -480,32 +679,21 @@ func (ms *MonsterSystem) processMonsterAttackAction(request MonsterActionRequest
 		return result, fmt.Errorf("missing targetId parameter")
 	}
 
-	// Get the monster
-	monster, exists := ms.monsters[request.MonsterID]
-	if !exists {
+	attack, err := ms.DeclareMonsterAttack(request.MonsterID, targetID)
+	if err != nil {
 		result.Success = false
-		result.Message = "Monster not found"
-		return result, fmt.Errorf("monster %s not found", request.MonsterID)
-	}
-
-	// Roll attack dice
-	attackRolls := ms.diceSystem.RollAttackDice(monster.AttackDice)
-	skulls := 0
-	for _, roll := range attackRolls {
-		if roll.CombatResult == Skull {
-			skulls++
-		}
-		result.DiceRolls = append(result.DiceRolls, roll)
+		result.Message = err.Error()
+		return result, err
 	}
 
-	result.Damage = skulls
+	result.DiceRolls = attack.AttackRolls
+	result.Damage = attack.Damage
 	result.Success = true
-	result.Message = fmt.Sprintf("Monster attacked %s for %d damage", targetID, skulls)
-
-	ms.logger.Printf("Monster %s attacks %s - rolled %d skulls", request.MonsterID, targetID, skulls)
-
-	// TODO: Actually apply damage to target when hero damage system is ready
-
+	if attack.Resolved {
+		result.Message = fmt.Sprintf("Monster attacked %s for %d damage", targetID, attack.Damage)
+	} else {
+		result.Message = fmt.Sprintf("Monster attacks %s with %d skulls; waiting for the defense roll", targetID, countSkulls(attack.AttackRolls))
+	}
 	return result, nil
 }
 
@@ -517,9 +705,26 @@ func (ms *MonsterSystem) processMonsterSpecial(request MonsterActionRequest, res
 		return result, fmt.Errorf("missing abilityId parameter")
 	}
 
-	// TODO: Implement special abilities
+	if !ms.HasDreadSpell(request.MonsterID, abilityID) {
+		result.Success = false
+		result.Message = fmt.Sprintf("Monster cannot use %s", abilityID)
+		return result, &GameError{Code: "spell_not_known", Message: fmt.Sprintf("monster %s has no ability %s", request.MonsterID, abilityID)}
+	}
+
+	targetID, _ := request.Parameters["targetId"].(string)
+	spellResult, err := ms.CastDreadSpell(request.MonsterID, abilityID, targetID)
+	if err != nil {
+		result.Success = false
+		result.Message = err.Error()
+		return result, err
+	}
+
 	result.Success = true
-	result.Message = fmt.Sprintf("Monster used %s", abilityID)
+	result.DiceRolls = spellResult.DiceRolls
+	if spellResult.Effect == SpellEffectDamage || spellResult.Effect == SpellEffectAttack {
+		result.Damage = spellResult.Value
+	}
+	result.Message = fmt.Sprintf("Monster cast %s", spellResult.SpellName)
 	return result, nil
 }
 
@@ -557,16 +762,3 @@ func (ms *MonsterSystem) calculateDistance(pos1, pos2 protocol.TileAddress) int
 	}
 	return dx + dy // Manhattan distance
 }
-
-// executeMonsterAttackAction - Used by ProcessAction for GM-controlled monster attacks
-func (ms *MonsterSystem) executeMonsterAttackAction(monsterID, targetID string) error {
-	_, exists := ms.monsters[monsterID]
-	if !exists {
-		return fmt.Errorf("monster %s not found", monsterID)
-	}
-
-	// For now, this is a placeholder for GM-controlled attacks
-	// TODO: Implement proper hero damage system when hero damage system is ready
-	ms.logger.Printf("Monster %s attacks %s (GM-controlled action)", monsterID, targetID)
-	return nil
-}
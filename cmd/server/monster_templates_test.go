@@ -0,0 +1,109 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+const ogreCardJSON = `{
+  "id": "ogre_champion",
+  "name": "Ogre Champion",
+  "stats": {"movementSquares": 6, "attackDice": 5, "defendDice": 5, "bodyPoints": 4, "mindPoints": 3},
+  "gridSize": {"width": 1, "height": 1},
+  "gameplayProperties": {
+    "blocksLineOfSight": true,
+    "blocksMovement": true,
+    "faction": "evil",
+    "abilities": [
+      {"id": "ogre_roar", "name": "Roar", "type": "active", "usesPerQuest": 1, "requiresAction": true, "range": 3, "effect": {"type": "stun"}}
+    ]
+  }
+}`
+
+// createTestMonsterContent loads monster cards from a temporary monsters directory
+func createTestMonsterContent(t *testing.T, cards map[string]string) *ContentManager {
+	dir := t.TempDir()
+	for name, card := range cards {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(card), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	contentManager := NewContentManager(&MockLogger{})
+	if err := contentManager.loadMonsters(dir); err != nil {
+		t.Fatalf("Failed to load monsters: %v", err)
+	}
+	return contentManager
+}
+
+func TestMonsterTemplates_ExpansionMonsterFromContent(t *testing.T) {
+	contentManager := createTestMonsterContent(t, map[string]string{
+		"ogre_champion.json": ogreCardJSON,
+		"skeleton.json":      `{"id": "skeleton", "name": "Skeleton", "stats": {"movementSquares": 6, "attackDice": 2, "defendDice": 2, "bodyPoints": 1}, "gameplayProperties": {"subType": "undead"}}`,
+		"broken.json":        `{"name": "No ID"}`,
+	})
+	if len(contentManager.GetAllMonsters()) != 2 {
+		t.Fatalf("Expected the card without an id to be skipped, got %d monsters", len(contentManager.GetAllMonsters()))
+	}
+
+	has := createTestHeroActionSystem()
+	ms := NewMonsterSystem(has.gameState, has.turnManager, has.diceSystem, &MockBroadcaster{}, &MockLogger{})
+	ms.SetContentManager(contentManager)
+
+	ogre, err := ms.SpawnMonster("ogre_champion", protocol.TileAddress{X: 8, Y: 5})
+	if err != nil {
+		t.Fatalf("Expected the ogre to spawn from its card, got: %v", err)
+	}
+	if ogre.AttackDice != 5 || ogre.DefenseDice != 5 || ogre.MaxBody != 4 || ogre.MovementRange != 6 {
+		t.Errorf("Expected the card's stats, got %+v", ogre)
+	}
+
+	abilities := ms.GetMonsterAbilities(ogre.ID)
+	if len(abilities) != 1 || abilities[0].ID != "ogre_roar" || abilities[0].UsesPerQuest != 1 || abilities[0].EffectDetails["type"] != "stun" {
+		t.Errorf("Expected the roar ability from the card, got %+v", abilities)
+	}
+
+	skeleton, err := ms.SpawnMonster(Skeleton, protocol.TileAddress{X: 9, Y: 5})
+	if err != nil || skeleton.SubType != "undead" {
+		t.Errorf("Expected an undead skeleton, got %+v (%v)", skeleton, err)
+	}
+}
+
+func TestMonsterTemplates_UnknownTypesAreRejected(t *testing.T) {
+	has := createTestHeroActionSystem()
+	ms := NewMonsterSystem(has.gameState, has.turnManager, has.diceSystem, &MockBroadcaster{}, &MockLogger{})
+	ms.SetContentManager(createTestMonsterContent(t, map[string]string{"ogre_champion.json": ogreCardJSON}))
+
+	if _, err := ms.SpawnMonster(Goblin, protocol.TileAddress{X: 8, Y: 5}); err == nil {
+		t.Error("Expected a monster without a card not to spawn")
+	}
+
+	quest := &geometry.QuestDefinition{Monsters: []geometry.QuestMonster{
+		{ID: "m1", Type: "ogre_champion", X: 8, Y: 5},
+		{ID: "m2", Type: "frozen_horror", X: 9, Y: 5},
+	}}
+	if err := createMonstersFromQuest(quest, ms); err != nil {
+		t.Fatalf("Expected quest monsters to be created, got: %v", err)
+	}
+	if monsters := ms.GetMonsters(); len(monsters) != 1 || monsters["monster_1"].Type != "ogre_champion" {
+		t.Errorf("Expected only the ogre to be placed, got %+v", monsters)
+	}
+}
+
+func TestMonsterTemplates_BuiltInWithoutMonsterCards(t *testing.T) {
+	has := createTestHeroActionSystem()
+	ms := NewMonsterSystem(has.gameState, has.turnManager, has.diceSystem, &MockBroadcaster{}, &MockLogger{})
+	ms.SetContentManager(NewContentManager(&MockLogger{}))
+
+	goblin, err := ms.SpawnMonster(Goblin, protocol.TileAddress{X: 8, Y: 5})
+	if err != nil {
+		t.Fatalf("Expected a goblin from the built-in templates, got: %v", err)
+	}
+	if goblin.AttackDice != 2 || goblin.DefenseDice != 1 || goblin.MaxBody != 1 {
+		t.Errorf("Expected the base game's goblin stats, got %+v", goblin)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// Monster stats a quest can override (keys of Monster.Overrides)
+const (
+	MonsterStatBody     = "body"
+	MonsterStatMind     = "mind"
+	MonsterStatAttack   = "attack"
+	MonsterStatDefense  = "defense"
+	MonsterStatMovement = "movement"
+)
+
+// SpawnQuestMonster spawns a monster placed by the quest, applying its display name and stat overrides
+func (ms *MonsterSystem) SpawnQuestMonster(questMonster geometry.QuestMonster) (*Monster, error) {
+	position := protocol.TileAddress{X: questMonster.X, Y: questMonster.Y}
+	monster, err := ms.SpawnMonster(MonsterType(questMonster.Type), position)
+	if err != nil {
+		return nil, err
+	}
+
+	monster.QuestMonsterID = questMonster.ID
+	monster.Name = questMonster.Name
+	if overrides := questMonster.Overrides; overrides != nil {
+		setStat := func(stat string, value *int, field *int) {
+			if value != nil {
+				*field = *value
+				ms.recordOverride(monster, stat, *value)
+			}
+		}
+		setStat(MonsterStatBody, overrides.Body, &monster.MaxBody)
+		setStat(MonsterStatMind, overrides.Mind, &monster.MaxMind)
+		setStat(MonsterStatAttack, overrides.Attack, &monster.AttackDice)
+		setStat(MonsterStatDefense, overrides.Defense, &monster.DefenseDice)
+		setStat(MonsterStatMovement, overrides.Movement, &monster.MovementRange)
+		monster.Body = monster.MaxBody
+		monster.Mind = monster.MaxMind
+	}
+
+	if monster.Name != "" || len(monster.Overrides) > 0 {
+		ms.logger.Printf("Quest monster %s is %s (%s) with overrides %v", questMonster.ID, monster.ID, monster.DisplayName(), monster.Overrides)
+	}
+	return monster, nil
+}
+
+// ApplyMonsterModifier applies a quest note's dice bonuses to the monster it names, once per note.
+// The modifier's monster ID may be either the spawned monster's ID or the quest's ID for it.
+func (ms *MonsterSystem) ApplyMonsterModifier(noteID string, modifier *geometry.MonsterModifier) (*Monster, error) {
+	monster := ms.findMonster(modifier.MonsterID)
+	if monster == nil {
+		return nil, fmt.Errorf("monster %s not found", modifier.MonsterID)
+	}
+	if !monster.IsAlive {
+		return nil, fmt.Errorf("monster %s is dead", monster.ID)
+	}
+	if slices.Contains(monster.AppliedModifiers, noteID) {
+		return monster, nil
+	}
+
+	monster.AttackDice = max(1, monster.AttackDice+modifier.AttackDiceBonus)
+	monster.DefenseDice = max(0, monster.DefenseDice+modifier.DefenseDiceBonus)
+	if modifier.AttackDiceBonus != 0 {
+		ms.recordOverride(monster, MonsterStatAttack, monster.AttackDice)
+	}
+	if modifier.DefenseDiceBonus != 0 {
+		ms.recordOverride(monster, MonsterStatDefense, monster.DefenseDice)
+	}
+	monster.AppliedModifiers = append(monster.AppliedModifiers, noteID)
+
+	ms.logger.Printf("Quest note %s modified %s: %d attack dice, %d defense dice", noteID, monster.DisplayName(), monster.AttackDice, monster.DefenseDice)
+	if monster.IsVisible {
+		ms.broadcastMonsterUpdate(monster)
+	}
+	return monster, nil
+}
+
+// findMonster looks a monster up by its ID or by the ID the quest placed it under
+func (ms *MonsterSystem) findMonster(id string) *Monster {
+	if monster, exists := ms.monsters[id]; exists {
+		return monster
+	}
+	for _, monster := range ms.monsters {
+		if monster.QuestMonsterID == id {
+			return monster
+		}
+	}
+	return nil
+}
+
+func (ms *MonsterSystem) recordOverride(monster *Monster, stat string, value int) {
+	if monster.Overrides == nil {
+		monster.Overrides = make(map[string]int)
+	}
+	monster.Overrides[stat] = value
+}
+
+// DisplayName returns the monster's quest name, or its type when it has none
+func (m *Monster) DisplayName() string {
+	if m.Name != "" {
+		return m.Name
+	}
+	return string(m.Type)
+}
+
+// toMonsterLite converts a monster to its client representation
+func toMonsterLite(monster *Monster) protocol.MonsterLite {
+	return protocol.MonsterLite{
+		ID:            monster.ID,
+		Type:          string(monster.Type),
+		Name:          monster.Name,
+		Tile:          monster.Position,
+		Body:          monster.Body,
+		MaxBody:       monster.MaxBody,
+		Mind:          monster.Mind,
+		MaxMind:       monster.MaxMind,
+		AttackDice:    monster.AttackDice,
+		DefenseDice:   monster.DefenseDice,
+		MovementRange: monster.MovementRange,
+		Overrides:     monster.Overrides,
+		IsVisible:     monster.IsVisible,
+		IsAlive:       monster.IsAlive,
+	}
+}
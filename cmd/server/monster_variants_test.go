@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// createTestBestiary returns a monster system whose content defines a gargoyle
+func createTestBestiary(t *testing.T) *MonsterSystem {
+	has := createTestHeroActionSystem()
+	contentManager := NewContentManager(&MockLogger{})
+	contentManager.monsterCards["gargoyle"] = &MonsterCard{ID: "gargoyle", Name: "Gargoyle", Stats: MonsterStats{MovementSquares: 6, AttackDice: 4, DefendDice: 5, BodyPoints: 3, MindPoints: 4}}
+
+	ms := NewMonsterSystem(has.gameState, has.turnManager, has.diceSystem, &MockBroadcaster{}, &MockLogger{})
+	ms.SetContentManager(contentManager)
+	return ms
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func TestMonsterVariants_NamedBossWithOverrides(t *testing.T) {
+	ms := createTestBestiary(t)
+
+	verag, err := ms.SpawnQuestMonster(geometry.QuestMonster{
+		ID: "verag", Type: "gargoyle", X: 8, Y: 5, Name: "Verag the Gargoyle",
+		Overrides: &geometry.MonsterOverrides{Body: intPtr(5), Attack: intPtr(5), Mind: intPtr(0)},
+	})
+	if err != nil {
+		t.Fatalf("Expected Verag to spawn, got: %v", err)
+	}
+
+	if verag.Body != 5 || verag.MaxBody != 5 || verag.AttackDice != 5 || verag.Mind != 0 {
+		t.Errorf("Expected overridden body, attack and mind, got %+v", verag)
+	}
+	if verag.DefenseDice != 5 || verag.MovementRange != 6 {
+		t.Errorf("Expected the card's defense and movement to stay, got %d / %d", verag.DefenseDice, verag.MovementRange)
+	}
+
+	lite := toMonsterLite(verag)
+	if lite.Name != "Verag the Gargoyle" || len(lite.Overrides) != 3 || lite.Overrides[MonsterStatBody] != 5 {
+		t.Errorf("Expected the GM to see Verag's name and overrides, got %+v", lite)
+	}
+}
+
+func TestMonsterVariants_QuestNoteModifiesMonsterOnce(t *testing.T) {
+	ms := createTestBestiary(t)
+	if _, err := ms.SpawnQuestMonster(geometry.QuestMonster{ID: "guardian", Type: "gargoyle", X: 8, Y: 5}); err != nil {
+		t.Fatalf("Failed to spawn guardian: %v", err)
+	}
+
+	quest := &geometry.QuestDefinition{QuestNotes: map[string]*geometry.QuestTreasureNote{
+		"idol": {
+			TreasureType:    "monster_modifier",
+			Description:     "The idol's eyes glow and the guardian stirs",
+			Location:        geometry.TreasureLocation{Room: 3, X: 2, Y: 2},
+			MonsterModifier: &geometry.MonsterModifier{MonsterID: "guardian", AttackDiceBonus: 1, DefenseDiceBonus: 2},
+		},
+	}}
+	resolver := NewTreasureResolver(NewContentManager(&MockLogger{}), nil, quest, &MockLogger{})
+
+	for range 2 {
+		result, err := resolver.ResolveTreasureSearch("hero-1", 3, protocol.TileAddress{X: 2, Y: 2}, "")
+		if err != nil || result.MonsterModifier == nil {
+			t.Fatalf("Expected the note's monster modifier, got %+v (%v)", result, err)
+		}
+		if _, err := ms.ApplyMonsterModifier(result.NoteID, result.MonsterModifier); err != nil {
+			t.Fatalf("Expected the modifier to apply, got: %v", err)
+		}
+	}
+
+	guardian := ms.findMonster("guardian")
+	if guardian.AttackDice != 5 || guardian.DefenseDice != 7 {
+		t.Errorf("Expected +1 attack and +2 defense applied once, got %d / %d", guardian.AttackDice, guardian.DefenseDice)
+	}
+	if guardian.Overrides[MonsterStatDefense] != 7 {
+		t.Errorf("Expected the modified defense in the overrides, got %v", guardian.Overrides)
+	}
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// Quest objective types (geometry.QuestObjective.Type)
+const (
+	ObjectiveKillMonster  = "kill_monster"  // Target: quest or board monster ID
+	ObjectiveRetrieveItem = "retrieve_item" // Target: item ID carried by a living hero
+	ObjectiveReachTile    = "reach_tile"    // Target: "x,y"
+	ObjectiveReachRoom    = "reach_room"    // Target: room number
+	ObjectiveEscape       = "escape"        // Target: stairway room, by default the quest's starting room
+	ObjectiveSurviveTurns = "survive_turns" // Target: number of full turn cycles
+	ObjectiveAll          = "all"           // Every nested objective
+	ObjectiveAny          = "any"           // At least one nested objective
+)
+
+// QuestObjectiveTracker evaluates the quest's objectives as the game is played and ends the
+// quest in victory once every top-level objective is met. Kills, items and turn cycles are
+// read from the game systems; the tiles and rooms heroes stand on are recorded at each check,
+// since the board only shows where they are now.
+type QuestObjectiveTracker struct {
+	objectives       []geometry.QuestObjective
+	stairway         int
+	gameState        *GameState
+	turnManager      *TurnManager
+	monsterSystem    *MonsterSystem
+	inventoryManager *InventoryManager
+	heroLifecycle    *HeroLifecycleSystem
+	dynamicTurnOrder *DynamicTurnOrderManager
+	broadcaster      Broadcaster
+	logger           Logger
+
+	tiles    map[string]bool         // "x,y" tiles a hero has stood on
+	rooms    map[string]map[int]bool // heroID -> rooms the hero has stood in
+	current  map[string]int          // Room each living hero stood in at the last check
+	progress []protocol.QuestObjectiveLite
+	mutex    sync.Mutex
+	checking sync.Mutex // One check at a time, so the quest is announced as won only once
+}
+
+// NewQuestObjectiveTracker creates a tracker for the quest's objectives
+func NewQuestObjectiveTracker(quest *geometry.QuestDefinition, gameState *GameState, turnManager *TurnManager, monsterSystem *MonsterSystem, inventoryManager *InventoryManager, heroLifecycle *HeroLifecycleSystem, dynamicTurnOrder *DynamicTurnOrderManager, broadcaster Broadcaster, logger Logger) *QuestObjectiveTracker {
+	ot := &QuestObjectiveTracker{
+		gameState:        gameState,
+		turnManager:      turnManager,
+		monsterSystem:    monsterSystem,
+		inventoryManager: inventoryManager,
+		heroLifecycle:    heroLifecycle,
+		dynamicTurnOrder: dynamicTurnOrder,
+		broadcaster:      broadcaster,
+		logger:           logger,
+		tiles:            make(map[string]bool),
+		rooms:            make(map[string]map[int]bool),
+		current:          make(map[string]int),
+	}
+	if quest != nil {
+		ot.objectives = quest.Objectives
+		ot.stairway = quest.StartingRoom
+	}
+	return ot
+}
+
+// Check records where the heroes stand, announces any change in progress and ends the quest
+// in victory once every objective is met. Returns the outcome if this check ended the quest.
+// It is called after each game message, so every kill, pickup, step and new turn cycle is seen.
+func (ot *QuestObjectiveTracker) Check() *QuestOutcome {
+	ot.checking.Lock()
+	defer ot.checking.Unlock()
+
+	if len(ot.objectives) == 0 || ot.dynamicTurnOrder.GetQuestOutcome() != nil {
+		return nil
+	}
+
+	ot.recordHeroPositions()
+	progress := ot.GetProgress()
+
+	ot.mutex.Lock()
+	changed := !reflect.DeepEqual(progress, ot.progress)
+	ot.progress = progress
+	ot.mutex.Unlock()
+	if changed {
+		ot.broadcaster.BroadcastEvent("QuestProgressChanged", protocol.QuestProgressChanged{Objectives: progress})
+	}
+
+	for _, objective := range progress {
+		if !objective.Met {
+			return nil
+		}
+	}
+
+	outcome := ot.dynamicTurnOrder.EndQuest(QuestVictory, "every objective is complete")
+	ot.broadcaster.BroadcastEvent("QuestEnded", protocol.QuestEnded{
+		Result:  outcome.Result,
+		Reason:  outcome.Reason,
+		Cycle:   outcome.Cycle,
+		Summary: ot.Summary(),
+	})
+	return outcome
+}
+
+// GetProgress evaluates every objective against the game as it stands
+func (ot *QuestObjectiveTracker) GetProgress() []protocol.QuestObjectiveLite {
+	ot.mutex.Lock()
+	defer ot.mutex.Unlock()
+
+	progress := make([]protocol.QuestObjectiveLite, 0, len(ot.objectives))
+	for _, objective := range ot.objectives {
+		progress = append(progress, ot.evaluateLocked(objective))
+	}
+	return progress
+}
+
+// Summary recaps the quest for the end-of-quest screen. It does not lock other systems'
+// state beyond reads, so the hero lifecycle can call it while ending the quest in defeat.
+func (ot *QuestObjectiveTracker) Summary() *protocol.QuestSummary {
+	summary := &protocol.QuestSummary{
+		Objectives: ot.GetProgress(),
+		Cycles:     ot.dynamicTurnOrder.GetCycleNumber(),
+	}
+	for _, monster := range ot.monsterSystem.GetMonsters() {
+		if !monster.IsAlive {
+			summary.MonstersKilled++
+		}
+	}
+	return summary
+}
+
+// recordHeroPositions notes the tile and room of every living hero on the board
+func (ot *QuestObjectiveTracker) recordHeroPositions() {
+	heroes := ot.turnManager.GetHeroPlayers()
+	alive := make(map[string]bool, len(heroes))
+	for _, player := range heroes {
+		alive[player.EntityID] = ot.heroLifecycle == nil || !ot.heroLifecycle.IsDead(player.EntityID)
+	}
+
+	ot.gameState.Lock.Lock()
+	positions := make(map[string]protocol.TileAddress, len(heroes))
+	rooms := make(map[string]int, len(heroes))
+	for heroID, living := range alive {
+		pos, onBoard := ot.gameState.Entities[heroID]
+		if !living || !onBoard {
+			continue
+		}
+		positions[heroID] = pos
+		if room, ok := regionAt(ot.gameState, pos); ok {
+			rooms[heroID] = room
+		}
+	}
+	ot.gameState.Lock.Unlock()
+
+	ot.mutex.Lock()
+	defer ot.mutex.Unlock()
+
+	for _, pos := range positions {
+		ot.tiles[fmt.Sprintf("%d,%d", pos.X, pos.Y)] = true
+	}
+	for heroID, room := range rooms {
+		if ot.rooms[heroID] == nil {
+			ot.rooms[heroID] = make(map[int]bool)
+		}
+		ot.rooms[heroID][room] = true
+	}
+	ot.current = rooms
+}
+
+// evaluateLocked works out whether an objective is met. The caller must hold the mutex.
+func (ot *QuestObjectiveTracker) evaluateLocked(objective geometry.QuestObjective) protocol.QuestObjectiveLite {
+	lite := protocol.QuestObjectiveLite{Type: objective.Type, Target: objective.Target, Description: objective.Description}
+
+	switch objective.Type {
+	case ObjectiveKillMonster:
+		found := false
+		lite.Met = true
+		for _, monster := range ot.monsterSystem.GetMonsters() {
+			if monster.ID == objective.Target || monster.QuestMonsterID == objective.Target {
+				found = true
+				lite.Met = lite.Met && !monster.IsAlive
+			}
+		}
+		lite.Met = lite.Met && found
+
+	case ObjectiveRetrieveItem:
+		for heroID := range ot.current {
+			if ot.inventoryManager.HasItem(heroID, objective.Target) {
+				lite.Met = true
+			}
+		}
+
+	case ObjectiveReachTile:
+		var x, y int
+		if _, err := fmt.Sscanf(objective.Target, "%d,%d", &x, &y); err == nil {
+			lite.Met = ot.tiles[fmt.Sprintf("%d,%d", x, y)]
+		}
+
+	case ObjectiveReachRoom:
+		if room, err := strconv.Atoi(strings.TrimPrefix(objective.Target, "room-")); err == nil {
+			for _, visited := range ot.rooms {
+				lite.Met = lite.Met || visited[room]
+			}
+		}
+
+	case ObjectiveEscape:
+		lite.Met, lite.Progress = ot.escapedLocked(objective.Target)
+
+	case ObjectiveSurviveTurns:
+		turns, err := strconv.Atoi(objective.Target)
+		if err != nil {
+			break
+		}
+		survived := min(max(ot.dynamicTurnOrder.GetCycleNumber()-1, 0), turns)
+		lite.Met = survived >= turns && len(ot.current) > 0
+		lite.Progress = fmt.Sprintf("%d/%d", survived, turns)
+
+	case ObjectiveAll, ObjectiveAny:
+		met := 0
+		for _, part := range objective.Objectives {
+			partLite := ot.evaluateLocked(part)
+			if partLite.Met {
+				met++
+			}
+			lite.Objectives = append(lite.Objectives, partLite)
+		}
+		if objective.Type == ObjectiveAll {
+			lite.Met = len(objective.Objectives) > 0 && met == len(objective.Objectives)
+		} else {
+			lite.Met = met > 0
+		}
+		lite.Progress = fmt.Sprintf("%d/%d", met, len(objective.Objectives))
+
+	default:
+		ot.logger.Printf("Warning: unknown quest objective type %q", objective.Type)
+	}
+
+	return lite
+}
+
+// escapedLocked reports whether every living hero has left the stairway and come back to it.
+// The caller must hold the mutex.
+func (ot *QuestObjectiveTracker) escapedLocked(target string) (bool, string) {
+	stairway := ot.stairway
+	if room, err := strconv.Atoi(strings.TrimPrefix(target, "room-")); err == nil {
+		stairway = room
+	}
+
+	escaped := 0
+	for heroID, room := range ot.current {
+		leftStairway := false
+		for visited := range ot.rooms[heroID] {
+			leftStairway = leftStairway || visited != stairway
+		}
+		if room == stairway && leftStairway {
+			escaped++
+		}
+	}
+	return len(ot.current) > 0 && escaped == len(ot.current), fmt.Sprintf("%d/%d", escaped, len(ot.current))
+}
+
+// objectiveTrackerPersistence is the serialized form of QuestObjectiveTracker
+type objectiveTrackerPersistence struct {
+	Tiles map[string]bool         `json:"tiles"`
+	Rooms map[string]map[int]bool `json:"rooms"`
+}
+
+// SerializeForPersistence serializes the tiles and rooms the heroes have reached
+func (ot *QuestObjectiveTracker) SerializeForPersistence() ([]byte, error) {
+	ot.mutex.Lock()
+	defer ot.mutex.Unlock()
+
+	return json.Marshal(objectiveTrackerPersistence{Tiles: ot.tiles, Rooms: ot.rooms})
+}
+
+// RestoreFromPersistence restores the tiles and rooms the heroes have reached
+func (ot *QuestObjectiveTracker) RestoreFromPersistence(data []byte) error {
+	var restored objectiveTrackerPersistence
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return err
+	}
+
+	ot.mutex.Lock()
+	defer ot.mutex.Unlock()
+
+	ot.tiles = orEmpty(restored.Tiles)
+	ot.rooms = orEmpty(restored.Rooms)
+	ot.progress = nil
+	return nil
+}
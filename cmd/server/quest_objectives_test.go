@@ -0,0 +1,138 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// createTestObjectiveTracker tracks the objectives for hero-1 and hero-2, both in room 2 of the
+// secret door board, which is the quest's stairway
+func createTestObjectiveTracker(t *testing.T, phase TurnPhaseType, objectives ...geometry.QuestObjective) (*HeroActionSystem, *QuestObjectiveTracker, *MockBroadcaster) {
+	has, lifecycle := createTestLifecycle(t, phase)
+	setupSecretDoorRooms(has)
+
+	broadcaster := &MockBroadcaster{}
+	quest := &geometry.QuestDefinition{StartingRoom: 2, Objectives: objectives}
+	tracker := NewQuestObjectiveTracker(quest, has.gameState, has.turnManager, has.monsterSystem, has.inventoryManager, lifecycle, has.dynamicTurnOrder, broadcaster, &MockLogger{})
+	lifecycle.SetQuestSummary(tracker.Summary)
+	return has, tracker, broadcaster
+}
+
+func countEvents(broadcaster *MockBroadcaster, eventType string) int {
+	count := 0
+	for _, event := range broadcaster.events {
+		if event.EventType == eventType {
+			count++
+		}
+	}
+	return count
+}
+
+func TestQuestObjectives_AllAndAnyComposition(t *testing.T) {
+	has, tracker, broadcaster := createTestObjectiveTracker(t, HeroPhaseActive, geometry.QuestObjective{
+		Type: ObjectiveAll,
+		Objectives: []geometry.QuestObjective{
+			{Type: ObjectiveKillMonster, Target: "boss"},
+			{Type: ObjectiveAny, Objectives: []geometry.QuestObjective{
+				{Type: ObjectiveRetrieveItem, Target: "talisman"},
+				{Type: ObjectiveReachTile, Target: "1,1"},
+			}},
+		},
+	})
+	goblin, _ := has.monsterSystem.GetMonsterByID("monster-1")
+	goblin.QuestMonsterID = "boss"
+
+	tracker.Check()
+	tracker.Check()
+	if countEvents(broadcaster, "QuestProgressChanged") != 1 {
+		t.Errorf("Expected progress to be announced only when it changes, got %d announcements", countEvents(broadcaster, "QuestProgressChanged"))
+	}
+
+	if err := has.monsterSystem.KillMonster("monster-1"); err != nil {
+		t.Fatalf("Failed to kill the goblin: %v", err)
+	}
+	if outcome := tracker.Check(); outcome != nil {
+		t.Fatalf("Expected the quest to go on until a branch of the 'any' is met, got %+v", outcome)
+	}
+	if progress := tracker.GetProgress()[0]; progress.Progress != "1/2" || !progress.Objectives[0].Met {
+		t.Errorf("Expected the kill to count as one of two parts, got %+v", progress)
+	}
+
+	has.gameState.Entities["hero-2"] = protocol.TileAddress{X: 1, Y: 1}
+	outcome := tracker.Check()
+	if outcome == nil || outcome.Result != QuestVictory || has.dynamicTurnOrder.GetCurrentPhase() != QuestEndedPhase {
+		t.Fatalf("Expected a victory once hero-2 reached (1,1), got %+v", outcome)
+	}
+	ended, ok := broadcaster.LastPayload.(protocol.QuestEnded)
+	if !ok || ended.Summary == nil || ended.Summary.MonstersKilled != 1 || !ended.Summary.Objectives[0].Met {
+		t.Errorf("Expected QuestEnded with a summary of one kill and the objective met, got %+v", broadcaster.LastPayload)
+	}
+}
+
+func TestQuestObjectives_ReachRoomAndEscapeSurviveRestore(t *testing.T) {
+	has, tracker, _ := createTestObjectiveTracker(t, HeroPhaseActive,
+		geometry.QuestObjective{Type: ObjectiveReachRoom, Target: "1"},
+		geometry.QuestObjective{Type: ObjectiveEscape},
+	)
+	tracker.Check()
+	if progress := tracker.GetProgress(); progress[1].Met {
+		t.Fatal("Expected heroes who never left the stairway not to have escaped")
+	}
+
+	has.gameState.Entities["hero-1"] = protocol.TileAddress{X: 2, Y: 2}
+	tracker.Check()
+	data, err := tracker.SerializeForPersistence()
+	if err != nil {
+		t.Fatalf("Failed to serialize objective progress: %v", err)
+	}
+
+	// The rooms reached travel with the saved game
+	_, restored, _ := createTestObjectiveTracker(t, HeroPhaseActive, tracker.objectives...)
+	if err := restored.RestoreFromPersistence(data); err != nil {
+		t.Fatalf("Failed to restore objective progress: %v", err)
+	}
+	restored.gameState.Entities["hero-1"] = protocol.TileAddress{X: 5, Y: 5}
+	if outcome := restored.Check(); outcome != nil {
+		t.Fatalf("Expected hero-2 to still have to leave the stairway, got %+v", outcome)
+	}
+	if progress := restored.GetProgress(); !progress[0].Met || progress[1].Progress != "1/2" {
+		t.Errorf("Expected room 1 reached and one hero of two escaped, got %+v", progress)
+	}
+
+	restored.gameState.Entities["hero-2"] = protocol.TileAddress{X: 3, Y: 3}
+	restored.Check()
+	restored.gameState.Entities["hero-2"] = protocol.TileAddress{X: 6, Y: 5}
+	if outcome := restored.Check(); outcome == nil || outcome.Result != QuestVictory {
+		t.Errorf("Expected a victory once both heroes were back on the stairway, got %+v", outcome)
+	}
+}
+
+func TestQuestObjectives_SurviveTurnsAndPartyWipeSummary(t *testing.T) {
+	has, tracker, _ := createTestObjectiveTracker(t, GMPhase, geometry.QuestObjective{Type: ObjectiveSurviveTurns, Target: "3"})
+	lifecycle := has.heroLifecycle
+	knockOut(has, lifecycle, "player-1")
+	knockOut(has, lifecycle, "player-2")
+	lifecycle.ResolveGMPhaseEnd(1)
+
+	has.dynamicTurnOrder.RestorePhase(GMPhase, 2, "", "", nil)
+	if outcome := tracker.Check(); outcome != nil {
+		t.Fatalf("Expected one of three turns survived to leave the quest going, got %+v", outcome)
+	}
+
+	outcome := lifecycle.ResolveGMPhaseEnd(2)
+	if outcome == nil || outcome.Result != QuestDefeat {
+		t.Fatalf("Expected a defeat once every hero died, got %+v", outcome)
+	}
+	ended, ok := lifecycle.broadcaster.(*MockBroadcaster).LastPayload.(protocol.QuestEnded)
+	if !ok || ended.Summary == nil || ended.Summary.Cycles != 2 || ended.Summary.Objectives[0].Progress != "1/3" {
+		t.Errorf("Expected the defeat to carry a summary with 1/3 turns survived, got %+v", ended)
+	}
+
+	// No later check turns a lost quest into a victory
+	has.dynamicTurnOrder.RestorePhase(GMPhase, 5, "", "", nil)
+	if outcome := tracker.Check(); outcome != nil {
+		t.Errorf("Expected the lost quest to stay lost, got %+v", outcome)
+	}
+}
@@ -0,0 +1,464 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// Quest trigger events (geometry.QuestTrigger.Event)
+const (
+	TriggerOnRoomEntered       = "on_room_entered"       // Target: room number; a hero steps into the room
+	TriggerOnDoorOpened        = "on_door_opened"        // Target: quest door ID
+	TriggerOnMonsterKilled     = "on_monster_killed"     // Target: quest or board monster ID
+	TriggerOnFurnitureSearched = "on_furniture_searched" // Target: furniture ID searched for treasure
+	TriggerOnTurn              = "on_turn"               // Target: turn cycle number
+)
+
+// Quest trigger actions (geometry.QuestTriggerAction.Type)
+const (
+	TriggerActionSpawnMonster  = "spawn_monster"
+	TriggerActionRevealText    = "reveal_text"
+	TriggerActionOpenDoor      = "open_door"
+	TriggerActionLockDoor      = "lock_door"
+	TriggerActionGrantItem     = "grant_item"
+	TriggerActionModifyMonster = "modify_monster"
+	TriggerActionEndQuest      = "end_quest"
+)
+
+// questEvent is something that happened in the quest, which triggers with a matching event
+// and target respond to
+type questEvent struct {
+	Type    string
+	Targets []string // IDs a trigger's target may name, e.g. a monster's board and quest IDs
+	HeroID  string   // Hero who set the event off, if any
+}
+
+// QuestTriggerSystem fires the quest's scripted triggers. Like the objective tracker it works
+// out what happened since the last check from the game systems (rooms the heroes stand in,
+// open doors, dead monsters, the turn cycle); furniture searches are recorded as they happen.
+type QuestTriggerSystem struct {
+	triggers         []geometry.QuestTrigger
+	doors            map[string]string // Quest door ID -> board door ID
+	gameState        *GameState
+	turnManager      *TurnManager
+	monsterSystem    *MonsterSystem
+	inventoryManager *InventoryManager
+	heroLifecycle    *HeroLifecycleSystem
+	dynamicTurnOrder *DynamicTurnOrderManager
+	broadcaster      Broadcaster
+	logger           Logger
+	questSummary     func() *protocol.QuestSummary
+
+	fired     map[string]int  // Trigger ID -> times fired
+	rooms     map[string]int  // Room each living hero stood in at the last check
+	openDoors map[string]bool // Board doors open at the last check
+	dead      map[string]bool // Monsters dead at the last check
+	cycle     int             // Last turn cycle seen
+	searches  []questEvent    // Furniture searches since the last check
+	mutex     sync.Mutex
+	checking  sync.Mutex // One check at a time, so no event fires a trigger twice
+}
+
+// NewQuestTriggerSystem creates the trigger system for a quest. Doors already open when the
+// quest starts do not count as opened.
+func NewQuestTriggerSystem(quest *geometry.QuestDefinition, gameState *GameState, turnManager *TurnManager, monsterSystem *MonsterSystem, inventoryManager *InventoryManager, heroLifecycle *HeroLifecycleSystem, dynamicTurnOrder *DynamicTurnOrderManager, broadcaster Broadcaster, logger Logger) *QuestTriggerSystem {
+	ts := &QuestTriggerSystem{
+		doors:            make(map[string]string),
+		gameState:        gameState,
+		turnManager:      turnManager,
+		monsterSystem:    monsterSystem,
+		inventoryManager: inventoryManager,
+		heroLifecycle:    heroLifecycle,
+		dynamicTurnOrder: dynamicTurnOrder,
+		broadcaster:      broadcaster,
+		logger:           logger,
+		fired:            make(map[string]int),
+		rooms:            make(map[string]int),
+		openDoors:        make(map[string]bool),
+		dead:             make(map[string]bool),
+	}
+	if quest == nil {
+		return ts
+	}
+
+	ts.triggers = slices.Clone(quest.Triggers)
+	for i := range ts.triggers {
+		if ts.triggers[i].ID == "" {
+			ts.triggers[i].ID = fmt.Sprintf("trigger-%d", i+1)
+		}
+	}
+
+	gameState.Lock.Lock()
+	defer gameState.Lock.Unlock()
+	for i, edge := range geometry.ConvertQuestDoorsToEdges(quest.Doors) {
+		if id, ok := gameState.DoorByEdge[edge]; ok {
+			ts.doors[quest.Doors[i].ID] = id
+		}
+	}
+	for id, door := range gameState.Doors {
+		if door.State == "open" {
+			ts.openDoors[id] = true
+		}
+	}
+	return ts
+}
+
+// SetQuestSummary sets the function that recaps the quest when a trigger ends it
+func (ts *QuestTriggerSystem) SetQuestSummary(summary func() *protocol.QuestSummary) {
+	ts.questSummary = summary
+}
+
+// SetQuestTriggers sets the trigger system told about furniture searched for treasure
+func (has *HeroActionSystem) SetQuestTriggers(triggers *QuestTriggerSystem) {
+	has.questTriggers = triggers
+}
+
+// RecordFurnitureSearch notes a hero searching a piece of furniture for treasure
+func (ts *QuestTriggerSystem) RecordFurnitureSearch(heroID, furnitureID string) {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	ts.searches = append(ts.searches, questEvent{Type: TriggerOnFurnitureSearched, Targets: []string{furnitureID}, HeroID: heroID})
+}
+
+// Check fires every trigger matching what happened since the last check. Returns the outcome
+// if a trigger ended the quest. It is called after each game message, alongside the objectives.
+func (ts *QuestTriggerSystem) Check() *QuestOutcome {
+	ts.checking.Lock()
+	defer ts.checking.Unlock()
+
+	events := ts.collectEvents()
+	if len(ts.triggers) == 0 || ts.dynamicTurnOrder.GetQuestOutcome() != nil {
+		return nil
+	}
+
+	for _, event := range events {
+		for i := range ts.triggers {
+			trigger := &ts.triggers[i]
+			if !ts.matches(trigger, event) {
+				continue
+			}
+			if outcome := ts.fire(trigger, event); outcome != nil {
+				return outcome
+			}
+		}
+	}
+	return nil
+}
+
+// collectEvents works out the events since the last check and records the game as it now stands
+func (ts *QuestTriggerSystem) collectEvents() []questEvent {
+	events := make([]questEvent, 0)
+
+	// Heroes stepping into a room
+	rooms := make(map[string]int)
+	ts.gameState.Lock.Lock()
+	for _, player := range ts.turnManager.GetHeroPlayers() {
+		pos, onBoard := ts.gameState.Entities[player.EntityID]
+		if !onBoard || (ts.heroLifecycle != nil && ts.heroLifecycle.IsDead(player.EntityID)) {
+			continue
+		}
+		if room, ok := regionAt(ts.gameState, pos); ok {
+			rooms[player.EntityID] = room
+		}
+	}
+
+	// Doors opening; a door locked again may open, and fire its triggers, once more
+	openDoors := make(map[string]bool)
+	for id, door := range ts.gameState.Doors {
+		if door.State == "open" {
+			openDoors[id] = true
+		}
+	}
+	corridor := ts.gameState.CorridorRegion
+	ts.gameState.Lock.Unlock()
+
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	for _, heroID := range sortedKeys(rooms) {
+		room := rooms[heroID]
+		if previous, seen := ts.rooms[heroID]; room != corridor && (!seen || previous != room) {
+			events = append(events, questEvent{Type: TriggerOnRoomEntered, Targets: []string{strconv.Itoa(room)}, HeroID: heroID})
+		}
+	}
+	ts.rooms = rooms
+
+	for _, id := range sortedKeys(openDoors) {
+		if !ts.openDoors[id] {
+			events = append(events, questEvent{Type: TriggerOnDoorOpened, Targets: []string{ts.questDoorID(id), id}})
+		}
+	}
+	ts.openDoors = openDoors
+
+	// Monsters dying
+	monsters := ts.monsterSystem.GetMonsters()
+	for _, id := range sortedKeys(monsters) {
+		if monster := monsters[id]; !monster.IsAlive && !ts.dead[monster.ID] {
+			ts.dead[monster.ID] = true
+			events = append(events, questEvent{Type: TriggerOnMonsterKilled, Targets: []string{monster.ID, monster.QuestMonsterID}})
+		}
+	}
+
+	events = append(events, ts.searches...)
+	ts.searches = nil
+
+	// New turn cycles
+	cycle := ts.dynamicTurnOrder.GetCycleNumber()
+	for c := ts.cycle + 1; c <= cycle; c++ {
+		events = append(events, questEvent{Type: TriggerOnTurn, Targets: []string{strconv.Itoa(c)}})
+	}
+	ts.cycle = max(ts.cycle, cycle)
+
+	return events
+}
+
+// matches reports whether a trigger responds to an event. Triggers that are not repeatable
+// respond only until they first fire; repeatable on_turn triggers fire every Target cycles.
+func (ts *QuestTriggerSystem) matches(trigger *geometry.QuestTrigger, event questEvent) bool {
+	if trigger.Event != event.Type {
+		return false
+	}
+	ts.mutex.Lock()
+	fired := ts.fired[trigger.ID]
+	ts.mutex.Unlock()
+	if fired > 0 && !trigger.Repeatable {
+		return false
+	}
+
+	target := trigger.Target
+	switch trigger.Event {
+	case TriggerOnRoomEntered:
+		target = strings.TrimPrefix(target, "room-")
+	case TriggerOnTurn:
+		every, err := strconv.Atoi(target)
+		cycle, _ := strconv.Atoi(event.Targets[0])
+		if target == "" || err != nil || !trigger.Repeatable {
+			break
+		}
+		return every > 0 && cycle%every == 0
+	}
+	return target == "" || slices.Contains(event.Targets, target)
+}
+
+// fire runs a trigger's actions and announces it, along with any text it reveals
+func (ts *QuestTriggerSystem) fire(trigger *geometry.QuestTrigger, event questEvent) *QuestOutcome {
+	ts.mutex.Lock()
+	ts.fired[trigger.ID]++
+	times := ts.fired[trigger.ID]
+	ts.mutex.Unlock()
+	ts.logger.Printf("Quest trigger %s fired on %s %v (time %d)", trigger.ID, event.Type, event.Targets, times)
+
+	announcement := protocol.QuestTriggerFired{TriggerID: trigger.ID, Event: event.Type, HeroID: event.HeroID}
+	for _, action := range trigger.Actions {
+		if action.Type == TriggerActionRevealText && action.Text != "" {
+			announcement.Messages = append(announcement.Messages, action.Text)
+		}
+	}
+	ts.broadcaster.BroadcastEvent("QuestTriggerFired", announcement)
+
+	for _, action := range trigger.Actions {
+		var err error
+		switch action.Type {
+		case TriggerActionRevealText:
+			// Announced above
+		case TriggerActionSpawnMonster:
+			if action.Monster == nil {
+				err = fmt.Errorf("no monster to spawn")
+				break
+			}
+			_, err = ts.monsterSystem.SpawnTriggeredMonster(*action.Monster)
+		case TriggerActionOpenDoor:
+			err = ts.setDoorState(action.DoorID, "open")
+		case TriggerActionLockDoor:
+			err = ts.setDoorState(action.DoorID, "locked")
+		case TriggerActionGrantItem:
+			err = ts.grantItems(action, event.HeroID)
+		case TriggerActionModifyMonster:
+			if action.Modifier == nil {
+				err = fmt.Errorf("no monster modifier")
+				break
+			}
+			// Each firing of a repeatable trigger modifies the monster again
+			_, err = ts.monsterSystem.ApplyMonsterModifier(fmt.Sprintf("%s#%d", trigger.ID, times), action.Modifier)
+		case TriggerActionEndQuest:
+			return ts.endQuest(action)
+		default:
+			err = fmt.Errorf("unknown action %q", action.Type)
+		}
+		if err != nil {
+			ts.logger.Printf("Warning: quest trigger %s: %s failed: %v", trigger.ID, action.Type, err)
+		}
+	}
+	return nil
+}
+
+// setDoorState opens or locks a quest door and announces it. Opening reveals the room beyond.
+func (ts *QuestTriggerSystem) setDoorState(questDoorID, state string) error {
+	id, ok := ts.doors[questDoorID]
+	if !ok {
+		return fmt.Errorf("door %s not found", questDoorID)
+	}
+
+	ts.gameState.Lock.Lock()
+	door := ts.gameState.Doors[id]
+	if door == nil {
+		ts.gameState.Lock.Unlock()
+		return fmt.Errorf("door %s not on the board", id)
+	}
+	door.State = state
+	var revealed []int
+	if state == "open" {
+		revealed = revealAcrossDoor(ts.gameState, door)
+	}
+	ts.gameState.Lock.Unlock()
+
+	ts.broadcaster.BroadcastEvent("DoorStateChanged", protocol.DoorStateChanged{ThresholdID: id, State: state})
+	if len(revealed) > 0 {
+		ts.broadcaster.BroadcastEvent("RegionsRevealed", protocol.RegionsRevealed{IDs: revealed})
+	}
+	return nil
+}
+
+// grantItems gives a trigger's items and gold to the hero who set it off, or to the hero
+// whose turn it is when no hero did
+func (ts *QuestTriggerSystem) grantItems(action geometry.QuestTriggerAction, heroID string) error {
+	if heroID == "" {
+		if player := ts.turnManager.GetCurrentPlayer(); player != nil {
+			heroID = player.EntityID
+		}
+	}
+	if heroID == "" {
+		return fmt.Errorf("no hero to grant items to")
+	}
+
+	for _, item := range action.Items {
+		if err := ts.inventoryManager.AddItem(heroID, item.ID); err != nil {
+			return err
+		}
+	}
+	if action.Gold > 0 {
+		if err := ts.inventoryManager.AddGold(heroID, action.Gold); err != nil {
+			return err
+		}
+	}
+
+	if inventory, err := ts.inventoryManager.GetInventory(heroID); err == nil {
+		ts.broadcaster.BroadcastEvent("InventoryChanged", inventoryToLite(inventory))
+	}
+	return nil
+}
+
+// endQuest ends the quest in the action's result and announces it with the quest's summary
+func (ts *QuestTriggerSystem) endQuest(action geometry.QuestTriggerAction) *QuestOutcome {
+	result := QuestVictory
+	if action.Result == QuestDefeat {
+		result = QuestDefeat
+	}
+	reason := action.Text
+	if reason == "" {
+		reason = "a quest event ended the quest"
+	}
+
+	outcome := ts.dynamicTurnOrder.EndQuest(result, reason)
+	ended := protocol.QuestEnded{Result: outcome.Result, Reason: outcome.Reason, Cycle: outcome.Cycle}
+	if ts.questSummary != nil {
+		ended.Summary = ts.questSummary()
+	}
+	ts.broadcaster.BroadcastEvent("QuestEnded", ended)
+	return outcome
+}
+
+// questDoorID returns the quest's ID for a board door, or "" if the quest did not place it
+func (ts *QuestTriggerSystem) questDoorID(boardID string) string {
+	for questID, id := range ts.doors {
+		if id == boardID {
+			return questID
+		}
+	}
+	return ""
+}
+
+// SpawnTriggeredMonster places a monster a quest trigger calls up on its square, or the
+// nearest free one, with its dread spells, and shows it to the heroes
+func (ms *MonsterSystem) SpawnTriggeredMonster(questMonster geometry.QuestMonster) (*Monster, error) {
+	position := protocol.TileAddress{X: questMonster.X, Y: questMonster.Y}
+	if !ms.isFreeSquare(position) {
+		free, found := ms.nearestFreeSquare(position)
+		if !found {
+			return nil, &GameError{Code: "no_space", Message: fmt.Sprintf("no free square for %s", questMonster.ID)}
+		}
+		questMonster.X, questMonster.Y = free.X, free.Y
+	}
+
+	monster, err := ms.SpawnQuestMonster(questMonster)
+	if err != nil {
+		return nil, err
+	}
+	for _, spell := range questMonster.DreadSpells {
+		if err := ms.AssignDreadSpell(monster.ID, spell.ID, spell.Uses); err != nil {
+			ms.logger.Printf("Warning: Failed to assign dread spell %s to %s: %v", spell.ID, monster.ID, err)
+		}
+	}
+
+	monster.IsVisible = true
+	ms.gameState.Lock.Lock()
+	ms.gameState.KnownMonsters[monster.ID] = true
+	ms.gameState.Lock.Unlock()
+	ms.broadcastMonsterUpdate(monster)
+	return monster, nil
+}
+
+// sortedKeys returns a map's keys in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// questTriggerPersistence is the serialized form of QuestTriggerSystem
+type questTriggerPersistence struct {
+	Fired     map[string]int  `json:"fired"`
+	Rooms     map[string]int  `json:"rooms"`
+	OpenDoors map[string]bool `json:"openDoors"`
+	Dead      map[string]bool `json:"dead"`
+	Cycle     int             `json:"cycle"`
+}
+
+// SerializeForPersistence serializes which triggers have fired and the game as last checked
+func (ts *QuestTriggerSystem) SerializeForPersistence() ([]byte, error) {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	return json.Marshal(questTriggerPersistence{Fired: ts.fired, Rooms: ts.rooms, OpenDoors: ts.openDoors, Dead: ts.dead, Cycle: ts.cycle})
+}
+
+// RestoreFromPersistence restores which triggers have fired and the game as last checked
+func (ts *QuestTriggerSystem) RestoreFromPersistence(data []byte) error {
+	var restored questTriggerPersistence
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return err
+	}
+
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	ts.fired = orEmpty(restored.Fired)
+	ts.rooms = orEmpty(restored.Rooms)
+	ts.openDoors = orEmpty(restored.OpenDoors)
+	ts.dead = orEmpty(restored.Dead)
+	ts.cycle = restored.Cycle
+	ts.searches = nil
+	return nil
+}
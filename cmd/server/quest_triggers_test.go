@@ -0,0 +1,154 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// createTestTriggers sets hero-1 (5,5) and hero-2 (6,5) in room 2 of the secret door rooms,
+// with the goblin (5,6) hidden, and a closed door "gate" between the rooms at (5,2)
+func createTestTriggers(t *testing.T, triggers ...geometry.QuestTrigger) (*HeroActionSystem, *QuestTriggerSystem, *MockBroadcaster) {
+	has, lifecycle := createTestLifecycle(t, HeroPhaseActive)
+	setupSecretDoorRooms(has)
+
+	gate := geometry.EdgeAddress{X: 5, Y: 2, Orientation: geometry.Vertical}
+	has.gameState.Doors = map[string]*DoorInfo{"door-gate": {Edge: gate, RegionA: 1, RegionB: 2, State: "closed"}}
+	has.gameState.DoorByEdge[gate] = "door-gate"
+	has.gameState.RevealedRegions[2] = true
+	has.gameState.KnownMonsters = make(map[string]bool)
+	has.monsterSystem.gameState = has.gameState // Monsters called up by triggers share the heroes' board
+
+	broadcaster := &MockBroadcaster{}
+	quest := &geometry.QuestDefinition{
+		StartingRoom: 2,
+		Doors:        []geometry.QuestDoor{{ID: "gate", X: 5, Y: 2, Orientation: "vertical", State: "closed"}},
+		Triggers:     triggers,
+	}
+	tracker := NewQuestObjectiveTracker(quest, has.gameState, has.turnManager, has.monsterSystem, has.inventoryManager, lifecycle, has.dynamicTurnOrder, broadcaster, &MockLogger{})
+	ts := NewQuestTriggerSystem(quest, has.gameState, has.turnManager, has.monsterSystem, has.inventoryManager, lifecycle, has.dynamicTurnOrder, broadcaster, &MockLogger{})
+	ts.SetQuestSummary(tracker.Summary)
+	has.SetQuestTriggers(ts)
+	return has, ts, broadcaster
+}
+
+func TestQuestTriggers_RoomEnteredSpawnsMonsterOnce(t *testing.T) {
+	has, ts, broadcaster := createTestTriggers(t, geometry.QuestTrigger{
+		ID:     "ambush",
+		Event:  TriggerOnRoomEntered,
+		Target: "room-1",
+		Actions: []geometry.QuestTriggerAction{
+			{Type: TriggerActionRevealText, Text: "The door slams behind you!"},
+			{Type: TriggerActionSpawnMonster, Monster: &geometry.QuestMonster{ID: "ambusher", Type: "orc", X: 2, Y: 5}},
+		},
+	})
+	has.monsterSystem.templates["orc"] = monsterTemplateFromCard(&MonsterCard{ID: "orc", Name: "Orc", Stats: MonsterStats{MovementSquares: 8, AttackDice: 3, DefendDice: 2, BodyPoints: 1, MindPoints: 2}})
+
+	ts.Check()
+	if countEvents(broadcaster, "QuestTriggerFired") != 0 {
+		t.Fatal("Expected no trigger while the heroes are in room 2")
+	}
+
+	has.gameState.Entities["hero-1"] = protocol.TileAddress{X: 2, Y: 5}
+	ts.Check()
+	fired, ok := broadcaster.events[0].Payload.(protocol.QuestTriggerFired)
+	if !ok || fired.TriggerID != "ambush" || fired.HeroID != "hero-1" || len(fired.Messages) != 1 {
+		t.Fatalf("Expected the ambush to be announced with its text, got %+v", broadcaster.events)
+	}
+	ambusher := has.monsterSystem.findMonster("ambusher")
+	if ambusher == nil || !ambusher.IsVisible || !has.gameState.KnownMonsters[ambusher.ID] {
+		t.Fatalf("Expected the ambusher to be spawned in sight, got %+v", ambusher)
+	}
+	if ambusher.Position.X == 2 && ambusher.Position.Y == 5 {
+		t.Errorf("Expected the ambusher to be moved off the hero's square, got %+v", ambusher.Position)
+	}
+
+	// Leaving and entering the room again does not spring the ambush twice
+	has.gameState.Entities["hero-1"] = protocol.TileAddress{X: 5, Y: 5}
+	ts.Check()
+	has.gameState.Entities["hero-1"] = protocol.TileAddress{X: 2, Y: 5}
+	ts.Check()
+	if countEvents(broadcaster, "QuestTriggerFired") != 1 {
+		t.Errorf("Expected a once-only trigger to fire once, got %d", countEvents(broadcaster, "QuestTriggerFired"))
+	}
+}
+
+func TestQuestTriggers_RepeatableTurnTriggerAndDoors(t *testing.T) {
+	has, ts, broadcaster := createTestTriggers(t,
+		geometry.QuestTrigger{ID: "rage", Event: TriggerOnTurn, Target: "2", Repeatable: true, Actions: []geometry.QuestTriggerAction{
+			{Type: TriggerActionModifyMonster, Modifier: &geometry.MonsterModifier{MonsterID: "monster-1", AttackDiceBonus: 1}},
+		}},
+		geometry.QuestTrigger{ID: "portcullis", Event: TriggerOnTurn, Target: "3", Actions: []geometry.QuestTriggerAction{
+			{Type: TriggerActionOpenDoor, DoorID: "gate"},
+		}},
+		geometry.QuestTrigger{ID: "trap-shut", Event: TriggerOnDoorOpened, Target: "gate", Actions: []geometry.QuestTriggerAction{
+			{Type: TriggerActionLockDoor, DoorID: "gate"},
+		}},
+	)
+	goblin, _ := has.monsterSystem.GetMonsterByID("monster-1")
+	attack := goblin.AttackDice
+
+	ts.Check()
+	has.dynamicTurnOrder.RestorePhase(HeroPhaseActive, 4, "player-1", "", nil)
+	ts.Check()
+	if goblin.AttackDice != attack+2 {
+		t.Errorf("Expected the goblin to grow stronger on turns 2 and 4, got %d attack dice", goblin.AttackDice)
+	}
+	if has.gameState.Doors["door-gate"].State != "open" || !has.gameState.RevealedRegions[1] {
+		t.Fatalf("Expected the gate opened on turn 3 to reveal room 1, got %+v", has.gameState.Doors["door-gate"])
+	}
+
+	// The gate opening springs the next trigger, which locks it shut
+	ts.Check()
+	if has.gameState.Doors["door-gate"].State != "locked" || broadcaster.LastEvent != "DoorStateChanged" {
+		t.Fatalf("Expected the gate to be locked, got %+v", has.gameState.Doors["door-gate"])
+	}
+	_, err := has.ProcessInstantAction(InstantActionRequest{PlayerID: "player-1", EntityID: "hero-1", Action: OpenDoorInstant, Parameters: map[string]any{"doorId": "door-gate"}})
+	expectGameErrorCode(t, err, "door_locked")
+}
+
+func TestQuestTriggers_SearchAndKillGrantItemsEndQuestAndPersist(t *testing.T) {
+	has, ts, broadcaster := createTestTriggers(t,
+		geometry.QuestTrigger{ID: "hidden-cache", Event: TriggerOnFurnitureSearched, Target: "chest", Actions: []geometry.QuestTriggerAction{
+			{Type: TriggerActionGrantItem, Items: []geometry.ItemReference{{ID: "shortsword"}}, Gold: 50},
+		}},
+		geometry.QuestTrigger{Event: TriggerOnMonsterKilled, Target: "warlord", Actions: []geometry.QuestTriggerAction{
+			{Type: TriggerActionEndQuest, Text: "the warlord has fallen"},
+		}},
+	)
+	has.inventoryManager.contentManager.equipmentCards["shortsword"] = &ItemCard{ID: "shortsword", Name: "Shortsword", Type: "weapon", AttackDice: 2}
+
+	ts.RecordFurnitureSearch("hero-2", "chest")
+	ts.Check()
+	inventory, _ := has.inventoryManager.GetInventory("hero-2")
+	if inventory.Gold != 50 || !has.inventoryManager.HasItem("hero-2", "shortsword") || broadcaster.LastEvent != "InventoryChanged" {
+		t.Fatalf("Expected the searching hero to get the cache, got %d gold (%+v)", inventory.Gold, broadcaster.events)
+	}
+
+	// A restored game remembers the cache was found
+	data, err := ts.SerializeForPersistence()
+	if err != nil {
+		t.Fatalf("Failed to serialize triggers: %v", err)
+	}
+	_, restored, restoredBroadcaster := createTestTriggers(t, ts.triggers...)
+	if err := restored.RestoreFromPersistence(data); err != nil {
+		t.Fatalf("Failed to restore triggers: %v", err)
+	}
+	restored.RecordFurnitureSearch("hero-2", "chest")
+	restored.Check()
+	if countEvents(restoredBroadcaster, "QuestTriggerFired") != 0 {
+		t.Errorf("Expected the restored cache not to be granted again, got %+v", restoredBroadcaster.events)
+	}
+
+	goblin, _ := has.monsterSystem.GetMonsterByID("monster-1")
+	goblin.QuestMonsterID, goblin.IsAlive = "warlord", false
+	outcome := ts.Check()
+	if outcome == nil || outcome.Result != QuestVictory || has.dynamicTurnOrder.GetQuestOutcome() == nil {
+		t.Fatalf("Expected the warlord's death to end the quest in victory, got %+v", outcome)
+	}
+	ended, ok := broadcaster.LastPayload.(protocol.QuestEnded)
+	if !ok || ended.Reason != "the warlord has fallen" || ended.Summary == nil || ended.Summary.MonstersKilled != 1 {
+		t.Errorf("Expected the quest end to be announced with its summary, got %+v", ended)
+	}
+}
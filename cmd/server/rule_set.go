@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// FixedMovementSquares is how far heroes move under the FixedMovementWhenClear rule
+const FixedMovementSquares = 8
+
+// RuleSet holds the optional house rules the GM picks in the lobby. The zero value plays
+// by the book. Systems keep a pointer to the game's RuleSet and consult it when they act,
+// so a restored game keeps the rules it was saved with. Rules are read and replaced under
+// mutex, since the lobby's choice lands while systems may be consulting them.
+type RuleSet struct {
+	mutex sync.RWMutex
+
+	// DoubleDiceEffects: rolling a double on two movement dice affects the hero's next attack.
+	// Double 1s roll one fewer attack die; double 2s to 5s reroll one missed attack die;
+	// double 6s reroll two.
+	DoubleDiceEffects bool `json:"doubleDiceEffects"`
+
+	// FixedMovementWhenClear: while no monster is in sight, heroes move 8 squares instead of
+	// rolling movement dice. Extra dice from potions and spells are still rolled on top.
+	FixedMovementWhenClear bool `json:"fixedMovementWhenClear"`
+
+	// NarrativeLineOfSight: line of sight is the GM's call. Ranged attacks, hero spells and
+	// dread spells skip the center-to-center check; range limits still apply.
+	NarrativeLineOfSight bool `json:"narrativeLineOfSight"`
+
+	// DelayedMonsterReveal: monsters stay hidden when their room is revealed until the GM
+	// reveals them with RequestRevealMonster.
+	DelayedMonsterReveal bool `json:"delayedMonsterReveal"`
+}
+
+// Effects earned by rolling doubles under the DoubleDiceEffects rule
+const (
+	rerollAttackDice = "reroll_attack_dice" // Reroll up to Value attack dice that missed
+)
+
+// ToLite returns a copy of the rules, for lobby and snapshot patches and for systems
+// consulting a rule
+func (r *RuleSet) ToLite() protocol.HouseRules {
+	if r == nil {
+		return protocol.HouseRules{}
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return protocol.HouseRules{
+		DoubleDiceEffects:      r.DoubleDiceEffects,
+		FixedMovementWhenClear: r.FixedMovementWhenClear,
+		NarrativeLineOfSight:   r.NarrativeLineOfSight,
+		DelayedMonsterReveal:   r.DelayedMonsterReveal,
+	}
+}
+
+// Replace swaps in the house rules chosen in the lobby
+func (r *RuleSet) Replace(lite protocol.HouseRules) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.DoubleDiceEffects = lite.DoubleDiceEffects
+	r.FixedMovementWhenClear = lite.FixedMovementWhenClear
+	r.NarrativeLineOfSight = lite.NarrativeLineOfSight
+	r.DelayedMonsterReveal = lite.DelayedMonsterReveal
+}
+
+// SerializeForPersistence serializes the house rules to JSON
+func (r *RuleSet) SerializeForPersistence() ([]byte, error) {
+	return json.Marshal(r.ToLite())
+}
+
+// RestoreFromPersistence replaces the house rules with previously serialized ones
+func (r *RuleSet) RestoreFromPersistence(data []byte) error {
+	var restored protocol.HouseRules
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return err
+	}
+	r.Replace(restored)
+	return nil
+}
+
+// checksLineOfSight reports whether attacks and spells must pass the line of sight check
+func (r *RuleSet) checksLineOfSight() bool {
+	return !r.ToLite().NarrativeLineOfSight
+}
+
+// SetRuleSet sets the house rules consulted for doubles and line of sight
+func (has *HeroActionSystem) SetRuleSet(rules *RuleSet) {
+	has.rules = rules
+	has.diceSystem.SetRuleSet(rules)
+}
+
+// SetRuleSet sets the house rules consulted for dread spells and monster reveal
+func (ms *MonsterSystem) SetRuleSet(rules *RuleSet) {
+	ms.rules = rules
+}
+
+// RevealsOnSight reports whether monsters appear as soon as the heroes can see them,
+// rather than waiting for the GM under the DelayedMonsterReveal rule
+func (ms *MonsterSystem) RevealsOnSight() bool {
+	return !ms.rules.ToLite().DelayedMonsterReveal
+}
+
+// SetRuleSet sets the house rules consulted for doubles
+func (ds *DiceSystem) SetRuleSet(rules *RuleSet) {
+	ds.rules = rules
+}
+
+// DoublesEffect returns the next-attack effect a movement roll earns under the
+// DoubleDiceEffects rule, or nil when the rule is off or the dice are not a double
+func (ds *DiceSystem) DoublesEffect(rolls []DiceRoll) *ActiveEffect {
+	if !ds.rules.ToLite().DoubleDiceEffects || len(rolls) != 2 || rolls[0].Result != rolls[1].Result {
+		return nil
+	}
+
+	effect := &ActiveEffect{Source: "double_dice", Trigger: "next_attack", ExpiresOn: "after_trigger"}
+	switch rolls[0].Result {
+	case 1:
+		effect.EffectType, effect.Value = SpellEffectBonusAttackDice, -1
+	case 6:
+		effect.EffectType, effect.Value = rerollAttackDice, 2
+	default:
+		effect.EffectType, effect.Value = rerollAttackDice, 1
+	}
+	return effect
+}
+
+// RerollMisses rerolls up to count attack dice that did not come up skulls
+func (ds *DiceSystem) RerollMisses(rolls []DiceRoll, count int) []DiceRoll {
+	for i := range rolls {
+		if count == 0 {
+			break
+		}
+		if rolls[i].CombatResult != Skull {
+			rolls[i] = ds.RollAttackDice(1)[0]
+			count--
+		}
+	}
+	return rolls
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+func TestRuleSet_DoubleOnesWeakenNextAttack(t *testing.T) {
+	has := createTestItemUser(t, nil, nil)
+	has.SetRuleSet(&RuleSet{DoubleDiceEffects: true})
+	has.debugSystem.SetDiceOverride("movement", 1)
+
+	roll := InstantActionRequest{PlayerID: "player-1", EntityID: "hero-1", Action: RollMovementInstant}
+	if _, err := has.ProcessInstantAction(roll); err != nil {
+		t.Fatalf("Expected the movement roll to succeed, got: %v", err)
+	}
+
+	result, err := has.ProcessAction(attackRequest(map[string]any{}))
+	if err != nil {
+		t.Fatalf("Expected the attack to succeed, got: %v", err)
+	}
+	barbarian := has.turnManager.GetPlayer("player-1").Character
+	if len(result.AttackRolls) != barbarian.GetEffectiveAttackDice()-1 {
+		t.Errorf("Expected double 1s to cost one attack die, got %d of %d", len(result.AttackRolls), barbarian.GetEffectiveAttackDice())
+	}
+
+	sixes := []DiceRoll{{Die: MovementDie, Result: 6}, {Die: MovementDie, Result: 6}}
+	if effect := has.diceSystem.DoublesEffect(sixes); effect == nil || effect.EffectType != rerollAttackDice || effect.Value != 2 {
+		t.Errorf("Expected double 6s to reroll two attack dice, got %+v", effect)
+	}
+	has.SetRuleSet(&RuleSet{})
+	if effect := has.diceSystem.DoublesEffect(sixes); effect != nil {
+		t.Errorf("Expected no doubles effect by the book, got %+v", effect)
+	}
+}
+
+func TestRuleSet_FixedMovementOnlyWhileClear(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		inSight   bool
+		wantTotal int
+	}{
+		{"clear", false, FixedMovementSquares + 2},
+		{"monster in sight", true, 2*2 + 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			has := createTestHeroActionSystem()
+			has.turnManager.SetRuleSet(&RuleSet{FixedMovementWhenClear: true}, func(string) bool { return tc.inSight })
+			has.debugSystem.SetDiceOverride("movement", 2)
+
+			// One extra die, as from a potion, is rolled on top either way
+			if _, err := has.turnManager.RollMovementDiceWithBonus(1); err != nil {
+				t.Fatalf("Expected movement to be rolled, got: %v", err)
+			}
+			if left := has.turnManager.GetTurnState().MovementLeft; left != tc.wantTotal {
+				t.Errorf("Expected %d squares of movement, got %d", tc.wantTotal, left)
+			}
+		})
+	}
+}
+
+func TestRuleSet_FixedMovementFollowsLineOfSight(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		walled    bool
+		wantTotal int
+	}{
+		// The goblin next door has not been revealed, but the hero can see it
+		{"unrevealed monster in sight", false, 2 * 2},
+		{"monster behind a wall", true, FixedMovementSquares},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			has := createTestHeroActionSystem()
+			has.monsterSystem.gameState = has.gameState
+			has.monsterSystem.monsters["monster-1"].IsVisible = false
+			if tc.walled {
+				has.gameState.BlockedWalls = map[geometry.EdgeAddress]bool{
+					{X: 5, Y: 6, Orientation: geometry.Horizontal}: true,
+				}
+			}
+			has.turnManager.SetRuleSet(&RuleSet{FixedMovementWhenClear: true}, has.monsterSystem.MonsterInSightOf)
+			has.debugSystem.SetDiceOverride("movement", 2)
+
+			if _, err := has.turnManager.RollMovementDice(); err != nil {
+				t.Fatalf("Expected movement to be rolled, got: %v", err)
+			}
+			if left := has.turnManager.GetTurnState().MovementLeft; left != tc.wantTotal {
+				t.Errorf("Expected %d squares of movement, got %d", tc.wantTotal, left)
+			}
+		})
+	}
+}
+
+func TestRuleSet_NarrativeLineOfSightAndDelayedReveal(t *testing.T) {
+	has := createTestArmedHero(t, &ItemCard{ID: "crossbow", Name: "Crossbow", Type: "weapon", AttackDice: 3, Ranged: true}, protocol.TileAddress{X: 5, Y: 9})
+	has.gameState.BlockedWalls = map[geometry.EdgeAddress]bool{
+		{X: 5, Y: 8, Orientation: geometry.Horizontal}: true,
+	}
+	rules := &RuleSet{NarrativeLineOfSight: true, DelayedMonsterReveal: true}
+	has.SetRuleSet(rules)
+	if _, err := has.ProcessAction(attackRequest(map[string]any{})); err != nil {
+		t.Errorf("Expected the GM's call to stand in for line of sight, got: %v", err)
+	}
+
+	ms := has.monsterSystem
+	ms.SetRuleSet(rules)
+	if ms.RevealsOnSight() {
+		t.Error("Expected monsters to wait for the GM to reveal them")
+	}
+	if err := ms.RevealMonster("monster-1"); err != nil || !ms.gameState.KnownMonsters["monster-1"] {
+		t.Errorf("Expected the GM's reveal to make the goblin known, got: %v", err)
+	}
+
+	// The rules travel with the saved game
+	data, err := rules.SerializeForPersistence()
+	if err != nil {
+		t.Fatalf("Failed to serialize house rules: %v", err)
+	}
+	restored := &RuleSet{}
+	if err := restored.RestoreFromPersistence(data); err != nil || restored.ToLite() != rules.ToLite() {
+		t.Errorf("Expected %+v after restore, got %+v (%v)", rules.ToLite(), restored.ToLite(), err)
+	}
+}
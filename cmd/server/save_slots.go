@@ -0,0 +1,242 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// SaveSlotInfo describes a saved session without its game data
+type SaveSlotInfo struct {
+	Slot        string    `json:"slot"`
+	GameID      string    `json:"gameId,omitempty"`
+	QuestID     string    `json:"questId,omitempty"`
+	CycleNumber int       `json:"cycleNumber"`
+	TurnPhase   string    `json:"turnPhase"`
+	SavedAt     time.Time `json:"savedAt"`
+}
+
+// SaveFile is the on-disk form of a saved session
+type SaveFile struct {
+	Info     SaveSlotInfo     `json:"info"`
+	Snapshot GameSnapshotData `json:"snapshot"`
+}
+
+// slotNamePattern keeps slot names safe to use as file names
+var slotNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+
+// ValidateSlotName checks that a save slot name is non-empty and file-system safe
+func ValidateSlotName(slot string) error {
+	if !slotNamePattern.MatchString(slot) {
+		return &GameError{Code: "invalid_slot", Message: "slot names must be 1-64 letters, digits, '-' or '_'"}
+	}
+	return nil
+}
+
+// SaveSlotStore keeps named saved sessions as JSON files, one file per slot
+type SaveSlotStore struct {
+	directory string
+	mutex     sync.Mutex
+}
+
+// GetSaveDirectoryFromEnv returns the save slot directory (SAVE_DIR, default data/saves)
+func GetSaveDirectoryFromEnv() string {
+	if dir := os.Getenv("SAVE_DIR"); dir != "" {
+		return dir
+	}
+	return "data/saves"
+}
+
+// NewSaveSlotStore creates a save slot store rooted at directory
+func NewSaveSlotStore(directory string) (*SaveSlotStore, error) {
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create save directory: %w", err)
+	}
+	return &SaveSlotStore{directory: directory}, nil
+}
+
+func (ss *SaveSlotStore) slotPath(slot string) string {
+	return filepath.Join(ss.directory, slot+".save.json")
+}
+
+// Save writes a session to a slot, overwriting any previous save in it
+func (ss *SaveSlotStore) Save(save SaveFile) error {
+	if err := ValidateSlotName(save.Info.Slot); err != nil {
+		return err
+	}
+
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	data, err := json.MarshalIndent(save, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal save: %w", err)
+	}
+
+	path := ss.slotPath(save.Info.Slot)
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return fmt.Errorf("failed to write save: %w", err)
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// Load reads the session stored in a slot
+func (ss *SaveSlotStore) Load(slot string) (*SaveFile, error) {
+	if err := ValidateSlotName(slot); err != nil {
+		return nil, err
+	}
+
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	data, err := os.ReadFile(ss.slotPath(slot))
+	if os.IsNotExist(err) {
+		return nil, &GameError{Code: "slot_not_found", Message: fmt.Sprintf("no save in slot %s", slot)}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read save: %w", err)
+	}
+
+	var save SaveFile
+	if err := json.Unmarshal(data, &save); err != nil {
+		return nil, fmt.Errorf("failed to parse save %s: %w", slot, err)
+	}
+	return &save, nil
+}
+
+// List returns every saved slot, most recent first
+func (ss *SaveSlotStore) List() ([]SaveSlotInfo, error) {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	paths, err := filepath.Glob(filepath.Join(ss.directory, "*.save.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saves: %w", err)
+	}
+
+	slots := make([]SaveSlotInfo, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read save: %w", err)
+		}
+
+		// Only the header is needed; skip decoding the game data
+		var header struct {
+			Info SaveSlotInfo `json:"info"`
+		}
+		if err := json.Unmarshal(data, &header); err != nil {
+			return nil, fmt.Errorf("failed to parse save %s: %w", filepath.Base(path), err)
+		}
+		if header.Info.Slot == "" {
+			header.Info.Slot = strings.TrimSuffix(filepath.Base(path), ".save.json")
+		}
+		slots = append(slots, header.Info)
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].SavedAt.After(slots[j].SavedAt)
+	})
+	return slots, nil
+}
+
+// Delete removes a saved slot
+func (ss *SaveSlotStore) Delete(slot string) error {
+	if err := ValidateSlotName(slot); err != nil {
+		return err
+	}
+
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	err := os.Remove(ss.slotPath(slot))
+	if os.IsNotExist(err) {
+		return &GameError{Code: "slot_not_found", Message: fmt.Sprintf("no save in slot %s", slot)}
+	}
+	return err
+}
+
+// SetSaveSlotStore enables named save slots for this game
+func (gm *GameManager) SetSaveSlotStore(store *SaveSlotStore) {
+	gm.saveSlots = store
+}
+
+// SaveSession captures the whole game into a named slot; only the GM may save
+func (gm *GameManager) SaveSession(playerID, slot string) (*SaveSlotInfo, error) {
+	if gm.saveSlots == nil {
+		return nil, &GameError{Code: "saves_disabled", Message: "save slots are not configured"}
+	}
+	if !gm.isGameMaster(playerID) {
+		return nil, &GameError{Code: "not_game_master", Message: "only the GM can save the game"}
+	}
+	if err := ValidateSlotName(slot); err != nil {
+		return nil, err
+	}
+
+	snapshot, err := gm.CaptureSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture session: %w", err)
+	}
+
+	info := SaveSlotInfo{
+		Slot:        slot,
+		QuestID:     snapshot.QuestID,
+		CycleNumber: snapshot.CycleNumber,
+		TurnPhase:   string(gm.dynamicTurnOrder.GetCurrentPhase()),
+		SavedAt:     snapshot.CreatedAt,
+	}
+	if gm.eventRecorder != nil {
+		info.GameID = gm.eventRecorder.GameID()
+	}
+
+	if err := gm.saveSlots.Save(SaveFile{Info: info, Snapshot: *snapshot}); err != nil {
+		return nil, err
+	}
+
+	gm.logger.Printf("Saved session to slot %s (cycle %d, phase %s)", slot, info.CycleNumber, info.TurnPhase)
+	return &info, nil
+}
+
+// LoadSession replaces the running game with the session stored in a slot; only the GM may load
+func (gm *GameManager) LoadSession(playerID, slot string) (*SaveSlotInfo, error) {
+	if gm.saveSlots == nil {
+		return nil, &GameError{Code: "saves_disabled", Message: "save slots are not configured"}
+	}
+	if !gm.isGameMaster(playerID) {
+		return nil, &GameError{Code: "not_game_master", Message: "only the GM can load a saved game"}
+	}
+
+	save, err := gm.saveSlots.Load(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := gm.ImportSnapshot(&save.Snapshot); err != nil {
+		return nil, err
+	}
+
+	gm.broadcaster.BroadcastEvent("SessionLoaded", protocol.SessionLoaded{
+		Slot:        save.Info.Slot,
+		CycleNumber: save.Info.CycleNumber,
+		TurnPhase:   save.Info.TurnPhase,
+	})
+
+	gm.logger.Printf("Loaded session from slot %s (cycle %d, phase %s)", slot, save.Info.CycleNumber, save.Info.TurnPhase)
+	return &save.Info, nil
+}
+
+// ListSessions returns the available save slots, most recent first
+func (gm *GameManager) ListSessions() ([]SaveSlotInfo, error) {
+	if gm.saveSlots == nil {
+		return nil, &GameError{Code: "saves_disabled", Message: "save slots are not configured"}
+	}
+	return gm.saveSlots.List()
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// newSaveTestGameManager adds save slots and a small treasure deck to the snapshot test manager
+func newSaveTestGameManager(t *testing.T, saveDir string) *GameManager {
+	gm := newSnapshotTestGameManager()
+
+	store, err := NewSaveSlotStore(saveDir)
+	if err != nil {
+		t.Fatalf("Failed to create save slot store: %v", err)
+	}
+	gm.SetSaveSlotStore(store)
+
+	for _, card := range []*TreasureCard{
+		{ID: "gold_25", Name: "25 Gold", Type: "gold", Value: 25},
+		{ID: "healing_potion", Name: "Potion of Healing", Type: "potion"},
+		{ID: "hazard_arrow", Name: "Arrow Trap", Type: "hazard", Damage: 1},
+	} {
+		gm.contentManager.treasureCards[card.ID] = card
+	}
+
+	return gm
+}
+
+func TestGameManager_SaveAndLoadSessionRoundTrip(t *testing.T) {
+	saveDir := t.TempDir()
+	gm := newSaveTestGameManager(t, saveDir)
+
+	// Build up a mid-quest state
+	player := NewPlayer("player-1", "Grug", "hero-1", Barbarian)
+	player.Character.CurrentBody = 3
+	gm.turnManager.AddPlayer(player)
+	gm.inventoryManager.InitializeHeroInventory("hero-1")
+	gm.inventoryManager.AddGold("hero-1", 120)
+	gm.monsterSystem.RestoreMonster(&Monster{ID: "monster_1", Type: Orc, Body: 1, MaxBody: 1, IsAlive: true, Position: protocol.TileAddress{X: 5, Y: 5}})
+	gm.gameState.Doors["door-1"].State = "open"
+	gm.gameState.RevealedRegions[4] = true
+	gm.gameState.Entities["hero-1"] = protocol.TileAddress{X: 2, Y: 3}
+	gm.turnStateManager.StartHeroTurn("hero-1", "player-1", protocol.TileAddress{X: 2, Y: 3})
+	gm.turnStateManager.RecordSearch("hero-1", "treasure", "room-4", "room", protocol.TileAddress{X: 2, Y: 3}, true, nil)
+	gm.treasureDeck.deck = []*TreasureCard{gm.contentManager.treasureCards["gold_25"], gm.contentManager.treasureCards["hazard_arrow"]}
+	gm.treasureDeck.discardPile = []*TreasureCard{gm.contentManager.treasureCards["healing_potion"]}
+	gm.dynamicTurnOrder.RestorePhase(HeroPhaseActive, 3, "player-1", "", nil)
+
+	info, err := gm.SaveSession("player-1", "week-1")
+	if err != nil {
+		t.Fatalf("SaveSession failed: %v", err)
+	}
+	if info.CycleNumber != 3 || info.TurnPhase != string(HeroPhaseActive) {
+		t.Errorf("Expected save at cycle 3 in hero phase, got cycle %d phase %s", info.CycleNumber, info.TurnPhase)
+	}
+
+	// Resume "next week" in a fresh server
+	loaded := newSaveTestGameManager(t, saveDir)
+	loaded.turnManager.AddPlayer(NewPlayer("player-1", "Grug", "hero-1", Barbarian))
+	if _, err := loaded.LoadSession("player-1", "week-1"); err != nil {
+		t.Fatalf("LoadSession failed: %v", err)
+	}
+
+	if p := loaded.turnManager.GetPlayer("player-1"); p == nil || p.Character.CurrentBody != 3 {
+		t.Errorf("Expected hero body points 3 after load, got %+v", p)
+	}
+	if inventory, err := loaded.inventoryManager.GetInventory("hero-1"); err != nil || inventory.Gold != 120 {
+		t.Errorf("Expected 120 gold after load, got %+v (%v)", inventory, err)
+	}
+	if monster, err := loaded.monsterSystem.GetMonsterByID("monster_1"); err != nil || monster.Body != 1 || !monster.IsAlive {
+		t.Errorf("Expected live monster_1 with 1 body point, got %+v (%v)", monster, err)
+	}
+	if loaded.gameState.Doors["door-1"].State != "open" {
+		t.Errorf("Expected door-1 open after load")
+	}
+	if !loaded.gameState.RevealedRegions[4] {
+		t.Errorf("Expected region 4 revealed after load")
+	}
+	if canSearch, _ := loaded.turnStateManager.CanSearchTreasure("hero-1", "room-4"); canSearch {
+		t.Errorf("Expected room-4 to stay searched after load")
+	}
+	if loaded.treasureDeck.GetDeckSize() != 2 || loaded.treasureDeck.GetDiscardSize() != 1 {
+		t.Errorf("Expected deck 2 / discard 1, got %d / %d", loaded.treasureDeck.GetDeckSize(), loaded.treasureDeck.GetDiscardSize())
+	}
+	if loaded.treasureDeck.deck[0].ID != "gold_25" {
+		t.Errorf("Expected draw order preserved, top card is %s", loaded.treasureDeck.deck[0].ID)
+	}
+	if loaded.dynamicTurnOrder.GetCurrentPhase() != HeroPhaseActive || loaded.dynamicTurnOrder.GetCycleNumber() != 3 {
+		t.Errorf("Expected hero phase cycle 3, got %s cycle %d", loaded.dynamicTurnOrder.GetCurrentPhase(), loaded.dynamicTurnOrder.GetCycleNumber())
+	}
+
+	slots, err := loaded.ListSessions()
+	if err != nil || len(slots) != 1 || slots[0].Slot != "week-1" {
+		t.Errorf("Expected one listed slot week-1, got %+v (%v)", slots, err)
+	}
+}
+
+func TestSaveSlotStore_RejectsUnsafeSlotNames(t *testing.T) {
+	store, _ := NewSaveSlotStore(t.TempDir())
+
+	for _, slot := range []string{"", "../escape", "a/b", "-leading"} {
+		if _, err := store.Load(slot); err == nil {
+			t.Errorf("Expected slot name %q to be rejected", slot)
+		}
+	}
+
+	if _, err := store.Load("missing"); err == nil {
+		t.Errorf("Expected an error loading an empty slot")
+	}
+}
+
+func TestGameManager_OnlyGMSavesAndLoadsCampaignSessions(t *testing.T) {
+	gm := newSaveTestGameManager(t, t.TempDir())
+	gm.SetCampaign(NewCampaignSession(nil, "gm-1", &MockLogger{}), nil)
+
+	_, err := gm.SaveSession("player-1", "week-1")
+	expectGameErrorCode(t, err, "not_game_master")
+	if _, err := gm.SaveSession("gm-1", "week-1"); err != nil {
+		t.Fatalf("Expected the GM to save, got: %v", err)
+	}
+
+	_, err = gm.LoadSession("player-1", "week-1")
+	expectGameErrorCode(t, err, "not_game_master")
+	if _, err := gm.LoadSession("gm-1", "week-1"); err != nil {
+		t.Errorf("Expected the GM to load, got: %v", err)
+	}
+}
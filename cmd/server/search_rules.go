@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+)
+
+// searchLocation is the room or corridor a hero searches
+type searchLocation struct {
+	Region int
+	Key    string // "room-17" or "corridor-0", as used by the search ledger
+	Type   string // "room" or "corridor"
+}
+
+// checkSearchAllowed checks that a hero may search where they stand. No search is allowed
+// while a monster is visible in the hero's room or corridor, and treasure can only be
+// searched for in rooms. Returns nil when the hero is not on the region map.
+func (has *HeroActionSystem) checkSearchAllowed(heroID, searchType string) (*searchLocation, error) {
+	has.gameState.Lock.Lock()
+	defer has.gameState.Lock.Unlock()
+
+	heroPos, exists := has.gameState.Entities[heroID]
+	if !exists {
+		return nil, fmt.Errorf("hero %s not found", heroID)
+	}
+	region, ok := regionAt(has.gameState, heroPos)
+	if !ok {
+		return nil, nil
+	}
+
+	location := &searchLocation{Region: region, Key: fmt.Sprintf("room-%d", region), Type: "room"}
+	if region == has.gameState.CorridorRegion {
+		if searchType == "treasure" {
+			return nil, &GameError{Code: "corridor_search", Message: "treasure can only be searched for in rooms"}
+		}
+		location.Key, location.Type = fmt.Sprintf("corridor-%d", region), "corridor"
+	}
+
+	if has.monsterSystem != nil {
+		for _, monster := range has.monsterSystem.GetVisibleMonsters() {
+			if monsterRegion, ok := regionAt(has.gameState, monster.Position); ok && monsterRegion == region {
+				return nil, &GameError{Code: "monsters_in_sight", Message: fmt.Sprintf("cannot search while %s is in sight", monster.DisplayName())}
+			}
+		}
+	}
+
+	return location, nil
+}
+
+// recordSearch adds a trap or secret door search to the hero's quest search ledger
+func (has *HeroActionSystem) recordSearch(heroID, searchType string, location *searchLocation, success bool) {
+	if has.turnStateManager == nil || location == nil {
+		return
+	}
+
+	has.gameState.Lock.Lock()
+	heroPos := has.gameState.Entities[heroID]
+	has.gameState.Lock.Unlock()
+
+	if err := has.turnStateManager.RecordSearch(heroID, searchType, location.Key, location.Type, heroPos, success, nil); err != nil {
+		has.logger.Printf("Warning: Failed to record %s search for %s: %v", searchType, heroID, err)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// createTestSearcher gives hero-1, standing in room 2 of the secret door board, a treasure deck of gold
+func createTestSearcher(t *testing.T) *HeroActionSystem {
+	has := createTestItemUser(t, nil, nil)
+	setupSecretDoorRooms(has)
+
+	deck := NewTreasureDeckManager(NewContentManager(&MockLogger{}), &MockLogger{})
+	for range 3 {
+		deck.deck = append(deck.deck, &TreasureCard{ID: "gold_25", Name: "Gold", Type: "gold", Value: 25})
+	}
+	has.SetTreasureResolver(NewTreasureResolver(NewContentManager(&MockLogger{}), deck, nil, &MockLogger{}))
+	has.turnManager.RestoreActions()
+	return has
+}
+
+func searchRequest(action HeroAction) ActionRequest {
+	return ActionRequest{PlayerID: "player-1", EntityID: "hero-1", Action: action, Parameters: map[string]any{}}
+}
+
+func TestSearch_BlockedByVisibleMonsterInRoom(t *testing.T) {
+	has := createTestSearcher(t)
+	goblin, _ := has.monsterSystem.GetMonsterByID("monster-1")
+	goblin.IsVisible = true
+
+	for _, action := range []HeroAction{SearchTreasureAction, SearchTrapsAction, SearchSecretAction} {
+		_, err := has.ProcessAction(searchRequest(action))
+		expectGameErrorCode(t, err, "monsters_in_sight")
+	}
+	if has.turnManager.GetTurnState().ActionTaken {
+		t.Error("Expected a refused search not to consume the action")
+	}
+
+	// A monster seen in the next room does not stop the search
+	goblin.Position = protocol.TileAddress{X: 2, Y: 6}
+	if _, err := has.ProcessAction(searchRequest(SearchTrapsAction)); err != nil {
+		t.Errorf("Expected the search to go ahead with the goblin in room 1, got: %v", err)
+	}
+}
+
+func TestSearch_TreasureOncePerHeroPerRoomPerQuest(t *testing.T) {
+	has := createTestSearcher(t)
+	if _, err := has.ProcessAction(searchRequest(SearchTreasureAction)); err != nil {
+		t.Fatalf("Expected the first treasure search to succeed, got: %v", err)
+	}
+
+	// A new turn clears the turn states but not the quest's ledger
+	has.turnStateManager.AdvanceTurn()
+	has.turnManager.RestoreActions()
+	_, err := has.ProcessAction(searchRequest(SearchTreasureAction))
+	expectGameErrorCode(t, err, "already_searched")
+
+	if _, err := has.ProcessAction(searchRequest(SearchSecretAction)); err != nil {
+		t.Errorf("Expected secret doors to be searchable after treasure, got: %v", err)
+	}
+	ledger := has.turnStateManager.GetSearchLedger()
+	if searches := ledger["hero-1"]["room-2"]; !slices.Equal(searches, []string{"treasure", "secret_door"}) {
+		t.Errorf("Expected room-2 searched for treasure and secret doors, got %v", ledger)
+	}
+	has.turnStateManager.StartHeroTurn("hero-2", "player-2", protocol.TileAddress{X: 6, Y: 5})
+	if canSearch, _ := has.turnStateManager.CanSearchTreasure("hero-2", "room-2"); !canSearch {
+		t.Error("Expected another hero to still search room-2 for treasure")
+	}
+}
+
+func TestSearch_NoTreasureInCorridors(t *testing.T) {
+	has := createTestSearcher(t)
+	has.gameState.RegionMap.TileRegionIDs[5*10+5] = has.gameState.CorridorRegion
+
+	_, err := has.ProcessAction(searchRequest(SearchTreasureAction))
+	expectGameErrorCode(t, err, "corridor_search")
+
+	if _, err := has.ProcessAction(searchRequest(SearchTrapsAction)); err != nil {
+		t.Fatalf("Expected corridors to be searchable for traps, got: %v", err)
+	}
+	if _, searched := has.turnStateManager.GetSearchLedger()["hero-1"]["corridor-0"]; !searched {
+		t.Error("Expected the trap search to be recorded against the corridor")
+	}
+}
+
+func TestSearch_LedgerRecordedWithoutTurnState(t *testing.T) {
+	tsm := NewTurnStateManager(&MockLogger{})
+
+	err := tsm.RecordSearch("hero-1", "treasure", "room-2", "room", protocol.TileAddress{X: 5, Y: 5}, true, nil)
+	expectGameErrorCode(t, err, "no_active_turn")
+	if searches := tsm.GetSearchLedger()["hero-1"]["room-2"]; !slices.Equal(searches, []string{"treasure"}) {
+		t.Errorf("Expected the search kept in the quest's ledger, got %v", tsm.GetSearchLedger())
+	}
+}
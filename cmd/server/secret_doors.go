@@ -0,0 +1,55 @@
+package main
+
+import (
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// findHiddenSecretDoors returns the quest's undiscovered secret doors on the edges of the
+// hero's region. In the corridor only doors the hero can see are found.
+// Callers must hold the game state lock.
+func findHiddenSecretDoors(state *GameState, quest *geometry.QuestDefinition, hero protocol.TileAddress) []geometry.EdgeAddress {
+	if quest == nil {
+		return nil
+	}
+
+	heroRegion, ok := regionAt(state, hero)
+	if !ok {
+		return nil
+	}
+
+	hidden := make([]geometry.EdgeAddress, 0)
+	for _, secretDoor := range quest.SecretDoors {
+		edge := geometry.ConvertQuestSecretDoorToEdge(secretDoor)
+		if _, found := state.DoorByEdge[edge]; found {
+			continue
+		}
+
+		a, b := geometry.RegionsAcrossDoor(state.RegionMap, state.Segment, edge)
+		if a != heroRegion && b != heroRegion {
+			continue
+		}
+		if heroRegion == state.CorridorRegion && !isEdgeVisible(state, hero.X, hero.Y, edge) {
+			continue
+		}
+		hidden = append(hidden, edge)
+	}
+	return hidden
+}
+
+// RevealSecretDoor turns the wall edge hiding a secret door into a closed door between
+// the regions either side of it. Callers must hold the game state lock.
+func (gs *GameState) RevealSecretDoor(edge geometry.EdgeAddress) (string, *DoorInfo) {
+	id := makeDoorID(gs.Segment.ID, edge)
+	if door, exists := gs.Doors[id]; exists {
+		return id, door
+	}
+
+	a, b := geometry.RegionsAcrossDoor(gs.RegionMap, gs.Segment, edge)
+	door := &DoorInfo{Edge: edge, RegionA: a, RegionB: b, State: "closed"}
+
+	delete(gs.BlockedWalls, edge)
+	gs.AddDoor(id, door)
+	gs.KnownDoors[id] = true
+	return id, door
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// setupSecretDoorRooms splits a 10x10 board into room 1 (x<5) and room 2 (x>=5), with a
+// secret door hidden in the wall between them next to hero-1 at (5,5); the goblin stays out of sight
+func setupSecretDoorRooms(has *HeroActionSystem) geometry.EdgeAddress {
+	secret := geometry.EdgeAddress{X: 5, Y: 5, Orientation: geometry.Vertical}
+
+	has.gameState.Segment = geometry.Segment{ID: "seg", Width: 10, Height: 10}
+	has.gameState.RegionMap = geometry.RegionMap{TileRegionIDs: make([]int, 100), RegionsCount: 3}
+	for i := range has.gameState.RegionMap.TileRegionIDs {
+		has.gameState.RegionMap.TileRegionIDs[i] = 1
+		if i%10 >= 5 {
+			has.gameState.RegionMap.TileRegionIDs[i] = 2
+		}
+	}
+	has.gameState.DoorByEdge = make(map[geometry.EdgeAddress]string)
+	has.gameState.BlockedWalls = map[geometry.EdgeAddress]bool{secret: true}
+
+	// Searching needs the hero's room clear of visible monsters
+	goblin, _ := has.monsterSystem.GetMonsterByID("monster-1")
+	goblin.IsVisible = false
+
+	has.SetQuest(&geometry.QuestDefinition{
+		SecretDoors: []geometry.QuestSecretDoor{{ID: "secret-1", X: 5, Y: 5, Orientation: "vertical"}},
+	})
+	return secret
+}
+
+func TestSecretDoors_SearchOpensWallIntoDoor(t *testing.T) {
+	has := createTestHeroActionSystem()
+	secret := setupSecretDoorRooms(has)
+	has.turnManager.RestoreActions()
+	has.debugSystem.SetDiceOverride("search_secret", 6)
+
+	result, err := has.ProcessAction(ActionRequest{PlayerID: "player-1", EntityID: "hero-1", Action: SearchSecretAction, Parameters: map[string]any{}})
+	if err != nil || result.SecretRevealed == nil {
+		t.Fatalf("Expected a secret door to be found, got %+v (%v)", result, err)
+	}
+
+	if has.gameState.BlockedWalls[secret] {
+		t.Error("Expected the wall edge to be removed")
+	}
+	id, ok := has.gameState.DoorByEdge[secret]
+	if !ok {
+		t.Fatal("Expected a door on the secret door's edge")
+	}
+	door := has.gameState.Doors[id]
+	if door.RegionA != 1 || door.RegionB != 2 || door.State != "closed" {
+		t.Errorf("Expected a closed door between rooms 1 and 2, got %+v", door)
+	}
+
+	// Searching again finds nothing new
+	has.turnManager.RestoreActions()
+	result, _ = has.ProcessAction(ActionRequest{PlayerID: "player-1", EntityID: "hero-1", Action: SearchSecretAction, Parameters: map[string]any{}})
+	if result.SecretRevealed != nil {
+		t.Error("Expected an already-found secret door not to be found twice")
+	}
+}
+
+func TestSecretDoors_OtherRoomsStayHidden(t *testing.T) {
+	has := createTestHeroActionSystem()
+	setupSecretDoorRooms(has)
+	has.gameState.Entities["hero-1"] = protocol.TileAddress{X: 8, Y: 1}
+	has.SetQuest(&geometry.QuestDefinition{
+		SecretDoors: []geometry.QuestSecretDoor{{ID: "secret-2", X: 2, Y: 5, Orientation: "vertical"}},
+	})
+	has.turnManager.RestoreActions()
+	has.debugSystem.SetDiceOverride("search_secret", 6)
+
+	result, err := has.ProcessAction(ActionRequest{PlayerID: "player-1", EntityID: "hero-1", Action: SearchSecretAction, Parameters: map[string]any{}})
+	if err != nil {
+		t.Fatalf("Expected search to succeed, got: %v", err)
+	}
+	if result.SecretRevealed != nil || len(has.gameState.Doors) != 0 {
+		t.Error("Expected a secret door inside another room to stay hidden")
+	}
+}
+
+func TestSecretDoors_ReplayRebuildsFoundDoor(t *testing.T) {
+	has := createTestHeroActionSystem()
+	secret := setupSecretDoorRooms(has)
+	gm := &GameManager{
+		gameState:        has.gameState,
+		monsterSystem:    has.monsterSystem,
+		dynamicTurnOrder: NewDynamicTurnOrderManager(&MockLogger{}),
+		logger:           &MockLogger{},
+	}
+
+	store, _ := NewFileEventStore(t.TempDir())
+	recorder := NewEventRecorder(store, "game-1", nil)
+	recorder.Record("DoorsVisible", protocol.DoorsVisible{Doors: []protocol.ThresholdLite{
+		{ID: makeDoorID("seg", secret), X: 5, Y: 5, Orientation: "vertical", Kind: "DoorSocket", State: "closed"},
+	}})
+
+	events, _ := store.LoadEvents("game-1", 0)
+	if err := ReplayEvents(gm, events); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	if _, ok := has.gameState.DoorByEdge[secret]; !ok || has.gameState.BlockedWalls[secret] {
+		t.Error("Expected replay to turn the secret door's wall back into a door")
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// BeginSpellSelection sets up the spell school draft for the quest's casters:
+// the Wizard picks a school, then the Elf picks one, then the Wizard takes the rest
+func (gm *GameManager) BeginSpellSelection() error {
+	var wizard, elf *Player
+	for _, player := range gm.turnManager.GetHeroPlayers() {
+		switch {
+		case strings.EqualFold(string(player.Class), string(Wizard)):
+			wizard = player
+		case strings.EqualFold(string(player.Class), string(Elf)):
+			elf = player
+		}
+	}
+
+	var picks []SpellDraftPick
+	switch {
+	case wizard != nil && elf != nil:
+		picks = []SpellDraftPick{{PlayerID: wizard.ID}, {PlayerID: elf.ID}, {PlayerID: wizard.ID, TakeRest: true}}
+	case wizard != nil:
+		picks = []SpellDraftPick{{PlayerID: wizard.ID, TakeRest: true}}
+	case elf != nil:
+		picks = []SpellDraftPick{{PlayerID: elf.ID}}
+	}
+
+	if err := gm.dynamicTurnOrder.StartSpellSelection(gm.contentManager.GetSpellElements(), picks); err != nil {
+		return err
+	}
+
+	if len(picks) > 0 && gm.dynamicTurnOrder.IsSpellSelectionComplete() {
+		return gm.loadChosenSpells()
+	}
+	return nil
+}
+
+// GetSpellSelectionForSnapshot returns the spell school draft for client snapshot, or nil
+// when no hero casts spells this quest
+func (gm *GameManager) GetSpellSelectionForSnapshot() *protocol.SpellSelectionStateChanged {
+	if gm.dynamicTurnOrder.IsSpellSelectionComplete() && len(gm.dynamicTurnOrder.GetSpellSchools()) == 0 {
+		return nil
+	}
+	state := spellSelectionState(gm.dynamicTurnOrder)
+	return &state
+}
+
+// ChooseSpellSchool records a caster's spell school pick and, once the draft is
+// complete, loads the chosen spells into each caster's inventory
+func (gm *GameManager) ChooseSpellSchool(playerID string, element string) error {
+	if err := gm.dynamicTurnOrder.ChooseSpellSchool(playerID, element); err != nil {
+		return err
+	}
+
+	if gm.dynamicTurnOrder.IsSpellSelectionComplete() {
+		return gm.loadChosenSpells()
+	}
+	return nil
+}
+
+// loadChosenSpells adds every spell of each chosen school to its caster's hand
+func (gm *GameManager) loadChosenSpells() error {
+	for element, playerID := range gm.dynamicTurnOrder.GetSpellSchools() {
+		player := gm.turnManager.GetPlayer(playerID)
+		if player == nil {
+			return fmt.Errorf("spell caster %s not found", playerID)
+		}
+
+		for _, spellID := range gm.contentManager.GetSpellsByElement(element) {
+			if err := gm.inventoryManager.AddSpell(player.EntityID, spellID); err != nil {
+				return fmt.Errorf("failed to give %s spell %s to %s: %w", element, spellID, player.EntityID, err)
+			}
+		}
+		gm.logger.Printf("Loaded %s spells for %s (%s)", element, player.Name, player.EntityID)
+	}
+	return nil
+}
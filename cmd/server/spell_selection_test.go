@@ -0,0 +1,63 @@
+package main
+
+import "testing"
+
+func TestGameManager_SpellSelectionLoadsCasterSpells(t *testing.T) {
+	gm := newSnapshotTestGameManager()
+
+	for _, spell := range []struct{ id, element string }{
+		{"swift_wind", "air"}, {"genie", "air"},
+		{"fire_of_wrath", "fire"},
+		{"water_of_healing", "water"},
+	} {
+		gm.contentManager.spellCards[spell.id] = &SpellCard{ID: spell.id, Name: spell.id}
+		if _, seen := gm.contentManager.spellsByElement[spell.element]; !seen {
+			gm.contentManager.spellElements = append(gm.contentManager.spellElements, spell.element)
+		}
+		gm.contentManager.spellsByElement[spell.element] = append(gm.contentManager.spellsByElement[spell.element], spell.id)
+	}
+
+	gm.turnManager.AddPlayer(NewPlayer("player-1", "Zargon's Bane", "hero-1", Wizard))
+	gm.turnManager.AddPlayer(NewPlayer("player-2", "Ladril", "hero-2", Elf))
+	gm.inventoryManager.InitializeHeroInventory("hero-1")
+	gm.inventoryManager.InitializeHeroInventory("hero-2")
+
+	if err := gm.BeginSpellSelection(); err != nil {
+		t.Fatalf("BeginSpellSelection failed: %v", err)
+	}
+	if next := gm.dynamicTurnOrder.GetNextSpellPicker(); next != "player-1" {
+		t.Fatalf("Expected the Wizard to pick first, got %q", next)
+	}
+	if draft := gm.GetSpellSelectionForSnapshot(); draft == nil || draft.NextPlayerID != "player-1" || len(draft.Elements) != 3 || draft.Complete {
+		t.Errorf("Expected the snapshot to show the Wizard picking from 3 schools, got %+v", draft)
+	}
+
+	if err := gm.ChooseSpellSchool("player-1", "air"); err != nil {
+		t.Fatalf("Wizard pick failed: %v", err)
+	}
+	if err := gm.ChooseSpellSchool("player-2", "water"); err != nil {
+		t.Fatalf("Elf pick failed: %v", err)
+	}
+
+	wizard, _ := gm.inventoryManager.GetInventory("hero-1")
+	elf, _ := gm.inventoryManager.GetInventory("hero-2")
+	if len(wizard.Spells) != 3 {
+		t.Errorf("Expected the Wizard to hold air and fire spells (3), got %d", len(wizard.Spells))
+	}
+	if len(elf.Spells) != 1 || elf.Spells[0].ID != "water_of_healing" {
+		t.Errorf("Expected the Elf to hold water_of_healing, got %+v", elf.Spells)
+	}
+}
+
+func TestGameManager_NoSpellSelectionWithoutCasters(t *testing.T) {
+	gm := newSnapshotTestGameManager()
+	gm.contentManager.spellElements = []string{"air", "fire"}
+	gm.turnManager.AddPlayer(NewPlayer("player-1", "Grugni", "hero-1", Dwarf))
+
+	if err := gm.BeginSpellSelection(); err != nil {
+		t.Fatalf("BeginSpellSelection failed: %v", err)
+	}
+	if draft := gm.GetSpellSelectionForSnapshot(); draft != nil {
+		t.Errorf("Expected no spell draft in the snapshot without casters, got %+v", draft)
+	}
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// TradeOffer is a proposed exchange waiting for the recipient's consent
+type TradeOffer struct {
+	ID         string     `json:"id"`
+	FromHeroID string     `json:"fromHeroId"`
+	ToHeroID   string     `json:"toHeroId"`
+	Give       TradeGoods `json:"give"`    // Handed over by the proposer
+	Request    TradeGoods `json:"request"` // Asked of the recipient in return
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// TradeSystem manages trades between adjacent heroes. A hero proposes a trade on their turn;
+// it only happens once the recipient accepts, and the heroes must still be adjacent then.
+type TradeSystem struct {
+	offers           map[string]*TradeOffer
+	nextOfferID      int
+	gameState        *GameState
+	turnManager      *TurnManager
+	turnStateManager *TurnStateManager
+	inventoryManager *InventoryManager
+	broadcaster      Broadcaster
+	logger           Logger
+	mutex            sync.Mutex
+}
+
+// NewTradeSystem creates a new trade system
+func NewTradeSystem(gameState *GameState, turnManager *TurnManager, inventoryManager *InventoryManager, broadcaster Broadcaster, logger Logger) *TradeSystem {
+	return &TradeSystem{
+		offers:           make(map[string]*TradeOffer),
+		gameState:        gameState,
+		turnManager:      turnManager,
+		inventoryManager: inventoryManager,
+		broadcaster:      broadcaster,
+		logger:           logger,
+	}
+}
+
+// SetTurnStateManager sets the turn state manager used to record trades
+func (ts *TradeSystem) SetTurnStateManager(turnStateManager *TurnStateManager) {
+	ts.turnStateManager = turnStateManager
+}
+
+// ProposeTrade offers goods to an adjacent hero, optionally asking for goods in return
+func (ts *TradeSystem) ProposeTrade(fromHeroID, toHeroID string, give, request TradeGoods) (*TradeOffer, error) {
+	if fromHeroID == toHeroID {
+		return nil, &GameError{Code: "invalid_trade", Message: "a hero cannot trade with themselves"}
+	}
+	if give.IsEmpty() && request.IsEmpty() {
+		return nil, &GameError{Code: "invalid_trade", Message: "a trade must exchange something"}
+	}
+	if ts.findHero(toHeroID) == nil {
+		return nil, &GameError{Code: "invalid_trade", Message: fmt.Sprintf("hero %s not found", toHeroID)}
+	}
+	if err := ts.checkAdjacent(fromHeroID, toHeroID); err != nil {
+		return nil, err
+	}
+
+	ts.mutex.Lock()
+	ts.nextOfferID++
+	offer := &TradeOffer{
+		ID:         fmt.Sprintf("trade-%d", ts.nextOfferID),
+		FromHeroID: fromHeroID,
+		ToHeroID:   toHeroID,
+		Give:       give,
+		Request:    request,
+		CreatedAt:  time.Now(),
+	}
+	ts.offers[offer.ID] = offer
+	ts.mutex.Unlock()
+
+	ts.broadcaster.BroadcastEvent("TradeProposed", protocol.TradeProposed{
+		TradeID:      offer.ID,
+		FromHeroID:   fromHeroID,
+		ToHeroID:     toHeroID,
+		GiveItems:    give.Items,
+		GiveGold:     give.Gold,
+		RequestItems: request.Items,
+		RequestGold:  request.Gold,
+	})
+
+	ts.logger.Printf("Hero %s proposed %s to hero %s", fromHeroID, offer.ID, toHeroID)
+	return offer, nil
+}
+
+// AcceptTrade completes a pending offer on behalf of its recipient, then equips any of the
+// received items the recipient asked to equip and the equipment rules allow
+func (ts *TradeSystem) AcceptTrade(playerID, offerID string, equip []string) (*TradeOffer, error) {
+	player := ts.turnManager.GetPlayer(playerID)
+	if player == nil {
+		return nil, fmt.Errorf("player %s not found", playerID)
+	}
+
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	offer, exists := ts.offers[offerID]
+	if !exists || offer.ToHeroID != player.EntityID {
+		return nil, &GameError{Code: "trade_not_found", Message: fmt.Sprintf("no trade %s offered to %s", offerID, player.EntityID)}
+	}
+
+	// The offer stays open if the heroes moved apart or no longer hold the goods
+	if err := ts.checkAdjacent(offer.FromHeroID, offer.ToHeroID); err != nil {
+		return nil, err
+	}
+	if err := ts.inventoryManager.Trade(offer.FromHeroID, offer.Give, offer.ToHeroID, offer.Request); err != nil {
+		return nil, err
+	}
+	delete(ts.offers, offerID)
+
+	for _, itemID := range equip {
+		if err := ts.inventoryManager.EquipItem(player.EntityID, itemID); err != nil {
+			ts.logger.Printf("Hero %s keeps %s carried: %v", player.EntityID, itemID, err)
+		}
+	}
+
+	ts.recordTrade(offer)
+
+	ts.broadcaster.BroadcastEvent("TradeCompleted", protocol.TradeCompleted{
+		TradeID:    offer.ID,
+		FromHeroID: offer.FromHeroID,
+		ToHeroID:   offer.ToHeroID,
+	})
+	ts.broadcastInventory(offer.FromHeroID)
+	ts.broadcastInventory(offer.ToHeroID)
+
+	ts.logger.Printf("Hero %s accepted %s from hero %s", offer.ToHeroID, offer.ID, offer.FromHeroID)
+	return offer, nil
+}
+
+// DeclineTrade withdraws or declines a pending offer; either hero may do so
+func (ts *TradeSystem) DeclineTrade(playerID, offerID string) error {
+	player := ts.turnManager.GetPlayer(playerID)
+	if player == nil {
+		return fmt.Errorf("player %s not found", playerID)
+	}
+
+	ts.mutex.Lock()
+	offer, exists := ts.offers[offerID]
+	if !exists || (offer.FromHeroID != player.EntityID && offer.ToHeroID != player.EntityID) {
+		ts.mutex.Unlock()
+		return &GameError{Code: "trade_not_found", Message: fmt.Sprintf("no trade %s involving %s", offerID, player.EntityID)}
+	}
+	delete(ts.offers, offerID)
+	ts.mutex.Unlock()
+
+	ts.broadcaster.BroadcastEvent("TradeDeclined", protocol.TradeDeclined{TradeID: offerID, HeroID: player.EntityID})
+	ts.logger.Printf("Hero %s declined %s", player.EntityID, offerID)
+	return nil
+}
+
+// GetPendingOffers returns the offers waiting on a hero, oldest first
+func (ts *TradeSystem) GetPendingOffers(heroID string) []*TradeOffer {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	offers := make([]*TradeOffer, 0)
+	for _, offer := range ts.offers {
+		if offer.ToHeroID == heroID {
+			offers = append(offers, offer)
+		}
+	}
+	sort.Slice(offers, func(i, j int) bool { return offers[i].CreatedAt.Before(offers[j].CreatedAt) })
+	return offers
+}
+
+// recordTrade records the trade in both heroes' turn states where they have one
+func (ts *TradeSystem) recordTrade(offer *TradeOffer) {
+	if ts.turnStateManager == nil {
+		return
+	}
+
+	details := map[string]interface{}{
+		"tradeId":      offer.ID,
+		"giveItems":    offer.Give.Items,
+		"giveGold":     offer.Give.Gold,
+		"requestItems": offer.Request.Items,
+		"requestGold":  offer.Request.Gold,
+	}
+	for _, heroID := range []string{offer.FromHeroID, offer.ToHeroID} {
+		target := offer.ToHeroID
+		if heroID == offer.ToHeroID {
+			target = offer.FromHeroID
+		}
+		activity := Activity{Type: "pass_item", Target: target, Context: "trade", Details: details}
+		if err := ts.turnStateManager.RecordActivity(heroID, activity); err != nil {
+			ts.logger.Printf("Trade %s not recorded for hero %s: %v", offer.ID, heroID, err)
+		}
+	}
+}
+
+// broadcastInventory sends a hero's inventory after it changed
+func (ts *TradeSystem) broadcastInventory(heroID string) {
+	inventory, err := ts.inventoryManager.GetInventory(heroID)
+	if err != nil {
+		ts.logger.Printf("Cannot broadcast inventory for %s: %v", heroID, err)
+		return
+	}
+	ts.broadcaster.BroadcastEvent("InventoryChanged", inventoryToLite(inventory))
+}
+
+// checkAdjacent requires two heroes to stand next to each other, diagonals included, with no
+// wall or closed door between them
+func (ts *TradeSystem) checkAdjacent(heroA, heroB string) error {
+	ts.gameState.Lock.Lock()
+	posA, okA := ts.gameState.Entities[heroA]
+	posB, okB := ts.gameState.Entities[heroB]
+	reachable := okA && okB && areAdjacent(posA, posB) && adjacentOpen(ts.gameState, posA, posB)
+	ts.gameState.Lock.Unlock()
+
+	if !okA || !okB {
+		return fmt.Errorf("player entity not found")
+	}
+	if !reachable {
+		return &GameError{Code: "not_adjacent", Message: "heroes must be adjacent to trade"}
+	}
+	return nil
+}
+
+// findHero returns the player controlling a hero entity
+func (ts *TradeSystem) findHero(heroID string) *Player {
+	for _, player := range ts.turnManager.GetHeroPlayers() {
+		if player.EntityID == heroID {
+			return player
+		}
+	}
+	return nil
+}
+
+// areAdjacent reports whether two different tiles touch, including diagonally
+func areAdjacent(a, b protocol.TileAddress) bool {
+	dx := absInt(a.X - b.X)
+	dy := absInt(a.Y - b.Y)
+	return dx <= 1 && dy <= 1 && (dx > 0 || dy > 0)
+}
+
+// inventoryToLite converts a hero inventory for the InventoryChanged patch
+func inventoryToLite(inventory *HeroInventory) protocol.InventoryChanged {
+	lite := protocol.InventoryChanged{
+		HeroID:    inventory.HeroID,
+		Gold:      inventory.Gold,
+		Equipment: make(map[string]protocol.InventoryItemLite, len(inventory.Equipment)),
+		Carried:   make([]protocol.InventoryItemLite, 0, len(inventory.Carried)),
+	}
+	for slot, item := range inventory.Equipment {
+		if item != nil {
+			lite.Equipment[slot] = protocol.InventoryItemLite{ID: item.ID, Name: item.Name}
+		}
+	}
+	for _, item := range inventory.Carried {
+		lite.Carried = append(lite.Carried, protocol.InventoryItemLite{ID: item.ID, Name: item.Name})
+	}
+	return lite
+}
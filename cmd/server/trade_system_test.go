@@ -0,0 +1,139 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// createTestTraders stands hero-2 next to hero-1, both carrying the given cards and 10 gold
+func createTestTraders(t *testing.T, items []*ItemCard, treasures []*TreasureCard) *HeroActionSystem {
+	has := createTestItemUser(t, items, treasures)
+	has.gameState.Entities["hero-2"] = protocol.TileAddress{X: 6, Y: 5}
+
+	tradeSystem := NewTradeSystem(has.gameState, has.turnManager, has.inventoryManager, &MockBroadcaster{}, &MockLogger{})
+	tradeSystem.SetTurnStateManager(has.turnStateManager)
+	has.SetTradeSystem(tradeSystem)
+
+	for _, heroID := range []string{"hero-1", "hero-2"} {
+		has.inventoryManager.AddGold(heroID, 10)
+	}
+	return has
+}
+
+func tradeRequest(params map[string]any) InstantActionRequest {
+	params["targetEntityId"] = "hero-2"
+	return InstantActionRequest{PlayerID: "player-1", EntityID: "hero-1", Action: TradeItemInstant, Parameters: params}
+}
+
+func TestTrade_PotionPassedToDyingHero(t *testing.T) {
+	has := createTestTraders(t, nil, []*TreasureCard{healingPotion()})
+	dying := has.turnManager.GetPlayer("player-2")
+	dying.Character.TakeDamage(dying.Character.CurrentBody)
+
+	result, err := has.ProcessInstantAction(tradeRequest(map[string]any{"itemId": "potion_of_healing", "gold": float64(4)}))
+	if err != nil {
+		t.Fatalf("Expected trade to be proposed, got: %v", err)
+	}
+	if !has.inventoryManager.HasItem("hero-1", "potion_of_healing") {
+		t.Fatal("Expected nothing to change hands before the offer is accepted")
+	}
+
+	if _, err := has.tradeSystem.AcceptTrade("player-2", result.TradeOffer.ID, nil); err != nil {
+		t.Fatalf("Expected the dying hero to accept, got: %v", err)
+	}
+
+	giver, _ := has.inventoryManager.GetInventory("hero-1")
+	receiver, _ := has.inventoryManager.GetInventory("hero-2")
+	if len(giver.Carried) != 0 || len(receiver.Carried) != 2 {
+		t.Errorf("Expected the potion to move to hero-2, got %d / %d carried", len(giver.Carried), len(receiver.Carried))
+	}
+	if giver.Gold != 6 || receiver.Gold != 14 {
+		t.Errorf("Expected 4 gold to move, got %d / %d", giver.Gold, receiver.Gold)
+	}
+
+	activities := has.turnStateManager.GetHeroTurnState("hero-1").Activities
+	if len(activities) != 1 || activities[0].Type != "pass_item" || activities[0].Target != "hero-2" {
+		t.Errorf("Expected the trade recorded on hero-1's turn, got %+v", activities)
+	}
+
+	if _, err := has.ProcessInstantAction(useItemRequest("player-2", "hero-2", UsePotionInstant, "potion_of_healing")); err != nil {
+		t.Errorf("Expected the dying hero to drink the passed potion, got: %v", err)
+	}
+}
+
+func TestTrade_IsAtomic(t *testing.T) {
+	has := createTestTraders(t, nil, []*TreasureCard{healingPotion()})
+
+	// hero-2 is asked for more gold than they have
+	result, err := has.ProcessInstantAction(tradeRequest(map[string]any{"itemId": "potion_of_healing", "requestGold": float64(50)}))
+	if err != nil {
+		t.Fatalf("Expected trade to be proposed, got: %v", err)
+	}
+	if _, err := has.tradeSystem.AcceptTrade("player-2", result.TradeOffer.ID, nil); err == nil {
+		t.Fatal("Expected accepting an unaffordable trade to fail")
+	}
+
+	giver, _ := has.inventoryManager.GetInventory("hero-1")
+	receiver, _ := has.inventoryManager.GetInventory("hero-2")
+	if len(giver.Carried) != 1 || len(receiver.Carried) != 1 || giver.Gold != 10 || receiver.Gold != 10 {
+		t.Errorf("Expected nothing to change hands, got %d/%d carried and %d/%d gold",
+			len(giver.Carried), len(receiver.Carried), giver.Gold, receiver.Gold)
+	}
+	if len(has.tradeSystem.GetPendingOffers("hero-2")) != 1 {
+		t.Error("Expected the failed offer to stay open")
+	}
+}
+
+func TestTrade_RequiresAdjacencyAndRecipientConsent(t *testing.T) {
+	has := createTestTraders(t, nil, []*TreasureCard{healingPotion()})
+
+	result, err := has.ProcessInstantAction(tradeRequest(map[string]any{"itemId": "potion_of_healing"}))
+	if err != nil {
+		t.Fatalf("Expected trade to be proposed, got: %v", err)
+	}
+	if _, err := has.tradeSystem.AcceptTrade("player-1", result.TradeOffer.ID, nil); err == nil {
+		t.Error("Expected the proposer not to accept their own offer")
+	}
+
+	has.gameState.Entities["hero-2"] = protocol.TileAddress{X: 8, Y: 5}
+	if _, err := has.tradeSystem.AcceptTrade("player-2", result.TradeOffer.ID, nil); err == nil {
+		t.Error("Expected accepting after moving apart to fail")
+	}
+	if _, err := has.ProcessInstantAction(tradeRequest(map[string]any{"itemId": "potion_of_healing"})); err == nil {
+		t.Error("Expected proposing to a distant hero to fail")
+	}
+
+	// Next to each other, but with a wall between them
+	has.gameState.Entities["hero-2"] = protocol.TileAddress{X: 6, Y: 5}
+	has.gameState.BlockedWalls = map[geometry.EdgeAddress]bool{
+		{X: 6, Y: 5, Orientation: geometry.Vertical}: true,
+	}
+	_, err = has.ProcessInstantAction(tradeRequest(map[string]any{"itemId": "potion_of_healing"}))
+	expectGameErrorCode(t, err, "not_adjacent")
+}
+
+func TestTrade_EquipRespectsUsableBy(t *testing.T) {
+	has := createTestTraders(t, []*ItemCard{
+		{ID: "battle_axe", Name: "Battle Axe", Type: "weapon", UsableBy: []string{"barbarian", "dwarf"}},
+		{ID: "shortsword", Name: "Shortsword", Type: "weapon"},
+	}, nil)
+
+	result, err := has.ProcessInstantAction(tradeRequest(map[string]any{"itemIds": []any{"battle_axe", "shortsword"}}))
+	if err != nil {
+		t.Fatalf("Expected trade to be proposed, got: %v", err)
+	}
+	if _, err := has.tradeSystem.AcceptTrade("player-2", result.TradeOffer.ID, []string{"battle_axe", "shortsword"}); err != nil {
+		t.Fatalf("Expected trade to be accepted, got: %v", err)
+	}
+
+	// hero-2 is an elf: the axe stays carried, the shortsword is equipped
+	elf, _ := has.inventoryManager.GetInventory("hero-2")
+	if weapon := elf.Equipment["weapon"]; weapon == nil || weapon.ID != "shortsword" {
+		t.Errorf("Expected the shortsword equipped, got %+v", weapon)
+	}
+	if !has.inventoryManager.HasItem("hero-2", "battle_axe") {
+		t.Error("Expected the elf to carry the battle axe")
+	}
+}
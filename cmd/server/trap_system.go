@@ -0,0 +1,437 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// TrapType defines the kinds of trap a quest can place
+type TrapType string
+
+const (
+	PitTrap          TrapType = "pit"
+	SpearTrap        TrapType = "spear"
+	FallingBlockTrap TrapType = "falling_block"
+	ChestTrap        TrapType = "chest"
+)
+
+// ToolKitItemID is the equipment card heroes need to disarm traps
+const ToolKitItemID = "tool_kit"
+
+// Trap is a quest trap and what each hero knows about it
+type Trap struct {
+	ID          string               `json:"id"`
+	Type        TrapType             `json:"type"`
+	Position    protocol.TileAddress `json:"position"`
+	Room        int                  `json:"room"`
+	FurnitureID string               `json:"furnitureId,omitempty"`
+	Damage      int                  `json:"damage"`
+	Revealed    bool                 `json:"revealed,omitempty"`   // Known to every hero
+	RevealedTo  map[string]bool      `json:"revealedTo,omitempty"` // Hero entity IDs that found it by searching
+	Sprung      bool                 `json:"sprung,omitempty"`
+	Disarmed    bool                 `json:"disarmed,omitempty"`
+}
+
+// IsActive reports whether the trap can still be sprung
+func (t *Trap) IsActive() bool {
+	return !t.Sprung && !t.Disarmed
+}
+
+// IsKnownTo reports whether a hero knows where the trap is
+func (t *Trap) IsKnownTo(heroID string) bool {
+	return t.Revealed || t.RevealedTo[heroID]
+}
+
+// TrapResult describes what happened when a trap was sprung
+type TrapResult struct {
+	TrapID       string                `json:"trapId"`
+	Type         TrapType              `json:"type"`
+	EntityID     string                `json:"entityId"`
+	Damage       int                   `json:"damage"`
+	DiceRolls    []DiceRoll            `json:"diceRolls,omitempty"`
+	EndsMovement bool                  `json:"endsMovement"`
+	PushedBackTo *protocol.TileAddress `json:"pushedBackTo,omitempty"`
+}
+
+// TrapSystem tracks quest traps: who has found them, springing and disarming
+type TrapSystem struct {
+	traps         map[string]*Trap
+	gameState     *GameState
+	turnManager   *TurnManager
+	diceSystem    *DiceSystem
+	heroLifecycle *HeroLifecycleSystem
+	broadcaster   Broadcaster
+	logger        Logger
+	mutex         sync.RWMutex
+}
+
+// NewTrapSystem creates a new trap system
+func NewTrapSystem(gameState *GameState, turnManager *TurnManager, diceSystem *DiceSystem, broadcaster Broadcaster, logger Logger) *TrapSystem {
+	return &TrapSystem{
+		traps:       make(map[string]*Trap),
+		gameState:   gameState,
+		turnManager: turnManager,
+		diceSystem:  diceSystem,
+		broadcaster: broadcaster,
+		logger:      logger,
+	}
+}
+
+// SetHeroLifecycle sets the hero lifecycle system told when a trap brings a hero down
+func (ts *TrapSystem) SetHeroLifecycle(heroLifecycle *HeroLifecycleSystem) {
+	ts.heroLifecycle = heroLifecycle
+}
+
+// LoadQuestTraps places the quest's traps
+func (ts *TrapSystem) LoadQuestTraps(quest *geometry.QuestDefinition) {
+	if quest == nil {
+		return
+	}
+
+	for _, questTrap := range quest.Traps {
+		ts.AddTrap(&Trap{
+			ID:          questTrap.ID,
+			Type:        TrapType(questTrap.Type),
+			Position:    protocol.TileAddress{SegmentID: ts.gameState.Segment.ID, X: questTrap.X, Y: questTrap.Y},
+			Room:        questTrap.Room,
+			FurnitureID: questTrap.FurnitureID,
+			Damage:      questTrap.Damage,
+			Revealed:    questTrap.Revealed,
+		})
+	}
+
+	ts.logger.Printf("Loaded %d traps for quest %s", len(quest.Traps), quest.ID)
+}
+
+// AddTrap places a trap, filling in the default damage for its type
+func (ts *TrapSystem) AddTrap(trap *Trap) {
+	if trap.Damage <= 0 {
+		switch trap.Type {
+		case FallingBlockTrap:
+			trap.Damage = 3
+		default:
+			trap.Damage = 1
+		}
+	}
+	if trap.RevealedTo == nil {
+		trap.RevealedTo = make(map[string]bool)
+	}
+
+	ts.mutex.Lock()
+	ts.traps[trap.ID] = trap
+	ts.mutex.Unlock()
+}
+
+// GetTrap returns a trap by ID
+func (ts *TrapSystem) GetTrap(trapID string) (*Trap, bool) {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+
+	trap, exists := ts.traps[trapID]
+	return trap, exists
+}
+
+// GetKnownTraps returns the active traps a hero knows about
+func (ts *TrapSystem) GetKnownTraps(heroID string) []*Trap {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+
+	known := make([]*Trap, 0)
+	for _, trap := range ts.traps {
+		if trap.IsActive() && trap.IsKnownTo(heroID) {
+			known = append(known, trap)
+		}
+	}
+	sort.Slice(known, func(i, j int) bool { return known[i].ID < known[j].ID })
+	return known
+}
+
+// IsKnownTrapAt reports whether a hero knows of an active floor trap on a square
+func (ts *TrapSystem) IsKnownTrapAt(heroID string, x, y int) bool {
+	trap := ts.floorTrapAt(x, y)
+	return trap != nil && trap.IsKnownTo(heroID)
+}
+
+// RevealTrapsInRegion reveals a hero's region's traps to that hero. In the corridor only
+// traps the hero can see are found. Returns the newly revealed traps.
+func (ts *TrapSystem) RevealTrapsInRegion(heroID string, heroPos protocol.TileAddress, visibility VisibilityCalculator) []*Trap {
+	ts.gameState.Lock.Lock()
+	heroRegion, _ := regionAt(ts.gameState, heroPos)
+	inCorridor := heroRegion == ts.gameState.CorridorRegion
+
+	candidates := make([]*Trap, 0)
+	ts.mutex.RLock()
+	for _, trap := range ts.traps {
+		if !trap.IsActive() || trap.IsKnownTo(heroID) {
+			continue
+		}
+		trapRegion, ok := regionAt(ts.gameState, trap.Position)
+		if !ok {
+			trapRegion = trap.Room
+		}
+		if trapRegion != heroRegion {
+			continue
+		}
+		if inCorridor && visibility != nil && !visibility.IsTileCenterVisible(ts.gameState, heroPos.X, heroPos.Y, trap.Position.X, trap.Position.Y) {
+			continue
+		}
+		candidates = append(candidates, trap)
+	}
+	ts.mutex.RUnlock()
+	ts.gameState.Lock.Unlock()
+
+	if len(candidates) == 0 {
+		return candidates
+	}
+
+	ts.mutex.Lock()
+	for _, trap := range candidates {
+		trap.RevealedTo[heroID] = true
+	}
+	ts.mutex.Unlock()
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+
+	ts.broadcaster.BroadcastEvent("TrapsRevealed", protocol.TrapsRevealed{
+		EntityID: heroID,
+		Traps:    trapsToLite(candidates),
+	})
+	ts.logger.Printf("Hero %s found %d trap(s)", heroID, len(candidates))
+	return candidates
+}
+
+// SpringTrapOnEntry springs an unknown floor trap on the square a hero just stepped onto.
+// Returns nil when there is no trap there.
+func (ts *TrapSystem) SpringTrapOnEntry(heroID string, from, to protocol.TileAddress) (*TrapResult, error) {
+	trap := ts.floorTrapAt(to.X, to.Y)
+	if trap == nil {
+		return nil, nil
+	}
+	return ts.springTrap(trap, heroID, &from)
+}
+
+// SpringChestTraps springs the active traps guarding a piece of furniture a hero searches
+func (ts *TrapSystem) SpringChestTraps(heroID string, furnitureID string) ([]*TrapResult, error) {
+	if furnitureID == "" {
+		return nil, nil
+	}
+
+	ts.mutex.RLock()
+	guards := make([]*Trap, 0)
+	for _, trap := range ts.traps {
+		if trap.Type == ChestTrap && trap.FurnitureID == furnitureID && trap.IsActive() {
+			guards = append(guards, trap)
+		}
+	}
+	ts.mutex.RUnlock()
+
+	results := make([]*TrapResult, 0, len(guards))
+	for _, trap := range guards {
+		result, err := ts.springTrap(trap, heroID, nil)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// DisarmTrap attempts to disarm a known trap next to the hero. Heroes need a tool kit
+// unless their class can disarm bare-handed; a failed attempt springs the trap.
+func (ts *TrapSystem) DisarmTrap(player *Player, trapID string, hasToolKit bool) (bool, []DiceRoll, *TrapResult, error) {
+	trap, err := ts.CheckDisarmTrap(player, trapID, hasToolKit)
+	if err != nil {
+		return false, nil, nil, err
+	}
+
+	rolls := ts.diceSystem.RollDice(SearchDie, 1, "disarm_trap")
+	if rolls[0].Result >= 4 { // Success on 4-6
+		ts.mutex.Lock()
+		trap.Disarmed = true
+		ts.mutex.Unlock()
+
+		ts.broadcaster.BroadcastEvent("TrapDisarmed", protocol.TrapDisarmed{TrapID: trap.ID, EntityID: player.EntityID})
+		ts.logger.Printf("Hero %s disarmed trap %s with roll %d", player.EntityID, trapID, rolls[0].Result)
+		return true, rolls, nil, nil
+	}
+
+	ts.logger.Printf("Hero %s failed to disarm trap %s with roll %d", player.EntityID, trapID, rolls[0].Result)
+	result, err := ts.springTrap(trap, player.EntityID, nil)
+	return false, rolls, result, err
+}
+
+// CheckDisarmTrap checks that a hero may try to disarm a trap: it is active and known to them,
+// they have a tool kit (or need none) and stand next to it
+func (ts *TrapSystem) CheckDisarmTrap(player *Player, trapID string, hasToolKit bool) (*Trap, error) {
+	trap, exists := ts.GetTrap(trapID)
+	if !exists || !trap.IsActive() {
+		return nil, &GameError{Code: "trap_not_found", Message: fmt.Sprintf("trap %s not found", trapID)}
+	}
+	if !trap.IsKnownTo(player.EntityID) {
+		return nil, &GameError{Code: "trap_not_found", Message: fmt.Sprintf("%s has not found trap %s", player.EntityID, trapID)}
+	}
+	if !hasToolKit && !canDisarmWithoutToolKit(player) {
+		return nil, &GameError{Code: "tool_kit_required", Message: "a tool kit is needed to disarm traps"}
+	}
+
+	ts.gameState.Lock.Lock()
+	heroPos, onBoard := ts.gameState.Entities[player.EntityID]
+	ts.gameState.Lock.Unlock()
+	if !onBoard || max(absInt(heroPos.X-trap.Position.X), absInt(heroPos.Y-trap.Position.Y)) > 1 {
+		return nil, &GameError{Code: "not_adjacent", Message: fmt.Sprintf("%s must be next to trap %s", player.EntityID, trapID)}
+	}
+	return trap, nil
+}
+
+// springTrap resolves a trap against a hero. from is the square the hero stepped off
+// when the trap was sprung by movement; a falling block pushes them back onto it.
+func (ts *TrapSystem) springTrap(trap *Trap, heroID string, from *protocol.TileAddress) (*TrapResult, error) {
+	player := ts.findHero(heroID)
+	if player == nil || player.Character == nil {
+		return nil, fmt.Errorf("hero %s not found", heroID)
+	}
+
+	result := &TrapResult{TrapID: trap.ID, Type: trap.Type, EntityID: heroID}
+
+	switch trap.Type {
+	case PitTrap:
+		result.Damage = trap.Damage
+		result.EndsMovement = true
+	case SpearTrap, FallingBlockTrap:
+		// Trap dice attack the hero, who cannot defend
+		result.DiceRolls = ts.diceSystem.RollDice(CombatDie, trap.Damage, "trap")
+		for i := range result.DiceRolls {
+			result.DiceRolls[i].CombatResult = GetCombatResult(result.DiceRolls[i].Result, true)
+			if result.DiceRolls[i].CombatResult == Skull {
+				result.Damage++
+			}
+		}
+		result.EndsMovement = trap.Type == FallingBlockTrap
+	case ChestTrap:
+		result.Damage = trap.Damage
+	default:
+		return nil, fmt.Errorf("unknown trap type %s", trap.Type)
+	}
+
+	ts.mutex.Lock()
+	trap.Sprung = true
+	trap.Revealed = true
+	ts.mutex.Unlock()
+
+	if trap.Type == FallingBlockTrap {
+		ts.gameState.Lock.Lock()
+		ts.gameState.BlockedTiles[protocol.TileAddress{X: trap.Position.X, Y: trap.Position.Y}] = true
+		if from != nil {
+			ts.gameState.Entities[heroID] = *from
+			result.PushedBackTo = from
+		}
+		ts.gameState.Lock.Unlock()
+
+		if from != nil {
+			ts.broadcaster.BroadcastEvent("EntityUpdated", protocol.EntityUpdated{ID: heroID, Tile: *from})
+		}
+	}
+
+	if result.Damage > 0 {
+		player.Character.TakeDamage(result.Damage)
+	}
+
+	ts.broadcaster.BroadcastEvent("TrapTriggered", protocol.TrapTriggered{
+		TrapID:   trap.ID,
+		TrapType: string(trap.Type),
+		EntityID: heroID,
+		Tile:     trap.Position,
+		Damage:   result.Damage,
+		BodyLeft: player.Character.CurrentBody,
+	})
+	ts.logger.Printf("Hero %s sprang %s trap %s for %d damage (%d body left)", heroID, trap.Type, trap.ID, result.Damage, player.Character.CurrentBody)
+
+	if ts.heroLifecycle != nil {
+		ts.heroLifecycle.CheckHero(heroID)
+	}
+
+	return result, nil
+}
+
+// floorTrapAt returns the active floor trap on a square, if any
+func (ts *TrapSystem) floorTrapAt(x, y int) *Trap {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+
+	for _, trap := range ts.traps {
+		if trap.Type != ChestTrap && trap.IsActive() && trap.Position.X == x && trap.Position.Y == y {
+			return trap
+		}
+	}
+	return nil
+}
+
+func (ts *TrapSystem) findHero(heroID string) *Player {
+	if ts.turnManager == nil {
+		return nil
+	}
+	for _, player := range ts.turnManager.GetHeroPlayers() {
+		if player.EntityID == heroID {
+			return player
+		}
+	}
+	return nil
+}
+
+// SerializeForPersistence serializes all traps to JSON
+func (ts *TrapSystem) SerializeForPersistence() ([]byte, error) {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+
+	return json.Marshal(ts.traps)
+}
+
+// RestoreFromPersistence replaces all traps with previously serialized data
+func (ts *TrapSystem) RestoreFromPersistence(data []byte) error {
+	var restored map[string]*Trap
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return err
+	}
+
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	ts.traps = orEmpty(restored)
+	for _, trap := range ts.traps {
+		trap.RevealedTo = orEmpty(trap.RevealedTo)
+	}
+	return nil
+}
+
+// canDisarmWithoutToolKit reports whether a hero's class can disarm traps bare-handed
+func canDisarmWithoutToolKit(player *Player) bool {
+	return strings.EqualFold(string(player.Class), string(Dwarf))
+}
+
+// regionAt returns the region of a square, if the region map covers it.
+// Callers must hold the game state lock.
+func regionAt(state *GameState, pos protocol.TileAddress) (int, bool) {
+	idx := pos.Y*state.Segment.Width + pos.X
+	if pos.X < 0 || pos.Y < 0 || pos.X >= state.Segment.Width || idx >= len(state.RegionMap.TileRegionIDs) {
+		return -1, false
+	}
+	return state.RegionMap.TileRegionIDs[idx], true
+}
+
+func trapsToLite(traps []*Trap) []protocol.TrapLite {
+	lite := make([]protocol.TrapLite, 0, len(traps))
+	for _, trap := range traps {
+		lite = append(lite, protocol.TrapLite{
+			ID:          trap.ID,
+			Type:        string(trap.Type),
+			Tile:        trap.Position,
+			FurnitureID: trap.FurnitureID,
+		})
+	}
+	return lite
+}
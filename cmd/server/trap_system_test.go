@@ -0,0 +1,128 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// createTestTrapHero gives hero-1 rolled movement and an empty board to walk on
+func createTestTrapHero(t *testing.T) *HeroActionSystem {
+	has := createTestHeroActionSystem()
+	has.gameState.BlockedTiles = make(map[protocol.TileAddress]bool)
+	has.SetMovementValidator(&MockMovementValidator{})
+
+	has.debugSystem.SetDiceOverride("movement", 6)
+	if _, err := has.turnManager.RollMovementDice(); err != nil {
+		t.Fatalf("Failed to roll movement: %v", err)
+	}
+	return has
+}
+
+func moveRequest(dx, dy float64) MovementRequest {
+	return MovementRequest{
+		PlayerID:   "player-1",
+		EntityID:   "hero-1",
+		Action:     "move_before",
+		Parameters: map[string]any{"dx": dx, "dy": dy},
+	}
+}
+
+func TestTraps_UnknownPitSpringsMidMove(t *testing.T) {
+	has := createTestTrapHero(t)
+	has.trapSystem.AddTrap(&Trap{ID: "pit-1", Type: PitTrap, Position: protocol.TileAddress{X: 5, Y: 6}})
+
+	result, err := has.ProcessMovement(moveRequest(0, 1))
+	if err != nil {
+		t.Fatalf("Expected the move onto the trap to succeed, got: %v", err)
+	}
+	if len(result.TrapsSprung) != 1 || result.Damage != 1 {
+		t.Fatalf("Expected the pit to deal 1 damage, got %+v", result.TrapsSprung)
+	}
+
+	player := has.turnManager.GetPlayer("player-1")
+	if player.Character.CurrentBody != player.Character.BaseStats.BodyPoints-1 {
+		t.Errorf("Expected hero to lose 1 body, got %d", player.Character.CurrentBody)
+	}
+	if has.turnManager.CanMove() {
+		t.Error("Expected falling into a pit to end the hero's movement")
+	}
+}
+
+func TestTraps_FallingBlockPushesHeroBack(t *testing.T) {
+	has := createTestTrapHero(t)
+	has.debugSystem.SetDiceOverride("trap", 4)
+	has.trapSystem.AddTrap(&Trap{ID: "block-1", Type: FallingBlockTrap, Position: protocol.TileAddress{X: 5, Y: 6}})
+
+	result, err := has.ProcessMovement(moveRequest(0, 1))
+	if err != nil {
+		t.Fatalf("Expected the move to succeed, got: %v", err)
+	}
+	if result.Damage != 3 {
+		t.Errorf("Expected 3 skulls from the falling block, got %d damage", result.Damage)
+	}
+	if pos := has.gameState.Entities["hero-1"]; pos.X != 5 || pos.Y != 5 {
+		t.Errorf("Expected hero pushed back to (5,5), got (%d,%d)", pos.X, pos.Y)
+	}
+	if !has.gameState.BlockedTiles[protocol.TileAddress{X: 5, Y: 6}] {
+		t.Error("Expected the fallen block to seal the square")
+	}
+}
+
+func TestTraps_KnownTrapBlocksMovement(t *testing.T) {
+	has := createTestTrapHero(t)
+	has.trapSystem.AddTrap(&Trap{ID: "spear-1", Type: SpearTrap, Position: protocol.TileAddress{X: 5, Y: 6}})
+	has.trapSystem.traps["spear-1"].RevealedTo["hero-1"] = true
+
+	if _, err := has.ProcessMovement(moveRequest(0, 1)); err == nil {
+		t.Fatal("Expected a known trap to block movement")
+	}
+	if trap, _ := has.trapSystem.GetTrap("spear-1"); !trap.IsActive() {
+		t.Error("Expected the known trap not to be sprung")
+	}
+}
+
+func TestTraps_DisarmNeedsToolKitAndFailureSpringsTrap(t *testing.T) {
+	has := createTestHeroActionSystem()
+	has.trapSystem.AddTrap(&Trap{ID: "pit-1", Type: PitTrap, Position: protocol.TileAddress{X: 5, Y: 6}, Revealed: true})
+	disarm := ActionRequest{PlayerID: "player-1", EntityID: "hero-1", Action: DisarmTrapAction, Parameters: map[string]any{"trapId": "pit-1"}}
+
+	// Barbarian without a tool kit
+	has.turnManager.RestoreActions()
+	if _, err := has.ProcessAction(disarm); err == nil {
+		t.Fatal("Expected disarming without a tool kit to fail")
+	}
+	if left := has.turnManager.GetTurnState().ActionsLeft; left != 1 {
+		t.Errorf("Expected a refused attempt to leave the action unspent, got %d left", left)
+	}
+
+	// A dwarf needs no tool kit; a low roll springs the trap
+	has.turnManager.GetPlayer("player-1").Class = Dwarf
+	has.debugSystem.SetDiceOverride("disarm_trap", 2)
+
+	result, err := has.ProcessAction(disarm)
+	if err != nil {
+		t.Fatalf("Expected a disarm attempt, got: %v", err)
+	}
+	if result.Success || len(result.TrapsSprung) != 1 {
+		t.Errorf("Expected a failed disarm to spring the trap, got %+v", result)
+	}
+	if trap, _ := has.trapSystem.GetTrap("pit-1"); trap.IsActive() {
+		t.Error("Expected the sprung trap to be spent")
+	}
+}
+
+func TestTraps_ChestTrapSpringsOnSearch(t *testing.T) {
+	has := createTestHeroActionSystem()
+	has.trapSystem.AddTrap(&Trap{ID: "needle-1", Type: ChestTrap, FurnitureID: "chest-1", Damage: 2})
+
+	results, err := has.trapSystem.SpringChestTraps("hero-1", "other-chest")
+	if err != nil || len(results) != 0 {
+		t.Fatalf("Expected searching other furniture to be safe, got %v (%v)", results, err)
+	}
+
+	results, err = has.trapSystem.SpringChestTraps("hero-1", "chest-1")
+	if err != nil || len(results) != 1 || results[0].Damage != 2 {
+		t.Fatalf("Expected the chest trap to deal 2 damage, got %+v (%v)", results, err)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -154,3 +155,58 @@ func (tdm *TreasureDeckManager) GetDiscardSize() int {
 	defer tdm.mutex.Unlock()
 	return len(tdm.discardPile)
 }
+
+// treasureDeckPersistence stores the deck and discard pile as ordered card IDs
+type treasureDeckPersistence struct {
+	Deck        []string `json:"deck"`
+	DiscardPile []string `json:"discardPile"`
+}
+
+// SerializeForPersistence serializes the draw pile order and discard pile to JSON
+func (tdm *TreasureDeckManager) SerializeForPersistence() ([]byte, error) {
+	tdm.mutex.Lock()
+	defer tdm.mutex.Unlock()
+
+	data := treasureDeckPersistence{
+		Deck:        make([]string, 0, len(tdm.deck)),
+		DiscardPile: make([]string, 0, len(tdm.discardPile)),
+	}
+	for _, card := range tdm.deck {
+		data.Deck = append(data.Deck, card.ID)
+	}
+	for _, card := range tdm.discardPile {
+		data.DiscardPile = append(data.DiscardPile, card.ID)
+	}
+
+	return json.Marshal(data)
+}
+
+// RestoreFromPersistence rebuilds the draw pile (in stored order) and discard pile from card IDs
+func (tdm *TreasureDeckManager) RestoreFromPersistence(data []byte) error {
+	var restored treasureDeckPersistence
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return err
+	}
+
+	tdm.mutex.Lock()
+	defer tdm.mutex.Unlock()
+
+	tdm.deck = tdm.cardsFromIDs(restored.Deck)
+	tdm.discardPile = tdm.cardsFromIDs(restored.DiscardPile)
+
+	tdm.logger.Printf("Restored treasure deck (%d cards, %d discarded)", len(tdm.deck), len(tdm.discardPile))
+	return nil
+}
+
+func (tdm *TreasureDeckManager) cardsFromIDs(ids []string) []*TreasureCard {
+	cards := make([]*TreasureCard, 0, len(ids))
+	for _, id := range ids {
+		card, ok := tdm.contentManager.GetTreasureCard(id)
+		if !ok {
+			tdm.logger.Printf("Warning: treasure card %s no longer exists, dropping it from the deck", id)
+			continue
+		}
+		cards = append(cards, card)
+	}
+	return cards
+}
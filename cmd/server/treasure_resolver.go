@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
@@ -9,18 +10,20 @@ import (
 
 // TreasureResult represents the result of a treasure search
 type TreasureResult struct {
-	Success      bool          `json:"success"`
-	FoundItems   []*ItemCard   `json:"foundItems"`
-	FoundGold    int           `json:"foundGold"`
-	IsEmpty      bool          `json:"isEmpty"`
-	IsHazard     bool          `json:"isHazard"`
-	HazardDamage int           `json:"hazardDamage"`
-	EndTurn      bool          `json:"endTurn"`
-	IsMonster    bool          `json:"isMonster"`
-	MonsterType  string        `json:"monsterType,omitempty"`
-	Message      string        `json:"message"`
-	NoteID       string        `json:"noteId,omitempty"`
-	CardDrawn    *TreasureCard `json:"cardDrawn,omitempty"`
+	Success          bool                       `json:"success"`
+	FoundItems       []*ItemCard                `json:"foundItems"`
+	FoundGold        int                        `json:"foundGold"`
+	IsEmpty          bool                       `json:"isEmpty"`
+	IsHazard         bool                       `json:"isHazard"`
+	HazardDamage     int                        `json:"hazardDamage"`
+	EndTurn          bool                       `json:"endTurn"`
+	IsMonster        bool                       `json:"isMonster"`
+	MonsterType      string                     `json:"monsterType,omitempty"`
+	MonsterOverrides *geometry.MonsterOverrides `json:"monsterOverrides,omitempty"` // Quest stats for the wandering monster
+	MonsterModifier  *geometry.MonsterModifier  `json:"monsterModifier,omitempty"`  // Set when a quest note strengthens a monster
+	Message          string                     `json:"message"`
+	NoteID           string                     `json:"noteId,omitempty"`
+	CardDrawn        *TreasureCard              `json:"cardDrawn,omitempty"`
 }
 
 // TreasureResolver resolves treasure searches
@@ -123,8 +126,8 @@ func (tr *TreasureResolver) resolveQuestNote(note *geometry.QuestTreasureNote, n
 		result.IsEmpty = true
 
 	case "monster_modifier":
-		// This is handled separately by the monster system
-		result.Message = note.Description
+		// Applied to the monster by the caller, which owns the monster system
+		result.MonsterModifier = note.MonsterModifier
 	}
 
 	return result, nil
@@ -157,6 +160,9 @@ func (tr *TreasureResolver) drawFromTreasureDeck() (*TreasureResult, error) {
 			result.FoundItems = []*ItemCard{item}
 		} else if item, ok := tr.contentManager.GetArtifactCard(card.ID); ok {
 			result.FoundItems = []*ItemCard{item}
+		} else if card.Type == "potion" {
+			// Potions only in the treasure deck are kept as the card itself
+			result.FoundItems = []*ItemCard{treasureCardToItem(card)}
 		}
 
 	case "hazard":
@@ -170,6 +176,7 @@ func (tr *TreasureResolver) drawFromTreasureDeck() (*TreasureResult, error) {
 		// The wandering monster type is specified in the quest
 		if tr.quest != nil {
 			result.MonsterType = tr.quest.WanderingMonster
+			result.MonsterOverrides = tr.quest.WanderingMonsterStats
 		}
 		tr.logger.Printf("Wandering monster! Type: %s", result.MonsterType)
 	}
@@ -181,3 +188,19 @@ func (tr *TreasureResolver) drawFromTreasureDeck() (*TreasureResult, error) {
 func (tr *TreasureResolver) IsNoteConsumed(noteID string) bool {
 	return tr.consumedNotes[noteID]
 }
+
+// SerializeForPersistence serializes the consumed quest notes to JSON
+func (tr *TreasureResolver) SerializeForPersistence() ([]byte, error) {
+	return json.Marshal(tr.consumedNotes)
+}
+
+// RestoreFromPersistence restores the consumed quest notes
+func (tr *TreasureResolver) RestoreFromPersistence(data []byte) error {
+	var restored map[string]bool
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return err
+	}
+
+	tr.consumedNotes = orEmpty(restored)
+	return nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
@@ -11,11 +12,14 @@ import (
 // TurnStateManager manages turn state for all heroes and monsters in a quest
 type TurnStateManager struct {
 	currentTurn     int
-	heroStates      map[string]*HeroTurnState    // Hero ID -> state
-	monsterStates   map[string]*MonsterTurnState // Monster ID -> state
-	selectedMonster string                       // Currently selected monster ID (for GM control)
-	reactionStack   []ReactionContext            // For handling interrupts/reactions
-	turnHistory     []TurnHistoryEntry           // For replay/undo (future)
+	heroStates      map[string]*HeroTurnState      // Hero ID -> state
+	monsterStates   map[string]*MonsterTurnState   // Monster ID -> state
+	selectedMonster string                         // Currently selected monster ID (for GM control)
+	reactionStack   []ReactionContext              // For handling interrupts/reactions
+	turnHistory     []TurnHistoryEntry             // For replay/undo (future)
+	pendingEffects  map[string][]ActiveEffect      // Effects waiting for a hero's next turn to start
+	pendingItemUse  map[string]map[string]int      // Items used this round before the hero's turn started
+	searchLedger    map[string]map[string][]string // Hero ID -> location key -> search types made this quest
 	logger          Logger
 	mutex           sync.RWMutex
 }
@@ -53,6 +57,9 @@ func NewTurnStateManager(logger Logger) *TurnStateManager {
 		heroStates:      make(map[string]*HeroTurnState),
 		monsterStates:   make(map[string]*MonsterTurnState),
 		selectedMonster: "",
+		pendingEffects:  make(map[string][]ActiveEffect),
+		pendingItemUse:  make(map[string]map[string]int),
+		searchLedger:    make(map[string]map[string][]string),
 		reactionStack:   make([]ReactionContext, 0),
 		turnHistory:     make([]TurnHistoryEntry, 0),
 		logger:          logger,
@@ -76,6 +83,18 @@ func (tsm *TurnStateManager) StartHeroTurn(heroID, playerID string, startPositio
 		tsm.heroStates[heroID] = NewHeroTurnState(heroID, playerID, tsm.currentTurn, startPosition)
 	}
 
+	// Apply effects that were queued while the hero had no turn state
+	for _, effect := range tsm.pendingEffects[heroID] {
+		tsm.heroStates[heroID].AddActiveEffect(effect)
+	}
+	delete(tsm.pendingEffects, heroID)
+
+	// Items used out of turn earlier this round count towards this turn's limits
+	for itemID, count := range tsm.pendingItemUse[heroID] {
+		tsm.heroStates[heroID].ItemUsageThisTurn[itemID] += count
+	}
+	delete(tsm.pendingItemUse, heroID)
+
 	tsm.logger.Printf("Turn started for hero %s (player %s), turn %d", heroID, playerID, tsm.currentTurn)
 	return nil
 }
@@ -168,6 +187,22 @@ func (tsm *TurnStateManager) AddActiveEffect(heroID string, effect ActiveEffect)
 	return nil
 }
 
+// QueueActiveEffect adds an effect to a hero, holding it until the hero's next turn
+// starts if the hero has no turn state yet
+func (tsm *TurnStateManager) QueueActiveEffect(heroID string, effect ActiveEffect) {
+	tsm.mutex.Lock()
+	defer tsm.mutex.Unlock()
+
+	if state := tsm.heroStates[heroID]; state != nil {
+		state.AddActiveEffect(effect)
+		tsm.logger.Printf("Hero %s gained effect: %s (trigger: %s)", heroID, effect.EffectType, effect.Trigger)
+		return
+	}
+
+	tsm.pendingEffects[heroID] = append(tsm.pendingEffects[heroID], effect)
+	tsm.logger.Printf("Hero %s queued effect: %s (trigger: %s)", heroID, effect.EffectType, effect.Trigger)
+}
+
 // TriggerEffects triggers effects for a hero with matching trigger
 func (tsm *TurnStateManager) TriggerEffects(heroID string, trigger string) []ActiveEffect {
 	tsm.mutex.Lock()
@@ -175,7 +210,7 @@ func (tsm *TurnStateManager) TriggerEffects(heroID string, trigger string) []Act
 
 	state := tsm.heroStates[heroID]
 	if state == nil {
-		return nil
+		return tsm.triggerPendingEffects(heroID, trigger)
 	}
 
 	triggered := state.TriggerEffects(trigger)
@@ -185,6 +220,26 @@ func (tsm *TurnStateManager) TriggerEffects(heroID string, trigger string) []Act
 	return triggered
 }
 
+// triggerPendingEffects consumes queued effects for a hero without turn state
+func (tsm *TurnStateManager) triggerPendingEffects(heroID string, trigger string) []ActiveEffect {
+	var triggered, remaining []ActiveEffect
+	for _, effect := range tsm.pendingEffects[heroID] {
+		if effect.Trigger == trigger {
+			effect.Applied = true
+			triggered = append(triggered, effect)
+		} else {
+			remaining = append(remaining, effect)
+		}
+	}
+
+	if len(remaining) > 0 {
+		tsm.pendingEffects[heroID] = remaining
+	} else {
+		delete(tsm.pendingEffects, heroID)
+	}
+	return triggered
+}
+
 // CanMove validates whether a hero can move
 func (tsm *TurnStateManager) CanMove(heroID string) (bool, string) {
 	tsm.mutex.RLock()
@@ -224,14 +279,15 @@ func (tsm *TurnStateManager) CanUseItem(heroID string, itemID string, itemDef *I
 	tsm.mutex.RLock()
 	defer tsm.mutex.RUnlock()
 
-	state := tsm.heroStates[heroID]
-	if state == nil {
-		return false, "hero has no active turn"
+	// Heroes without a turn state are using the item out of turn
+	usage := tsm.pendingItemUse[heroID]
+	if state := tsm.heroStates[heroID]; state != nil {
+		usage = state.ItemUsageThisTurn
 	}
 
 	// Check if item has per-turn usage limit
 	if itemDef != nil && itemDef.MaxUsesPerTurn > 0 {
-		usageCount := state.ItemUsageThisTurn[itemID]
+		usageCount := usage[itemID]
 		if usageCount >= itemDef.MaxUsesPerTurn {
 			return false, fmt.Sprintf("item can only be used %d time(s) per turn", itemDef.MaxUsesPerTurn)
 		}
@@ -240,6 +296,25 @@ func (tsm *TurnStateManager) CanUseItem(heroID string, itemID string, itemDef *I
 	return true, ""
 }
 
+// RecordItemUse records an item use against the hero's per-turn limits, holding it until the
+// hero's turn starts if the hero has no turn state yet
+func (tsm *TurnStateManager) RecordItemUse(heroID string, activity Activity) {
+	tsm.mutex.Lock()
+	defer tsm.mutex.Unlock()
+
+	if state := tsm.heroStates[heroID]; state != nil {
+		state.RecordActivity(activity)
+		tsm.logger.Printf("Hero %s performed activity: %s (%s)", heroID, activity.Type, activity.ItemID)
+		return
+	}
+
+	if tsm.pendingItemUse[heroID] == nil {
+		tsm.pendingItemUse[heroID] = make(map[string]int)
+	}
+	tsm.pendingItemUse[heroID][activity.ItemID]++
+	tsm.logger.Printf("Hero %s used %s out of turn", heroID, activity.ItemID)
+}
+
 // ItemDefinition represents item metadata (placeholder - will be in ItemManager)
 type ItemDefinition struct {
 	ID              string
@@ -248,11 +323,16 @@ type ItemDefinition struct {
 	MaxUsesPerQuest int
 }
 
-// CanSearchTreasure validates whether a hero can search for treasure at a location
+// CanSearchTreasure validates whether a hero can search for treasure at a location.
+// Each hero searches each room for treasure once per quest.
 func (tsm *TurnStateManager) CanSearchTreasure(heroID string, locationKey string) (bool, string) {
 	tsm.mutex.RLock()
 	defer tsm.mutex.RUnlock()
 
+	if slices.Contains(tsm.searchLedger[heroID][locationKey], "treasure") {
+		return false, "already searched for treasure at this location"
+	}
+
 	state := tsm.heroStates[heroID]
 	if state == nil {
 		return false, "hero has no active turn"
@@ -261,18 +341,42 @@ func (tsm *TurnStateManager) CanSearchTreasure(heroID string, locationKey string
 	return state.CanSearchTreasure(locationKey)
 }
 
+// GetSearchLedger returns the search types each hero has made at each location this quest
+func (tsm *TurnStateManager) GetSearchLedger() map[string]map[string][]string {
+	tsm.mutex.RLock()
+	defer tsm.mutex.RUnlock()
+
+	ledger := make(map[string]map[string][]string, len(tsm.searchLedger))
+	for heroID, locations := range tsm.searchLedger {
+		ledger[heroID] = make(map[string][]string, len(locations))
+		for locationKey, searchTypes := range locations {
+			ledger[heroID][locationKey] = slices.Clone(searchTypes)
+		}
+	}
+	return ledger
+}
+
 // RecordSearch records a search action for a hero
 func (tsm *TurnStateManager) RecordSearch(heroID string, searchType string, locationKey string, locationType string, position protocol.TileAddress, success bool, foundItems []string) error {
 	tsm.mutex.Lock()
 	defer tsm.mutex.Unlock()
 
+	// The ledger outlives the turn so searches stay recorded for the rest of the quest,
+	// even when the hero has no turn state to log them in
+	if tsm.searchLedger[heroID] == nil {
+		tsm.searchLedger[heroID] = make(map[string][]string)
+	}
+	if !slices.Contains(tsm.searchLedger[heroID][locationKey], searchType) {
+		tsm.searchLedger[heroID][locationKey] = append(tsm.searchLedger[heroID][locationKey], searchType)
+	}
+	tsm.logger.Printf("Hero %s searched for %s at %s (success: %t)", heroID, searchType, locationKey, success)
+
 	state := tsm.heroStates[heroID]
 	if state == nil {
 		return &GameError{Code: "no_active_turn", Message: "hero has no active turn"}
 	}
 
 	state.RecordSearch(searchType, locationKey, locationType, position, success, foundItems)
-	tsm.logger.Printf("Hero %s searched for %s at %s (success: %t)", heroID, searchType, locationKey, success)
 	return nil
 }
 
@@ -581,39 +685,97 @@ func (tsm *TurnStateManager) AdvanceTurn() {
 	tsm.currentTurn++
 	tsm.logger.Printf("TurnStateManager: Advanced to turn %d, clearing all hero states", tsm.currentTurn)
 
+	// Keep effects that outlast the turn; they are re-applied when the hero's turn starts
+	for heroID, state := range tsm.heroStates {
+		for _, effect := range state.ActiveEffects {
+			if !effect.Applied && effect.ExpiresOn != "end_of_turn" {
+				tsm.pendingEffects[heroID] = append(tsm.pendingEffects[heroID], effect)
+			}
+		}
+	}
+
+	tsm.pendingItemUse = make(map[string]map[string]int)
+
 	// Clear all hero states for the new turn
 	// They will be recreated when each hero rolls movement dice
 	tsm.heroStates = make(map[string]*HeroTurnState)
 }
 
-// SerializeForPersistence serializes the turn state manager to JSON (for future database storage)
+// turnStateManagerPersistence is the serialized form of TurnStateManager
+type turnStateManagerPersistence struct {
+	CurrentTurn     int                            `json:"currentTurn"`
+	HeroStates      map[string]*HeroTurnState      `json:"heroStates"`
+	MonsterStates   map[string]*MonsterTurnState   `json:"monsterStates"`
+	SelectedMonster string                         `json:"selectedMonster"`
+	ReactionStack   []ReactionContext              `json:"reactionStack"`
+	TurnHistory     []TurnHistoryEntry             `json:"turnHistory"`
+	PendingEffects  map[string][]ActiveEffect      `json:"pendingEffects,omitempty"`
+	PendingItemUse  map[string]map[string]int      `json:"pendingItemUse,omitempty"`
+	SearchLedger    map[string]map[string][]string `json:"searchLedger,omitempty"`
+}
+
+// SerializeForPersistence serializes the turn state manager to JSON
 func (tsm *TurnStateManager) SerializeForPersistence() ([]byte, error) {
 	tsm.mutex.RLock()
 	defer tsm.mutex.RUnlock()
 
-	data := map[string]interface{}{
-		"currentTurn":     tsm.currentTurn,
-		"heroStates":      tsm.heroStates,
-		"monsterStates":   tsm.monsterStates,
-		"selectedMonster": tsm.selectedMonster,
-		"reactionStack":   tsm.reactionStack,
-		"turnHistory":     tsm.turnHistory,
+	data := turnStateManagerPersistence{
+		CurrentTurn:     tsm.currentTurn,
+		HeroStates:      tsm.heroStates,
+		MonsterStates:   tsm.monsterStates,
+		SelectedMonster: tsm.selectedMonster,
+		ReactionStack:   tsm.reactionStack,
+		TurnHistory:     tsm.turnHistory,
+		PendingEffects:  tsm.pendingEffects,
+		PendingItemUse:  tsm.pendingItemUse,
+		SearchLedger:    tsm.searchLedger,
 	}
 
 	return json.Marshal(data)
 }
 
-// RestoreFromPersistence restores the turn state manager from JSON (for future database loading)
+// RestoreFromPersistence restores the turn state manager from JSON produced by SerializeForPersistence
 func (tsm *TurnStateManager) RestoreFromPersistence(data []byte) error {
+	var restored turnStateManagerPersistence
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return err
+	}
+
 	tsm.mutex.Lock()
 	defer tsm.mutex.Unlock()
 
-	var restored map[string]interface{}
-	if err := json.Unmarshal(data, &restored); err != nil {
-		return err
+	tsm.currentTurn = max(restored.CurrentTurn, 1)
+	tsm.heroStates = orEmpty(restored.HeroStates)
+	tsm.monsterStates = orEmpty(restored.MonsterStates)
+	tsm.selectedMonster = restored.SelectedMonster
+	tsm.pendingEffects = orEmpty(restored.PendingEffects)
+	tsm.pendingItemUse = orEmpty(restored.PendingItemUse)
+	tsm.searchLedger = orEmpty(restored.SearchLedger)
+	tsm.reactionStack = restored.ReactionStack
+	if tsm.reactionStack == nil {
+		tsm.reactionStack = make([]ReactionContext, 0)
+	}
+	tsm.turnHistory = restored.TurnHistory
+	if tsm.turnHistory == nil {
+		tsm.turnHistory = make([]TurnHistoryEntry, 0)
+	}
+
+	// Nil maps serialize as null; make sure restored states are safe to mutate
+	for _, state := range tsm.heroStates {
+		state.TurnFlags = orEmpty(state.TurnFlags)
+		state.ItemUsageThisTurn = orEmpty(state.ItemUsageThisTurn)
+		state.LocationActions = orEmpty(state.LocationActions)
+		for _, location := range state.LocationActions {
+			location.SearchesByHero = orEmpty(location.SearchesByHero)
+		}
+	}
+	for _, state := range tsm.monsterStates {
+		state.TurnFlags = orEmpty(state.TurnFlags)
+		state.SpecialAbilitiesUsed = orEmpty(state.SpecialAbilitiesUsed)
+		state.QuestAbilityUsageLeft = orEmpty(state.QuestAbilityUsageLeft)
 	}
 
-	// This is a placeholder - full implementation would properly deserialize all fields
-	tsm.logger.Printf("Turn state manager restored from persistence (placeholder)")
+	tsm.logger.Printf("Turn state manager restored from persistence (turn %d, %d heroes, %d monsters)",
+		tsm.currentTurn, len(tsm.heroStates), len(tsm.monsterStates))
 	return nil
 }
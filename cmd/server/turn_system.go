@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
@@ -86,8 +87,10 @@ type TurnManager struct {
 	players          map[string]*Player // Player ID -> Player
 	broadcaster      Broadcaster
 	logger           Logger
-	diceSystem       *DiceSystem       // For rolling movement dice
-	turnStateManager *TurnStateManager // Manages per-hero turn state
+	diceSystem       *DiceSystem              // For rolling movement dice
+	turnStateManager *TurnStateManager        // Manages per-hero turn state
+	rules            *RuleSet                 // House rules, for fixed movement
+	monstersInSight  func(heroID string) bool // Reports whether the hero has line of sight to any living monster
 	lock             sync.RWMutex
 }
 
@@ -142,6 +145,9 @@ func NewPlayerFromContent(id, entityID string, heroCard *HeroCard, contentMgr *C
 
 	// Equip starting equipment
 	if inventoryMgr != nil {
+		// Keep equipment stats in step with what the hero equips from here on
+		inventoryMgr.RegisterHero(player)
+
 		// Add starting weapons
 		for _, weaponID := range heroCard.StartingEquipment.Weapons {
 			if _, ok := contentMgr.GetEquipmentCard(weaponID); ok {
@@ -248,17 +254,24 @@ type HeroCharacter struct {
 	CurrentBody   int       `json:"currentBody"`
 	CurrentMind   int       `json:"currentMind"`
 	EquipmentMods StatMods  `json:"equipmentMods"`
+	IsAsleep      bool      `json:"isAsleep,omitempty"` // Dread sleep spell: loses the next turn unless woken by damage
 }
 
-// StatMods represents modifications from equipment
+// StatMods represents modifications from equipment, derived from the equipped items
 type StatMods struct {
-	AttackBonus  int `json:"attackBonus"`  // Additional attack dice from weapons
-	DefenseBonus int `json:"defenseBonus"` // Additional defense from armor
+	AttackDice   int `json:"attackDice,omitempty"`   // Equipped weapon's attack dice, replacing the base value (0 = unarmed)
+	AttackBonus  int `json:"attackBonus"`            // Additional attack dice from weapons
+	DefenseBonus int `json:"defenseBonus"`           // Additional defense from armor
+	MovementDice int `json:"movementDice,omitempty"` // Additional movement dice from artifacts
 }
 
 // GetEffectiveAttackDice returns total attack dice including equipment
 func (hc *HeroCharacter) GetEffectiveAttackDice() int {
-	total := hc.BaseStats.AttackDice + hc.EquipmentMods.AttackBonus
+	base := hc.BaseStats.AttackDice
+	if hc.EquipmentMods.AttackDice > 0 {
+		base = hc.EquipmentMods.AttackDice
+	}
+	total := base + hc.EquipmentMods.AttackBonus
 	if total < 1 {
 		return 1 // Attack dice never falls below 1
 	}
@@ -328,6 +341,14 @@ func (tm *TurnManager) SetTurnStateManager(tsm *TurnStateManager) {
 	tm.turnStateManager = tsm
 }
 
+// SetRuleSet sets the house rules consulted when rolling movement
+func (tm *TurnManager) SetRuleSet(rules *RuleSet, monstersInSight func(heroID string) bool) {
+	tm.lock.Lock()
+	defer tm.lock.Unlock()
+	tm.rules = rules
+	tm.monstersInSight = monstersInSight
+}
+
 // AddPlayer adds a player to the game
 func (tm *TurnManager) AddPlayer(player *Player) error {
 	tm.lock.Lock()
@@ -473,6 +494,24 @@ func (tm *TurnManager) EndMovement() error {
 	return nil
 }
 
+// ForfeitMovement ends the current hero's movement and discards any squares left,
+// e.g. after falling into a pit
+func (tm *TurnManager) ForfeitMovement(reason string) {
+	tm.lock.Lock()
+	defer tm.lock.Unlock()
+
+	if tm.state.CurrentTurn != HeroTurn {
+		return
+	}
+
+	tm.logger.Printf("Movement forfeited (%s) with %d points remaining", reason, tm.state.MovementLeft)
+	tm.state.MovementLeft = 0
+	tm.state.HasMoved = true
+	tm.state.MovementStarted = true
+
+	tm.broadcastTurnState()
+}
+
 // CanMove checks if the player can still move this turn
 func (tm *TurnManager) CanMove() bool {
 	tm.lock.RLock()
@@ -489,6 +528,12 @@ func (tm *TurnManager) CanMove() bool {
 
 // RollMovementDice rolls movement dice for the current player and sets available movement
 func (tm *TurnManager) RollMovementDice() ([]DiceRoll, error) {
+	return tm.RollMovementDiceWithBonus(0)
+}
+
+// RollMovementDiceWithBonus rolls the active hero's movement dice plus extraDice more, and
+// any extra dice the hero's artifacts give
+func (tm *TurnManager) RollMovementDiceWithBonus(extraDice int) ([]DiceRoll, error) {
 	tm.lock.Lock()
 	defer tm.lock.Unlock()
 
@@ -506,10 +551,17 @@ func (tm *TurnManager) RollMovementDice() ([]DiceRoll, error) {
 		return nil, fmt.Errorf("no active player found")
 	}
 
-	movementDiceCount := player.Character.BaseStats.MovementDice
+	extraDice += player.Character.EquipmentMods.MovementDice
+	movementDiceCount := player.Character.BaseStats.MovementDice + extraDice
 
-	// Roll the dice
-	diceRolls := tm.diceSystem.RollDice(MovementDie, movementDiceCount, "movement")
+	// Roll the dice; with no monster in sight the fixed-movement house rule replaces the hero's own dice
+	var diceRolls []DiceRoll
+	if tm.movesFixedDistance(player.EntityID) {
+		diceRolls = append([]DiceRoll{{Die: MovementDie, Result: FixedMovementSquares, Type: "fixed_movement"}},
+			tm.diceSystem.RollDice(MovementDie, extraDice, "movement")...)
+	} else {
+		diceRolls = tm.diceSystem.RollDice(MovementDie, movementDiceCount, "movement")
+	}
 
 	// Calculate total movement points
 	totalMovement := 0
@@ -531,6 +583,11 @@ func (tm *TurnManager) RollMovementDice() ([]DiceRoll, error) {
 	return diceRolls, nil
 }
 
+// movesFixedDistance reports whether the FixedMovementWhenClear rule applies to the hero's roll
+func (tm *TurnManager) movesFixedDistance(heroID string) bool {
+	return tm.rules.ToLite().FixedMovementWhenClear && tm.monstersInSight != nil && !tm.monstersInSight(heroID)
+}
+
 // ConsumeAction reduces remaining action points and marks action as taken
 func (tm *TurnManager) ConsumeAction() error {
 	tm.lock.Lock()
@@ -770,6 +827,51 @@ func (tm *TurnManager) GetTurnState() TurnState {
 	return *tm.state
 }
 
+// turnManagerPersistence is the serialized form of TurnManager
+type turnManagerPersistence struct {
+	State   *TurnState         `json:"state"`
+	Players map[string]*Player `json:"players"`
+}
+
+// SerializeForPersistence serializes the turn state and all players (including hero stats) to JSON
+func (tm *TurnManager) SerializeForPersistence() ([]byte, error) {
+	tm.lock.RLock()
+	defer tm.lock.RUnlock()
+
+	return json.Marshal(turnManagerPersistence{
+		State:   tm.state,
+		Players: tm.players,
+	})
+}
+
+// RestoreFromPersistence restores the turn state and players from previously serialized data.
+// Players already in the game keep their identity; their character and status are overwritten.
+func (tm *TurnManager) RestoreFromPersistence(data []byte) error {
+	var restored turnManagerPersistence
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return err
+	}
+
+	tm.lock.Lock()
+	defer tm.lock.Unlock()
+
+	if restored.State != nil {
+		tm.state = restored.State
+	}
+
+	for playerID, player := range restored.Players {
+		if existing, ok := tm.players[playerID]; ok {
+			existing.Character = player.Character
+			existing.IsActive = player.IsActive
+			continue
+		}
+		tm.players[playerID] = player
+	}
+
+	tm.logger.Printf("Restored turn manager from persistence (turn %d, %d players)", tm.state.TurnNumber, len(restored.Players))
+	return nil
+}
+
 // IsGameMasterTurn checks if it's currently the GameMaster's turn
 func (tm *TurnManager) IsGameMasterTurn() bool {
 	tm.lock.RLock()
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// WanderingMonsterResult describes a wandering monster and its surprise attack
+type WanderingMonsterResult struct {
+	MonsterID    string               `json:"monsterId"`
+	MonsterType  MonsterType          `json:"monsterType"`
+	Tile         protocol.TileAddress `json:"tile"`
+	HeroID       string               `json:"heroId"`
+	AttackRolls  []DiceRoll           `json:"attackRolls"`
+	DefenseRolls []DiceRoll           `json:"defenseRolls"`
+	Damage       int                  `json:"damage"`
+}
+
+// SpawnWanderingMonster places a quest's wandering monster next to the hero who drew the card,
+// or on the nearest free square, and has it attack that hero at once. The monster stays on the
+// board under GM control.
+func (ms *MonsterSystem) SpawnWanderingMonster(wanderer geometry.QuestMonster, heroID string) (*WanderingMonsterResult, error) {
+	hero := ms.findHeroPlayer(heroID)
+	if hero == nil || hero.Character == nil {
+		return nil, fmt.Errorf("hero %s not found", heroID)
+	}
+
+	ms.gameState.Lock.Lock()
+	heroPos, onBoard := ms.gameState.Entities[heroID]
+	ms.gameState.Lock.Unlock()
+	if !onBoard {
+		return nil, fmt.Errorf("hero %s is not on the board", heroID)
+	}
+
+	position, found := ms.nearestFreeSquare(heroPos)
+	if !found {
+		return nil, &GameError{Code: "no_space", Message: "no free square for the wandering monster"}
+	}
+
+	wanderer.X, wanderer.Y = position.X, position.Y
+	monster, err := ms.SpawnQuestMonster(wanderer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to spawn wandering monster: %w", err)
+	}
+	monster.IsVisible = true
+	ms.gameState.Lock.Lock()
+	ms.gameState.KnownMonsters[monster.ID] = true
+	ms.gameState.Lock.Unlock()
+	ms.broadcastMonsterUpdate(monster)
+
+	// The wandering monster attacks with its normal dice; the hero defends with white shields,
+	// changed by any artifacts they hold as against any other monster
+	character := hero.Character
+	defenseDice := character.GetEffectiveDefenseDice()
+	if ms.inventoryManager != nil {
+		defense := &ArtifactDefense{Monster: monster, DefenseDice: defenseDice}
+		ms.inventoryManager.ApplyArtifactDefense(heroID, defense)
+		defenseDice = defense.DefenseDice
+	}
+	result := &WanderingMonsterResult{
+		MonsterID:    monster.ID,
+		MonsterType:  monster.Type,
+		Tile:         position,
+		HeroID:       heroID,
+		AttackRolls:  ms.diceSystem.RollAttackDice(monster.AttackDice),
+		DefenseRolls: ms.diceSystem.RollDefenseDice(defenseDice),
+	}
+	result.Damage = CalculateHeroCombatDamage(result.AttackRolls, result.DefenseRolls)
+	if result.Damage > 0 {
+		character.TakeDamage(result.Damage)
+		character.IsAsleep = false
+	}
+
+	ms.logger.Printf("Wandering %s (%s) appeared at (%d,%d) and attacked %s: %d skulls vs %d shields = %d damage (%d/%d body)",
+		monster.DisplayName(), monster.ID, position.X, position.Y, heroID,
+		countSkulls(result.AttackRolls), countHeroShields(result.DefenseRolls), result.Damage,
+		character.CurrentBody, character.BaseStats.BodyPoints)
+
+	ms.broadcaster.BroadcastEvent("WanderingMonsterAttacked", protocol.WanderingMonsterAttacked{
+		MonsterID:   monster.ID,
+		MonsterType: string(monster.Type),
+		Tile:        position,
+		HeroID:      heroID,
+		Skulls:      countSkulls(result.AttackRolls),
+		Shields:     countHeroShields(result.DefenseRolls),
+		Damage:      result.Damage,
+		BodyLeft:    character.CurrentBody,
+	})
+
+	if result.Damage > 0 && ms.heroLifecycle != nil {
+		ms.heroLifecycle.CheckHero(heroID)
+	}
+	return result, nil
+}
+
+// findHeroPlayer returns the player controlling a hero entity
+func (ms *MonsterSystem) findHeroPlayer(heroID string) *Player {
+	if ms.turnManager == nil {
+		return nil
+	}
+	for _, player := range ms.turnManager.GetHeroPlayers() {
+		if player.EntityID == heroID {
+			return player
+		}
+	}
+	return nil
+}
+
+// nearestFreeSquare searches outward from a square, square by square through open edges,
+// for the closest square with nothing standing on it. Adjacent squares are tried first.
+func (ms *MonsterSystem) nearestFreeSquare(from protocol.TileAddress) (protocol.TileAddress, bool) {
+	type step struct{ dx, dy int }
+	steps := []step{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+
+	visited := map[protocol.TileAddress]bool{from: true}
+	queue := []protocol.TileAddress{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, s := range steps {
+			next := protocol.TileAddress{SegmentID: from.SegmentID, X: current.X + s.dx, Y: current.Y + s.dy}
+			if visited[next] || ms.edgeClosed(current, s.dx, s.dy) {
+				continue
+			}
+			visited[next] = true
+			if ms.validatePosition(next) != nil || !ms.onBoard(next) {
+				continue
+			}
+			if ms.isFreeSquare(next) {
+				return next, true
+			}
+			queue = append(queue, next)
+		}
+	}
+	return protocol.TileAddress{}, false
+}
+
+// edgeClosed reports whether a wall or closed door blocks a step from a square
+func (ms *MonsterSystem) edgeClosed(from protocol.TileAddress, dx, dy int) bool {
+	ms.gameState.Lock.Lock()
+	defer ms.gameState.Lock.Unlock()
+
+	return stepClosed(ms.gameState, from.X, from.Y, dx, dy)
+}
+
+// onBoard reports whether a square lies within the board (any square, if the board has no size yet)
+func (ms *MonsterSystem) onBoard(position protocol.TileAddress) bool {
+	ms.gameState.Lock.Lock()
+	defer ms.gameState.Lock.Unlock()
+
+	segment := ms.gameState.Segment
+	return segment.Width == 0 || (position.X < segment.Width && position.Y < segment.Height)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// createTestWanderingGround puts hero-1 on a 10x10 board with an orc wandering monster in content
+func createTestWanderingGround(t *testing.T) (*HeroActionSystem, *MonsterSystem) {
+	has := createTestHeroActionSystem()
+	has.gameState.Segment = geometry.Segment{Width: 10, Height: 10}
+	has.gameState.KnownMonsters = make(map[string]bool)
+
+	contentManager := NewContentManager(&MockLogger{})
+	contentManager.monsterCards["orc"] = &MonsterCard{ID: "orc", Name: "Orc", Stats: MonsterStats{MovementSquares: 8, AttackDice: 3, DefendDice: 2, BodyPoints: 1, MindPoints: 2}}
+
+	ms := NewMonsterSystem(has.gameState, has.turnManager, has.diceSystem, &MockBroadcaster{}, &MockLogger{})
+	ms.SetContentManager(contentManager)
+	has.SetMonsterSystem(ms)
+	return has, ms
+}
+
+func TestWanderingMonster_AppearsNextToHeroAndAttacks(t *testing.T) {
+	has, ms := createTestWanderingGround(t)
+	has.gameState.Entities["hero-2"] = protocol.TileAddress{X: 5, Y: 4}
+	has.debugSystem.SetDiceOverride("attack", 6)
+	has.debugSystem.SetDiceOverride("defense", 1)
+
+	result, err := ms.SpawnWanderingMonster(geometry.QuestMonster{Type: "orc"}, "hero-1")
+	if err != nil {
+		t.Fatalf("Expected the wandering monster to appear, got: %v", err)
+	}
+	if result.Tile != (protocol.TileAddress{X: 6, Y: 5}) {
+		t.Errorf("Expected the orc on the first free adjacent square (6,5), got (%d,%d)", result.Tile.X, result.Tile.Y)
+	}
+
+	if result.Damage != 3 || has.turnManager.GetPlayer("player-1").Character.CurrentBody != 5 {
+		t.Errorf("Expected 3 unblocked skulls to leave the barbarian on 5 body, got %d damage", result.Damage)
+	}
+
+	orc, err := ms.GetMonsterByID(result.MonsterID)
+	if err != nil || !orc.IsAlive || !orc.IsVisible || !has.gameState.KnownMonsters[orc.ID] {
+		t.Errorf("Expected the orc to stay on the board for the GM, got %+v (%v)", orc, err)
+	}
+}
+
+func TestWanderingMonster_UsesQuestStatsAndNearestFreeSquare(t *testing.T) {
+	has, ms := createTestWanderingGround(t)
+	has.gameState.Entities["hero-2"] = protocol.TileAddress{X: 5, Y: 4}
+	has.gameState.Entities["hero-3"] = protocol.TileAddress{X: 6, Y: 5}
+	has.gameState.Entities["hero-4"] = protocol.TileAddress{X: 5, Y: 6}
+	has.gameState.Entities["monster-2"] = protocol.TileAddress{X: 4, Y: 5}
+	has.debugSystem.SetDiceOverride("attack", 1)
+
+	result, err := ms.SpawnWanderingMonster(geometry.QuestMonster{Type: "orc", Overrides: &geometry.MonsterOverrides{Attack: intPtr(5)}}, "hero-1")
+	if err != nil {
+		t.Fatalf("Expected the wandering monster to appear, got: %v", err)
+	}
+	if distance := absInt(result.Tile.X-5) + absInt(result.Tile.Y-5); distance != 2 {
+		t.Errorf("Expected the orc two squares away when the hero is surrounded, got (%d,%d)", result.Tile.X, result.Tile.Y)
+	}
+	if len(result.AttackRolls) != 5 || result.Damage != 0 {
+		t.Errorf("Expected the quest's 5 attack dice all missing, got %d dice and %d damage", len(result.AttackRolls), result.Damage)
+	}
+}
+
+func TestWanderingMonster_HeroDefendsWithArtifacts(t *testing.T) {
+	has, ms := createTestWanderingGround(t)
+	inventoryManager := NewInventoryManager(NewContentManager(&MockLogger{}), &MockLogger{})
+	inventoryManager.InitializeHeroInventory("hero-1")
+	inventoryManager.RegisterHero(has.turnManager.GetPlayer("player-1"))
+	inventoryManager.GiveItems("hero-1", []*ItemCard{{ID: "orc_ward", Name: "Orc Ward", Type: "artifact",
+		Effect: &EffectDefinition{Type: ArtifactEffectStatBonus, Data: map[string]any{"defense_dice": float64(3), "against": []any{"orc"}}}}}, 0)
+	ms.SetInventoryManager(inventoryManager)
+
+	result, err := ms.SpawnWanderingMonster(geometry.QuestMonster{Type: "orc"}, "hero-1")
+	if err != nil {
+		t.Fatalf("Expected the wandering monster to appear, got: %v", err)
+	}
+	if len(result.DefenseRolls) != 5 {
+		t.Errorf("Expected the ward to add 3 defense dice to the barbarian's 2, got %d", len(result.DefenseRolls))
+	}
+}
+
+func TestWanderingMonster_CardReturnsToDeck(t *testing.T) {
+	card := &TreasureCard{ID: "wandering_monster", Name: "Wandering Monster", Type: "monster", ReturnToDeck: true}
+	deck := NewTreasureDeckManager(NewContentManager(&MockLogger{}), &MockLogger{})
+	deck.deck = []*TreasureCard{card}
+
+	quest := &geometry.QuestDefinition{WanderingMonster: "orc", WanderingMonsterStats: &geometry.MonsterOverrides{Body: intPtr(2)}}
+	resolver := NewTreasureResolver(NewContentManager(&MockLogger{}), deck, quest, &MockLogger{})
+
+	result, err := resolver.ResolveTreasureSearch("hero-1", 1, protocol.TileAddress{X: 5, Y: 5}, "")
+	if err != nil {
+		t.Fatalf("Expected a card to be drawn, got: %v", err)
+	}
+	if !result.IsMonster || result.MonsterType != "orc" || result.MonsterOverrides == nil {
+		t.Errorf("Expected the quest's wandering orc, got %+v", result)
+	}
+	if deck.GetDeckSize() != 1 || deck.GetDiscardSize() != 0 {
+		t.Errorf("Expected the card back in the deck, got %d in deck and %d discarded", deck.GetDeckSize(), deck.GetDiscardSize())
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// checkAttackReach checks whether a hero's weapon reaches a target square.
+// Without a weapon, or with a melee weapon, only orthogonally adjacent squares are in reach;
+// weapons with AttackDiagonal (staffs, spears) also reach the diagonals. No melee strike goes
+// through a wall or closed door. Ranged weapons and
+// thrown weapons hit any square in line of sight within their Range (0 = unlimited); ranged
+// weapons cannot be used on adjacent targets unless they also have AttackAdjacent.
+func (has *HeroActionSystem) checkAttackReach(weapon *ItemCard, from, to protocol.TileAddress, thrown bool) error {
+	dx, dy := absInt(to.X-from.X), absInt(to.Y-from.Y)
+	adjacent := dx <= 1 && dy <= 1 && dx+dy > 0
+
+	if thrown {
+		if weapon == nil || !weapon.Throwable {
+			return &GameError{Code: "not_throwable", Message: "the equipped weapon cannot be thrown"}
+		}
+		return has.checkRangedReach(weapon, from, to)
+	}
+
+	// A dual-mode weapon strikes out of melee reach in its ranged mode
+	if ranged := artifactRangedMode(weapon); ranged != nil && !weapon.Ranged && !adjacent {
+		return has.checkRangedReach(ranged, from, to)
+	}
+
+	if weapon != nil && weapon.Ranged {
+		if adjacent && !weapon.AttackAdjacent {
+			return &GameError{Code: "target_too_close", Message: fmt.Sprintf("%s cannot be used on an adjacent target", weapon.Name)}
+		}
+		if !adjacent {
+			return has.checkRangedReach(weapon, from, to)
+		}
+	}
+
+	if dx+dy == 1 || (dx == 1 && dy == 1 && weapon != nil && weapon.AttackDiagonal) {
+		has.gameState.Lock.Lock()
+		open := adjacentOpen(has.gameState, from, to)
+		has.gameState.Lock.Unlock()
+		if open {
+			return nil
+		}
+		return &GameError{Code: "target_blocked", Message: fmt.Sprintf("a wall or closed door stands between the hero and (%d,%d)", to.X, to.Y)}
+	}
+	return &GameError{Code: "target_out_of_reach", Message: fmt.Sprintf("target at (%d,%d) is out of reach", to.X, to.Y)}
+}
+
+// checkRangedReach checks the weapon's range and the line of sight to the target
+func (has *HeroActionSystem) checkRangedReach(weapon *ItemCard, from, to protocol.TileAddress) error {
+	if weapon.Range > 0 && max(absInt(to.X-from.X), absInt(to.Y-from.Y)) > weapon.Range {
+		return &GameError{Code: "target_out_of_range", Message: fmt.Sprintf("%s reaches only %d squares", weapon.Name, weapon.Range)}
+	}
+	if has.rules.checksLineOfSight() && !has.visibility.IsTileCenterVisible(has.gameState, from.X, from.Y, to.X, to.Y) {
+		return &GameError{Code: "no_line_of_sight", Message: fmt.Sprintf("target at (%d,%d) is not in line of sight", to.X, to.Y)}
+	}
+	return nil
+}
+
+// dropThrownWeapon leaves a thrown weapon on the target's square for pickup
+func (has *HeroActionSystem) dropThrownWeapon(heroID string, weapon *ItemCard, tile protocol.TileAddress) {
+	if _, err := has.inventoryManager.DropItem(heroID, weapon.ID, tile); err != nil {
+		has.logger.Printf("Warning: failed to drop thrown %s: %v", weapon.ID, err)
+		return
+	}
+	has.broadcastGroundItems(tile)
+	if inventory, err := has.inventoryManager.GetInventory(heroID); err == nil {
+		has.broadcaster.BroadcastEvent("InventoryChanged", inventoryToLite(inventory))
+	}
+}
+
+// broadcastGroundItems announces what now lies on a tile
+func (has *HeroActionSystem) broadcastGroundItems(tile protocol.TileAddress) {
+	patch := protocol.GroundItemsChanged{Tile: groundTile(tile), Items: []protocol.InventoryItemLite{}}
+	for _, pile := range has.inventoryManager.GetGroundItems() {
+		if pile.Tile == patch.Tile {
+			patch.Items = pile.Items
+		}
+	}
+	has.broadcaster.BroadcastEvent("GroundItemsChanged", patch)
+}
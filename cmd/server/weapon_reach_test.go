@@ -0,0 +1,122 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/geometry"
+	"github.com/Ko-stant/dungeon-campaign-engine/internal/protocol"
+)
+
+// createTestArmedHero equips hero-1 with a weapon and stands the goblin at the given square
+func createTestArmedHero(t *testing.T, weapon *ItemCard, goblinAt protocol.TileAddress) *HeroActionSystem {
+	has := createTestItemUser(t, []*ItemCard{weapon}, nil)
+	if err := has.inventoryManager.EquipItem("hero-1", weapon.ID); err != nil {
+		t.Fatalf("Failed to equip %s: %v", weapon.ID, err)
+	}
+	goblin, _ := has.monsterSystem.GetMonsterByID("monster-1")
+	goblin.Position = goblinAt
+	return has
+}
+
+func attackRequest(params map[string]any) ActionRequest {
+	params["targetId"] = "monster-1"
+	return ActionRequest{PlayerID: "player-1", EntityID: "hero-1", Action: AttackAction, Parameters: params}
+}
+
+func TestWeaponReach_DiagonalNeedsStaffOrSpear(t *testing.T) {
+	diagonal := protocol.TileAddress{X: 6, Y: 6}
+
+	has := createTestArmedHero(t, &ItemCard{ID: "shortsword", Name: "Shortsword", Type: "weapon", AttackDice: 2}, diagonal)
+	_, err := has.ProcessAction(attackRequest(map[string]any{}))
+	expectGameErrorCode(t, err, "target_out_of_reach")
+	if has.turnManager.GetTurnState().ActionTaken {
+		t.Error("Expected an illegal attack not to consume the action")
+	}
+
+	has = createTestArmedHero(t, &ItemCard{ID: "staff", Name: "Staff", Type: "weapon", AttackDice: 1, AttackDiagonal: true}, diagonal)
+	if _, err := has.ProcessAction(attackRequest(map[string]any{})); err != nil {
+		t.Errorf("Expected the staff to reach the diagonal, got: %v", err)
+	}
+}
+
+func TestWeaponReach_CrossbowNeedsLineOfSightAndDistance(t *testing.T) {
+	has := createTestArmedHero(t, &ItemCard{ID: "crossbow", Name: "Crossbow", Type: "weapon", AttackDice: 3, Ranged: true}, protocol.TileAddress{X: 5, Y: 6})
+	_, err := has.ProcessAction(attackRequest(map[string]any{}))
+	expectGameErrorCode(t, err, "target_too_close")
+
+	goblin, _ := has.monsterSystem.GetMonsterByID("monster-1")
+	goblin.Position = protocol.TileAddress{X: 5, Y: 9}
+	has.gameState.BlockedWalls = map[geometry.EdgeAddress]bool{
+		{X: 5, Y: 8, Orientation: geometry.Horizontal}: true,
+	}
+	_, err = has.ProcessAction(attackRequest(map[string]any{}))
+	expectGameErrorCode(t, err, "no_line_of_sight")
+
+	has.gameState.BlockedWalls = nil
+	if _, err := has.ProcessAction(attackRequest(map[string]any{})); err != nil {
+		t.Errorf("Expected the crossbow to hit a monster in line of sight, got: %v", err)
+	}
+}
+
+func TestWeaponReach_ThrownDaggerLandsForPickup(t *testing.T) {
+	has := createTestArmedHero(t, &ItemCard{ID: "dagger", Name: "Dagger", Type: "weapon", AttackDice: 1, Throwable: true, Range: 4}, protocol.TileAddress{X: 5, Y: 8})
+
+	result, err := has.ProcessAction(attackRequest(map[string]any{"thrown": true}))
+	if err != nil {
+		t.Fatalf("Expected the dagger to be thrown, got: %v", err)
+	}
+	if result.ThrownItem != "dagger" || has.inventoryManager.HasItem("hero-1", "dagger") {
+		t.Fatal("Expected the thrown dagger to leave hero-1's inventory")
+	}
+	piles := has.inventoryManager.GetGroundItems()
+	if len(piles) != 1 || piles[0].Tile != (protocol.TileAddress{X: 5, Y: 8}) || piles[0].Items[0].ID != "dagger" {
+		t.Fatalf("Expected the dagger on the goblin's square, got %+v", piles)
+	}
+
+	pickUp := InstantActionRequest{PlayerID: "player-1", EntityID: "hero-1", Action: PickUpItemInstant, Parameters: map[string]any{"x": float64(5), "y": float64(8)}}
+	_, err = has.ProcessInstantAction(pickUp)
+	expectGameErrorCode(t, err, "not_adjacent")
+
+	// Next to the dagger, but with a wall between
+	has.gameState.Entities["hero-1"] = protocol.TileAddress{X: 5, Y: 7}
+	has.gameState.BlockedWalls = map[geometry.EdgeAddress]bool{
+		{X: 5, Y: 8, Orientation: geometry.Horizontal}: true,
+	}
+	_, err = has.ProcessInstantAction(pickUp)
+	expectGameErrorCode(t, err, "not_adjacent")
+
+	has.gameState.BlockedWalls = nil
+	if _, err := has.ProcessInstantAction(pickUp); err != nil {
+		t.Fatalf("Expected the dagger to be picked up, got: %v", err)
+	}
+	if !has.inventoryManager.HasItem("hero-1", "dagger") || len(has.inventoryManager.GetGroundItems()) != 0 {
+		t.Error("Expected the dagger back in hero-1's inventory")
+	}
+}
+
+func TestWeaponReach_MeleeBlockedByWallsAndClosedDoors(t *testing.T) {
+	has := createTestArmedHero(t, &ItemCard{ID: "shortsword", Name: "Shortsword", Type: "weapon", AttackDice: 2}, protocol.TileAddress{X: 5, Y: 6})
+	doorway := geometry.EdgeAddress{X: 5, Y: 6, Orientation: geometry.Horizontal}
+	has.gameState.Doors["door-1"] = &DoorInfo{Edge: doorway, State: "closed"}
+	has.gameState.DoorByEdge = map[geometry.EdgeAddress]string{doorway: "door-1"}
+
+	_, err := has.ProcessAction(attackRequest(map[string]any{}))
+	expectGameErrorCode(t, err, "target_blocked")
+	if has.turnManager.GetTurnState().ActionTaken {
+		t.Error("Expected a blocked attack not to consume the action")
+	}
+
+	has.gameState.Doors["door-1"].State = "open"
+	if _, err := has.ProcessAction(attackRequest(map[string]any{})); err != nil {
+		t.Errorf("Expected the hero to strike through the open door, got: %v", err)
+	}
+
+	// A staff cannot reach a diagonal walled off on both corners
+	has = createTestArmedHero(t, &ItemCard{ID: "staff", Name: "Staff", Type: "weapon", AttackDice: 1, AttackDiagonal: true}, protocol.TileAddress{X: 6, Y: 6})
+	has.gameState.BlockedWalls = map[geometry.EdgeAddress]bool{
+		{X: 6, Y: 5, Orientation: geometry.Vertical}:   true,
+		{X: 5, Y: 6, Orientation: geometry.Horizontal}: true,
+	}
+	_, err = has.ProcessAction(attackRequest(map[string]any{}))
+	expectGameErrorCode(t, err, "target_blocked")
+}
@@ -17,6 +17,15 @@ type QuestDoor struct {
 	Notes       string `json:"notes"`
 }
 
+// QuestSecretDoor represents a door hidden in a wall until a hero searches for it
+type QuestSecretDoor struct {
+	ID          string `json:"id"`
+	X           int    `json:"x"`
+	Y           int    `json:"y"`
+	Orientation string `json:"orientation"`
+	Notes       string `json:"notes"`
+}
+
 // QuestBlockingWall represents a wall that blocks corridor access
 type QuestBlockingWall struct {
 	ID          string `json:"id"`
@@ -29,12 +38,44 @@ type QuestBlockingWall struct {
 
 // QuestMonster represents a monster placement
 type QuestMonster struct {
-	ID    string `json:"id"`
-	Type  string `json:"type"`
-	X     int    `json:"x"`
-	Y     int    `json:"y"`
-	Room  int    `json:"room"`
-	Notes string `json:"notes"`
+	ID          string            `json:"id"`
+	Type        string            `json:"type"`
+	X           int               `json:"x"`
+	Y           int               `json:"y"`
+	Room        int               `json:"room"`
+	Notes       string            `json:"notes"`
+	DreadSpells []QuestDreadSpell `json:"dread_spells,omitempty"`
+	Name        string            `json:"name,omitempty"`      // Display name for a named monster, e.g. "Verag the Gargoyle"
+	Overrides   *MonsterOverrides `json:"overrides,omitempty"` // Stats replacing the monster card's
+}
+
+// MonsterOverrides replaces a quest monster's card stats; unset stats keep the card's value
+type MonsterOverrides struct {
+	Body     *int `json:"body,omitempty"`
+	Mind     *int `json:"mind,omitempty"`
+	Attack   *int `json:"attack,omitempty"`
+	Defense  *int `json:"defense,omitempty"`
+	Movement *int `json:"movement,omitempty"`
+}
+
+// QuestDreadSpell assigns a dread spell to a quest monster
+type QuestDreadSpell struct {
+	ID   string `json:"id"`
+	Uses int    `json:"uses,omitempty"` // Casts allowed this quest (default 1)
+}
+
+// QuestTrap represents a trap placement. Pit and chest traps deal Damage body points
+// (default 1); spear and falling block traps roll Damage combat dice (default 1 and 3).
+type QuestTrap struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"` // "pit", "spear", "falling_block", "chest"
+	X           int    `json:"x"`
+	Y           int    `json:"y"`
+	Room        int    `json:"room"`
+	FurnitureID string `json:"furniture_id,omitempty"` // Chest traps: the furniture they guard
+	Damage      int    `json:"damage,omitempty"`
+	Revealed    bool   `json:"revealed,omitempty"` // Visible to every hero from the start
+	Notes       string `json:"notes"`
 }
 
 // QuestFurniture represents furniture placement
@@ -51,11 +92,35 @@ type QuestFurniture struct {
 	Notes              string   `json:"notes"`
 }
 
-// QuestObjective represents a quest objective
+// QuestObjective represents a quest objective. Objectives of type "all" and "any" combine
+// the objectives nested in them.
 type QuestObjective struct {
-	Type        string `json:"type"`
-	Target      string `json:"target"`
-	Description string `json:"description"`
+	Type        string           `json:"type"`
+	Target      string           `json:"target"`
+	Description string           `json:"description"`
+	Objectives  []QuestObjective `json:"objectives,omitempty"`
+}
+
+// QuestTrigger fires its actions when a quest event happens: a hero entering a room, a door
+// opening, a monster dying, a hero searching furniture or a turn cycle starting
+type QuestTrigger struct {
+	ID         string               `json:"id"`
+	Event      string               `json:"event"`                // "on_room_entered", "on_door_opened", "on_monster_killed", "on_furniture_searched", "on_turn"
+	Target     string               `json:"target,omitempty"`     // Room number, door ID, monster ID, furniture ID or turn cycle; empty matches any
+	Repeatable bool                 `json:"repeatable,omitempty"` // Fires on every matching event rather than the first only; on_turn fires every Target cycles
+	Actions    []QuestTriggerAction `json:"actions"`
+}
+
+// QuestTriggerAction is one thing a trigger does when it fires
+type QuestTriggerAction struct {
+	Type     string           `json:"type"`               // "spawn_monster", "reveal_text", "open_door", "lock_door", "grant_item", "modify_monster", "end_quest"
+	Text     string           `json:"text,omitempty"`     // reveal_text: shown to every player; end_quest: the reason
+	Monster  *QuestMonster    `json:"monster,omitempty"`  // spawn_monster
+	DoorID   string           `json:"door_id,omitempty"`  // open_door, lock_door: quest door ID
+	Items    []ItemReference  `json:"items,omitempty"`    // grant_item: given to the hero who set the trigger off
+	Gold     int              `json:"gold,omitempty"`     // grant_item
+	Modifier *MonsterModifier `json:"modifier,omitempty"` // modify_monster
+	Result   string           `json:"result,omitempty"`   // end_quest: "victory" (default) or "defeat"
 }
 
 // QuestSpecialRules represents special quest rules
@@ -98,19 +163,23 @@ type MonsterModifier struct {
 
 // QuestDefinition represents the complete quest configuration
 type QuestDefinition struct {
-	ID               string                        `json:"id"`
-	Name             string                        `json:"name"`
-	Description      string                        `json:"description"`
-	Difficulty       string                        `json:"difficulty"`
-	StartingRoom     int                           `json:"starting_room"`
-	WanderingMonster string                        `json:"wandering_monster"`
-	SpecialRules     QuestSpecialRules             `json:"special_rules"`
-	Doors            []QuestDoor                   `json:"doors"`
-	BlockingWalls    []QuestBlockingWall           `json:"blocking_walls"`
-	Monsters         []QuestMonster                `json:"monsters"`
-	Furniture        []QuestFurniture              `json:"furniture"`
-	Objectives       []QuestObjective              `json:"objectives"`
-	QuestNotes       map[string]*QuestTreasureNote `json:"quest_notes,omitempty"`
+	ID                    string                        `json:"id"`
+	Name                  string                        `json:"name"`
+	Description           string                        `json:"description"`
+	Difficulty            string                        `json:"difficulty"`
+	StartingRoom          int                           `json:"starting_room"`
+	WanderingMonster      string                        `json:"wandering_monster"`
+	WanderingMonsterStats *MonsterOverrides             `json:"wandering_monster_stats,omitempty"`
+	SpecialRules          QuestSpecialRules             `json:"special_rules"`
+	Doors                 []QuestDoor                   `json:"doors"`
+	SecretDoors           []QuestSecretDoor             `json:"secret_doors,omitempty"`
+	BlockingWalls         []QuestBlockingWall           `json:"blocking_walls"`
+	Monsters              []QuestMonster                `json:"monsters"`
+	Furniture             []QuestFurniture              `json:"furniture"`
+	Traps                 []QuestTrap                   `json:"traps,omitempty"`
+	Objectives            []QuestObjective              `json:"objectives"`
+	QuestNotes            map[string]*QuestTreasureNote `json:"quest_notes,omitempty"`
+	Triggers              []QuestTrigger                `json:"triggers,omitempty"`
 }
 
 // LoadQuestFromFile loads a quest definition from a JSON file
@@ -146,6 +215,20 @@ func ConvertQuestDoorsToEdges(doors []QuestDoor) []EdgeAddress {
 	return edges
 }
 
+// ConvertQuestSecretDoorToEdge converts a quest secret door to the wall edge it hides in
+func ConvertQuestSecretDoorToEdge(door QuestSecretDoor) EdgeAddress {
+	orientation := Vertical
+	if door.Orientation == "horizontal" {
+		orientation = Horizontal
+	}
+
+	return EdgeAddress{
+		X:           door.X,
+		Y:           door.Y,
+		Orientation: orientation,
+	}
+}
+
 // ConvertQuestBlockingWallsToEdges converts quest blocking walls to EdgeAddress structures
 func ConvertQuestBlockingWallsToEdges(walls []QuestBlockingWall) []EdgeAddress {
 	var edges []EdgeAddress
@@ -37,11 +37,19 @@ type RequestToggleReady struct {
 type RequestStartGame struct {
 }
 
+type RequestSetHouseRules struct {
+	Rules HouseRules `json:"rules"`
+}
+
 type RequestSelectStartingPosition struct {
 	X int `json:"x"`
 	Y int `json:"y"`
 }
 
+type RequestChooseSpellSchool struct {
+	Element string `json:"element"`
+}
+
 type RequestElectSelfAsNextPlayer struct {
 }
 
@@ -72,6 +80,14 @@ type RequestMonsterAttack struct {
 	TargetID  string `json:"targetId"`
 }
 
+type RequestRevealMonster struct {
+	MonsterID string `json:"monsterId"`
+}
+
+type RequestRollDefense struct {
+	AttackID string `json:"attackId"` // The declared monster attack the hero defends against
+}
+
 type RequestUseMonsterAbility struct {
 	MonsterID string `json:"monsterId"`
 	AbilityID string `json:"abilityId"`
@@ -79,3 +95,44 @@ type RequestUseMonsterAbility struct {
 	TargetX   *int   `json:"targetX,omitempty"`
 	TargetY   *int   `json:"targetY,omitempty"`
 }
+
+type RequestProposeTrade struct {
+	TargetEntityID string   `json:"targetEntityId"`
+	GiveItems      []string `json:"giveItems,omitempty"`
+	GiveGold       int      `json:"giveGold,omitempty"`
+	RequestItems   []string `json:"requestItems,omitempty"`
+	RequestGold    int      `json:"requestGold,omitempty"`
+}
+
+type RequestRespondToTrade struct {
+	TradeID string   `json:"tradeId"`
+	Accept  bool     `json:"accept"`
+	Equip   []string `json:"equip,omitempty"` // Received items to equip straight away
+}
+
+type RequestLootCorpse struct {
+	HeroID string `json:"heroId"` // The dead hero whose items are recovered
+}
+
+type RequestSaveSession struct {
+	Slot string `json:"slot"`
+}
+
+type RequestLoadSession struct {
+	Slot string `json:"slot"`
+}
+
+type RequestListSessions struct {
+}
+
+type RequestAdvanceQuest struct {
+	QuestID string `json:"questId,omitempty"` // The GM's pick; empty for the next quest the party has not completed
+}
+
+type RequestBuyItem struct {
+	ItemID string `json:"itemId"` // Equipment card bought at the armoury
+}
+
+type RequestSellItem struct {
+	ItemID string `json:"itemId"` // Carried or equipped item sold back for half its cost
+}
@@ -96,6 +96,7 @@ type LobbyStateChanged struct {
 	CanStartGame    bool                        `json:"canStartGame"`
 	GameStarted     bool                        `json:"gameStarted"`
 	AvailableHeroes []string                    `json:"availableHeroes"`
+	HouseRules      HouseRules                  `json:"houseRules"`
 }
 
 type PlayerLobbyInfo struct {
@@ -134,6 +135,189 @@ type StartPositionInfo struct {
 	Y int `json:"y"`
 }
 
+type SpellSelectionStateChanged struct {
+	Elements     []string          `json:"elements"`
+	Schools      map[string]string `json:"schools"` // element -> playerId
+	NextPlayerID string            `json:"nextPlayerId,omitempty"`
+	Complete     bool              `json:"complete"`
+}
+
+type DreadSpellCast struct {
+	MonsterID  string   `json:"monsterId"`
+	SpellID    string   `json:"spellId"`
+	SpellName  string   `json:"spellName"`
+	TargetID   string   `json:"targetId,omitempty"`
+	Effect     string   `json:"effect"`
+	Value      int      `json:"value"`
+	Resisted   bool     `json:"resisted"`
+	Summoned   []string `json:"summoned,omitempty"`
+	UsesLeft   int      `json:"usesLeft"`
+	TargetBody int      `json:"targetBody,omitempty"`
+	TargetMind int      `json:"targetMind,omitempty"`
+}
+
+type TrapLite struct {
+	ID          string      `json:"id"`
+	Type        string      `json:"type"`
+	Tile        TileAddress `json:"tile"`
+	FurnitureID string      `json:"furnitureId,omitempty"`
+}
+
+type TrapsRevealed struct {
+	EntityID string     `json:"entityId"` // Hero who found them
+	Traps    []TrapLite `json:"traps"`
+}
+
+type TrapTriggered struct {
+	TrapID   string      `json:"trapId"`
+	TrapType string      `json:"trapType"`
+	EntityID string      `json:"entityId"`
+	Tile     TileAddress `json:"tile"`
+	Damage   int         `json:"damage"`
+	BodyLeft int         `json:"bodyLeft"`
+}
+
+type HazardTriggered struct {
+	CardID   string `json:"cardId"`
+	Name     string `json:"name"`
+	HeroID   string `json:"heroId"`
+	Damage   int    `json:"damage"`
+	BodyLeft int    `json:"bodyLeft"`
+	EndTurn  bool   `json:"endTurn"`
+	Mechanic string `json:"mechanic,omitempty"`
+	GoldLost int    `json:"goldLost,omitempty"`
+}
+
+type WanderingMonsterAttacked struct {
+	MonsterID   string      `json:"monsterId"`
+	MonsterType string      `json:"monsterType"`
+	Tile        TileAddress `json:"tile"`
+	HeroID      string      `json:"heroId"`
+	Skulls      int         `json:"skulls"`
+	Shields     int         `json:"shields"`
+	Damage      int         `json:"damage"`
+	BodyLeft    int         `json:"bodyLeft"`
+}
+
+type MonsterAttackDeclared struct {
+	AttackID    string   `json:"attackId"`
+	MonsterID   string   `json:"monsterId"`
+	HeroID      string   `json:"heroId"`
+	AttackFaces []string `json:"attackFaces"` // "skull", "white_shield" or "black_shield" per die
+	Skulls      int      `json:"skulls"`
+}
+
+type HeroDamaged struct {
+	AttackID     string   `json:"attackId,omitempty"`
+	MonsterID    string   `json:"monsterId"`
+	HeroID       string   `json:"heroId"`
+	AttackFaces  []string `json:"attackFaces"`
+	DefenseFaces []string `json:"defenseFaces"`
+	Skulls       int      `json:"skulls"`
+	Shields      int      `json:"shields"`
+	Damage       int      `json:"damage"`
+	BodyLeft     int      `json:"bodyLeft"`
+}
+
+type TrapDisarmed struct {
+	TrapID   string `json:"trapId"`
+	EntityID string `json:"entityId"`
+}
+
+type TradeProposed struct {
+	TradeID      string   `json:"tradeId"`
+	FromHeroID   string   `json:"fromHeroId"`
+	ToHeroID     string   `json:"toHeroId"`
+	GiveItems    []string `json:"giveItems,omitempty"`
+	GiveGold     int      `json:"giveGold,omitempty"`
+	RequestItems []string `json:"requestItems,omitempty"`
+	RequestGold  int      `json:"requestGold,omitempty"`
+}
+
+type TradeCompleted struct {
+	TradeID    string `json:"tradeId"`
+	FromHeroID string `json:"fromHeroId"`
+	ToHeroID   string `json:"toHeroId"`
+}
+
+type TradeDeclined struct {
+	TradeID string `json:"tradeId"`
+	HeroID  string `json:"heroId"`
+}
+
+type InventoryItemLite struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type InventoryChanged struct {
+	HeroID    string                       `json:"heroId"`
+	Gold      int                          `json:"gold"`
+	Equipment map[string]InventoryItemLite `json:"equipment"`
+	Carried   []InventoryItemLite          `json:"carried"`
+}
+
+type HeroDying struct {
+	HeroID         string      `json:"heroId"`
+	Tile           TileAddress `json:"tile"`
+	DiesAfterCycle int         `json:"diesAfterCycle"` // Dies when this cycle's GM phase ends unless rescued
+}
+
+type HeroRescued struct {
+	HeroID      string `json:"heroId"`
+	CurrentBody int    `json:"currentBody"`
+}
+
+type HeroDied struct {
+	HeroID  string              `json:"heroId"`
+	Tile    TileAddress         `json:"tile"`
+	ItemsTo string              `json:"itemsTo"` // "corpse" or "gm"
+	Items   []InventoryItemLite `json:"items"`
+	Gold    int                 `json:"gold"`
+}
+
+type CorpseLooted struct {
+	HeroID   string              `json:"heroId"` // The fallen hero
+	LooterID string              `json:"looterId"`
+	Items    []InventoryItemLite `json:"items"`
+	Gold     int                 `json:"gold"`
+}
+
+type GroundItemsChanged struct {
+	Tile  TileAddress         `json:"tile"`
+	Items []InventoryItemLite `json:"items"` // Everything now lying on the tile; empty once picked up
+}
+
+type QuestEnded struct {
+	Result  string        `json:"result"` // "victory" or "defeat"
+	Reason  string        `json:"reason"`
+	Cycle   int           `json:"cycle"`
+	Summary *QuestSummary `json:"summary,omitempty"`
+}
+
+// QuestSummary recaps a finished quest for the end-of-quest screen
+type QuestSummary struct {
+	Objectives     []QuestObjectiveLite `json:"objectives"`
+	MonstersKilled int                  `json:"monstersKilled"`
+	Cycles         int                  `json:"cycles"`
+}
+
+type QuestProgressChanged struct {
+	Objectives []QuestObjectiveLite `json:"objectives"`
+}
+
+type ItemUsed struct {
+	EntityID string `json:"entityId"`
+	TargetID string `json:"targetId"` // Hero the item was used on; Body and Mind are theirs
+	ItemID   string `json:"itemId"`
+	ItemName string `json:"itemName"`
+	Effect   string `json:"effect"`
+	Consumed bool   `json:"consumed"`
+	UsesLeft int    `json:"usesLeft"`
+	Body     int    `json:"body"`
+	Mind     int    `json:"mind"`
+}
+
 type MonsterTurnStateChanged struct {
 	MonsterID            string               `json:"monsterId"`
 	EntityID             string               `json:"entityId"`
@@ -174,3 +358,49 @@ type MonsterSelectionChanged struct {
 type AllMonsterStatesSync struct {
 	MonsterStates map[string]*MonsterTurnStateChanged `json:"monsterStates"`
 }
+
+type SessionSlotLite struct {
+	Slot        string `json:"slot"`
+	QuestID     string `json:"questId,omitempty"`
+	CycleNumber int    `json:"cycleNumber"`
+	TurnPhase   string `json:"turnPhase"`
+	SavedAt     string `json:"savedAt"`
+}
+
+type SessionSaved struct {
+	Session SessionSlotLite `json:"session"`
+}
+
+type SessionList struct {
+	Sessions []SessionSlotLite `json:"sessions"`
+}
+
+type SessionLoaded struct {
+	Slot        string `json:"slot"`
+	CycleNumber int    `json:"cycleNumber"`
+	TurnPhase   string `json:"turnPhase"`
+}
+
+// QuestStarted tells clients the campaign moved on to another quest; they re-fetch their snapshot
+type QuestStarted struct {
+	QuestID  string           `json:"questId"`
+	Name     string           `json:"name"`
+	Campaign CampaignProgress `json:"campaign"`
+}
+
+// QuestTriggerFired reports a quest trigger going off, with any text revealed to the players
+type QuestTriggerFired struct {
+	TriggerID string   `json:"triggerId"`
+	Event     string   `json:"event"`
+	HeroID    string   `json:"heroId,omitempty"` // Hero who set the trigger off, if any
+	Messages  []string `json:"messages,omitempty"`
+}
+
+// ArmouryTransaction reports an item bought or sold between quests
+type ArmouryTransaction struct {
+	HeroID   string `json:"heroId"`
+	ItemID   string `json:"itemId"`
+	ItemName string `json:"itemName"`
+	Sold     bool   `json:"sold"` // Sold back rather than bought
+	Gold     int    `json:"gold"` // Price paid, or gold received for the sale
+}
@@ -58,17 +58,20 @@ type FurnitureLite struct {
 }
 
 type MonsterLite struct {
-	ID          string      `json:"id"`
-	Type        string      `json:"type"`
-	Tile        TileAddress `json:"tile"`
-	Body        int         `json:"body"`
-	MaxBody     int         `json:"maxBody"`
-	Mind        int         `json:"mind"`
-	MaxMind     int         `json:"maxMind"`
-	AttackDice  int         `json:"attackDice"`
-	DefenseDice int         `json:"defenseDice"`
-	IsVisible   bool        `json:"isVisible"`
-	IsAlive     bool        `json:"isAlive"`
+	ID            string         `json:"id"`
+	Type          string         `json:"type"`
+	Name          string         `json:"name,omitempty"`
+	Tile          TileAddress    `json:"tile"`
+	Body          int            `json:"body"`
+	MaxBody       int            `json:"maxBody"`
+	Mind          int            `json:"mind"`
+	MaxMind       int            `json:"maxMind"`
+	AttackDice    int            `json:"attackDice"`
+	DefenseDice   int            `json:"defenseDice"`
+	MovementRange int            `json:"movementRange,omitempty"`
+	Overrides     map[string]int `json:"overrides,omitempty"` // Quest stats differing from the monster card
+	IsVisible     bool           `json:"isVisible"`
+	IsAlive       bool           `json:"isAlive"`
 }
 
 type HeroTurnStateLite struct {
@@ -92,6 +95,84 @@ type HeroTurnStateLite struct {
 	CurrentPosition     TileAddress                      `json:"currentPosition"`
 }
 
+// QuestObjectiveLite is a quest objective and how far the heroes are with it
+type QuestObjectiveLite struct {
+	Type        string               `json:"type"`
+	Target      string               `json:"target,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Met         bool                 `json:"met"`
+	Progress    string               `json:"progress,omitempty"`   // e.g. "3/5" turns survived
+	Objectives  []QuestObjectiveLite `json:"objectives,omitempty"` // Parts of an "all" or "any" objective
+}
+
+// HeroStatsLite is a hero's current stats, with attack and defense derived from their equipment
+type HeroStatsLite struct {
+	HeroID       string            `json:"heroId"`
+	Class        string            `json:"class"`
+	BodyPoints   int               `json:"bodyPoints"`
+	CurrentBody  int               `json:"currentBody"`
+	MindPoints   int               `json:"mindPoints"`
+	CurrentMind  int               `json:"currentMind"`
+	AttackDice   int               `json:"attackDice"`
+	DefenseDice  int               `json:"defenseDice"`
+	MovementDice int               `json:"movementDice"`
+	Equipment    map[string]string `json:"equipment,omitempty"` // slot -> equipped item ID
+	Condition    string            `json:"condition,omitempty"` // "dying" or "dead"
+}
+
+// CorpseLite marks where a hero died and what they left behind
+type CorpseLite struct {
+	HeroID string      `json:"heroId"`
+	Tile   TileAddress `json:"tile"`
+	Items  int         `json:"items"`
+	Gold   int         `json:"gold"`
+}
+
+// HouseRules lists the optional rules a game is played with; see RuleSet on the server
+type HouseRules struct {
+	DoubleDiceEffects      bool `json:"doubleDiceEffects"`
+	FixedMovementWhenClear bool `json:"fixedMovementWhenClear"`
+	NarrativeLineOfSight   bool `json:"narrativeLineOfSight"`
+	DelayedMonsterReveal   bool `json:"delayedMonsterReveal"`
+}
+
+// CampaignProgress is the party's way through the campaign's quests
+type CampaignProgress struct {
+	CampaignID     string              `json:"campaignId"`
+	Name           string              `json:"name"`
+	Quests         []CampaignQuestLite `json:"quests"`
+	CurrentQuestID string              `json:"currentQuestId,omitempty"`
+}
+
+// CampaignQuestLite is a campaign quest and how the party has fared in it
+type CampaignQuestLite struct {
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	Order    int    `json:"order"`
+	Status   string `json:"status"`   // "completed", "failed" or "unplayed"
+	Attempts int    `json:"attempts"` // Times the quest has been played to an end
+}
+
+// ArmouryLite is the armoury's stock, open to the heroes between quests
+type ArmouryLite struct {
+	Items []ArmouryItemLite `json:"items"`
+}
+
+// ArmouryItemLite is an equipment card for sale
+type ArmouryItemLite struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Cost     int      `json:"cost"`
+	UsableBy []string `json:"usableBy,omitempty"` // Hero classes; empty for every class
+}
+
+// GroundItemsLite lists items lying on a tile, such as a thrown dagger
+type GroundItemsLite struct {
+	Tile  TileAddress         `json:"tile"`
+	Items []InventoryItemLite `json:"items"`
+}
+
 type ActiveEffectLite struct {
 	Source     string `json:"source"`
 	EffectType string `json:"effectType"`
@@ -106,28 +187,38 @@ type LocationSearchSummary struct {
 }
 
 type Snapshot struct {
-	MapID             string                       `json:"mapId"`
-	PackID            string                       `json:"packId"`
-	Turn              int                          `json:"turn"`
-	LastEventID       int64                        `json:"lastEventId"`
-	MapWidth          int                          `json:"mapWidth"`
-	MapHeight         int                          `json:"mapHeight"`
-	RegionsCount      int                          `json:"regionsCount"`
-	TileRegionIDs     []int                        `json:"tileRegionIds"`
-	RevealedRegionIDs []int                        `json:"revealedRegionIds"`
-	DoorStates        []byte                       `json:"doorStates"`
-	Entities          []EntityLite                 `json:"entities"`
-	Thresholds        []ThresholdLite              `json:"thresholds"`
-	BlockingWalls     []BlockingWallLite           `json:"blockingWalls"`
-	Furniture         []FurnitureLite              `json:"furniture"`
-	Monsters          []MonsterLite                `json:"monsters"`
-	Variables         map[string]any               `json:"variables"`
-	HeroTurnStates    map[string]HeroTurnStateLite `json:"heroTurnStates"`
-	PlayerNames       map[string]string            `json:"playerNames,omitempty"` // Map of playerID -> player name from lobby
-	ProtocolVersion   string                       `json:"protocolVersion"`
-	VisibleRegionIDs  []int                        `json:"visibleRegionIds"`
-	CorridorRegionID  int                          `json:"corridorRegionId"`
-	KnownRegionIDs    []int                        `json:"knownRegionIds"`
+	MapID             string                         `json:"mapId"`
+	PackID            string                         `json:"packId"`
+	Turn              int                            `json:"turn"`
+	LastEventID       int64                          `json:"lastEventId"`
+	MapWidth          int                            `json:"mapWidth"`
+	MapHeight         int                            `json:"mapHeight"`
+	RegionsCount      int                            `json:"regionsCount"`
+	TileRegionIDs     []int                          `json:"tileRegionIds"`
+	RevealedRegionIDs []int                          `json:"revealedRegionIds"`
+	DoorStates        []byte                         `json:"doorStates"`
+	Entities          []EntityLite                   `json:"entities"`
+	Thresholds        []ThresholdLite                `json:"thresholds"`
+	BlockingWalls     []BlockingWallLite             `json:"blockingWalls"`
+	Furniture         []FurnitureLite                `json:"furniture"`
+	Monsters          []MonsterLite                  `json:"monsters"`
+	Variables         map[string]any                 `json:"variables"`
+	HeroTurnStates    map[string]HeroTurnStateLite   `json:"heroTurnStates"`
+	HeroStats         map[string]HeroStatsLite       `json:"heroStats,omitempty"` // heroID -> stats
+	Corpses           []CorpseLite                   `json:"corpses,omitempty"`
+	GroundItems       []GroundItemsLite              `json:"groundItems,omitempty"`
+	SearchedRooms     map[string]map[string][]string `json:"searchedRooms,omitempty"` // heroID -> "room-17" -> search types made this quest
+	HouseRules        HouseRules                     `json:"houseRules"`
+	SpellSelection    *SpellSelectionStateChanged    `json:"spellSelection,omitempty"` // Set when the quest has spellcasters
+	QuestEnded        *QuestEnded                    `json:"questEnded,omitempty"`     // Set once the quest is over
+	QuestProgress     []QuestObjectiveLite           `json:"questProgress,omitempty"`
+	Campaign          *CampaignProgress              `json:"campaign,omitempty"`    // Lobby mode only
+	Armoury           *ArmouryLite                   `json:"armoury,omitempty"`     // Set once the quest is over
+	PlayerNames       map[string]string              `json:"playerNames,omitempty"` // Map of playerID -> player name from lobby
+	ProtocolVersion   string                         `json:"protocolVersion"`
+	VisibleRegionIDs  []int                          `json:"visibleRegionIds"`
+	CorridorRegionID  int                            `json:"corridorRegionId"`
+	KnownRegionIDs    []int                          `json:"knownRegionIds"`
 
 	// Viewer identity - who is viewing this snapshot
 	ViewerPlayerID string `json:"viewerPlayerId"` // e.g., "player-abc123"
@@ -88,6 +88,26 @@ export function applyPatch(patch) {
       console.log('Game starting:', patch.payload);
       break;
 
+    case 'SessionLoaded':
+      // The whole game was replaced from a save; re-fetch the page snapshot
+      console.log('Session loaded:', patch.payload);
+      window.location.reload();
+      break;
+
+    case 'QuestStarted':
+      // The campaign moved on to another quest; re-fetch the page snapshot
+      console.log('Quest started:', patch.payload);
+      window.location.reload();
+      break;
+
+    case 'SessionSaved':
+      console.log('Session saved:', patch.payload.session);
+      break;
+
+    case 'SessionList':
+      console.log('Saved sessions:', patch.payload.sessions);
+      break;
+
     case 'TurnPhaseChanged':
       handleTurnPhaseChanged(patch);
       break;
@@ -128,6 +148,68 @@ export function applyPatch(patch) {
       handleQuestSetupStateChanged(patch);
       break;
 
+    case 'SpellSelectionStateChanged':
+      if (gameState.snapshot) {
+        gameState.snapshot.spellSelection = patch.payload;
+      }
+      console.log('Spell selection:', patch.payload);
+      break;
+
+    case 'DreadSpellCast':
+      console.log('Dread spell cast:', patch.payload);
+      break;
+
+    case 'TrapsRevealed':
+    case 'TrapTriggered':
+    case 'TrapDisarmed':
+      console.log(patch.type + ':', patch.payload);
+      break;
+
+    case 'HazardTriggered':
+    case 'WanderingMonsterAttacked':
+      console.log(patch.type + ':', patch.payload);
+      break;
+
+    case 'GroundItemsChanged':
+      console.log(patch.type + ':', patch.payload);
+      break;
+
+    case 'MonsterAttackDeclared':
+    case 'HeroDamaged':
+      console.log(patch.type + ':', patch.payload);
+      break;
+
+    case 'ItemUsed':
+      console.log(patch.type + ':', patch.payload);
+      break;
+
+    case 'TradeProposed':
+    case 'TradeCompleted':
+    case 'TradeDeclined':
+    case 'InventoryChanged':
+      console.log(patch.type + ':', patch.payload);
+      break;
+
+    case 'HeroDying':
+    case 'HeroRescued':
+    case 'HeroDied':
+    case 'CorpseLooted':
+    case 'QuestEnded':
+      console.log(patch.type + ':', patch.payload);
+      break;
+
+    case 'QuestProgressChanged':
+      console.log(patch.type + ':', patch.payload);
+      break;
+
+    case 'ArmouryTransaction':
+      console.log(patch.type + ':', patch.payload);
+      break;
+
+    case 'QuestTriggerFired':
+      console.log(patch.type + ':', patch.payload);
+      break;
+
     default:
       console.error('Unknown patch type:', patch.type);
   }